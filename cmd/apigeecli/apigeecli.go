@@ -15,8 +15,10 @@
 package main
 
 import (
+	"errors"
 	"fmt"
 	"internal/cmd"
+	"internal/cmd/plan"
 	"os"
 )
 
@@ -31,7 +33,13 @@ func main() {
 	rootCmd := cmd.GetRootCmd()
 	rootCmd.Version = fmt.Sprintf("%s date: %s [commit: %.7s]", version, date, commit)
 
-	if err := rootCmd.Execute(); err != nil {
+	ctx, stop := cmd.NotifyContext()
+	err := rootCmd.ExecuteContext(ctx)
+	stop()
+	if errors.Is(err, plan.ErrChanges) {
+		os.Exit(2)
+	}
+	if err != nil {
 		os.Exit(1)
 	}
 }
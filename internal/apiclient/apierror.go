@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// APIError is returned when the control plane responds with an error status.
+// Use errors.As to inspect it
+type APIError struct {
+	StatusCode int               // HTTP status code
+	Code       int               // google.rpc.Status code
+	Status     string            // google.rpc.Status status, for ex: ALREADY_EXISTS
+	Message    string            // google.rpc.Status message
+	Details    []json.RawMessage // google.rpc.Status details
+	Method     string            // request method
+	URL        string            // request url
+	Body       []byte            // raw response body
+}
+
+type rpcStatus struct {
+	Error struct {
+		Code    int               `json:"code,omitempty"`
+		Message string            `json:"message,omitempty"`
+		Status  string            `json:"status,omitempty"`
+		Details []json.RawMessage `json:"details,omitempty"`
+	} `json:"error,omitempty"`
+}
+
+// NewAPIError builds an APIError from a response and its body. The body is parsed
+// as a google.rpc.Status when possible
+func NewAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Body:       body,
+	}
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		apiErr.URL = resp.Request.URL.Redacted()
+	}
+	s := rpcStatus{}
+	if err := json.Unmarshal(body, &s); err == nil {
+		apiErr.Code = s.Error.Code
+		apiErr.Status = s.Error.Status
+		apiErr.Message = s.Error.Message
+		apiErr.Details = s.Error.Details
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return getErrorMessage(e.StatusCode)
+	}
+	return getErrorMessage(e.StatusCode) + ": " + e.Message
+}
+
+// IsStatus returns true if err is an APIError with the HTTP status code
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == statusCode
+	}
+	return false
+}
+
+// IsNotFound returns true if err is an APIError for a missing entity
+func IsNotFound(err error) bool {
+	return IsStatus(err, http.StatusNotFound)
+}
+
+// IsConflict returns true if err is an APIError for an entity that already exists
+func IsConflict(err error) bool {
+	return IsStatus(err, http.StatusConflict)
+}
@@ -0,0 +1,56 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAPIError(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprint(w, `{"error":{"code":409,"message":"APIProduct test already exists","status":"ALREADY_EXISTS",`+
+			`"details":[{"@type":"type.googleapis.com/google.rpc.RequestInfo","requestId":"1"}]}}`)
+	}))
+	defer ts.Close()
+
+	NewApigeeClient(ApigeeClientOptions{NoOutput: true})
+	SetApigeeToken("test")
+
+	_, err := HttpClient(ts.URL, "{}")
+	wrapped := fmt.Errorf("product not imported: %w", err)
+
+	var apiErr *APIError
+	if !errors.As(wrapped, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.StatusCode != http.StatusConflict || apiErr.Code != 409 || apiErr.Status != "ALREADY_EXISTS" {
+		t.Fatalf("unexpected status in %+v", apiErr)
+	}
+	if apiErr.Method != http.MethodPost || apiErr.URL != ts.URL {
+		t.Fatalf("unexpected request in %+v", apiErr)
+	}
+	if apiErr.Message != "APIProduct test already exists" || len(apiErr.Details) != 1 {
+		t.Fatalf("unexpected message or details in %+v", apiErr)
+	}
+	if !IsConflict(wrapped) || IsNotFound(wrapped) {
+		t.Fatalf("IsConflict or IsNotFound returned the wrong result")
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"sync"
 )
@@ -169,6 +170,11 @@ func GetAsyncEntity(entityURL string, wg *sync.WaitGroup, mu *sync.Mutex) {
 	ClientPrintHttpResponse.Set(false)
 	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
 	respBody, err := HttpClient(entityURL)
+	if err != nil && Canceled() {
+		// the command was interrupted, let the caller stop
+		clilog.Debug.Printf("Canceled entity: %s", entityURL)
+		return
+	}
 	if err != nil {
 		clilog.Error.Fatalf("error with entity: %s", entityURL)
 		clilog.Error.Println(err)
@@ -215,6 +221,9 @@ func FetchBundle(entityType string, folder string, name string, revision string,
 	}
 
 	err := DownloadResource(u.String(), proxyName, ".zip", true)
+	if err != nil && Canceled() {
+		return err
+	}
 	if err != nil {
 		clilog.Error.Fatalf("error with entity: %s", name)
 		clilog.Error.Println(err)
@@ -228,6 +237,44 @@ func FetchBundle(entityType string, folder string, name string, revision string,
 	return nil
 }
 
+// DownloadBundle downloads a revision of an API proxy or sharedflow to a zip file in a folder.
+// When the revision is empty, the revision deployed to the environment is downloaded
+func DownloadBundle(entityType string, folder string, name string, revision string) (fileName string, err error) {
+	if revision == "" {
+		if revision, err = deployedBundleRevision(entityType, name); err != nil {
+			return "", err
+		}
+	}
+	u, _ := url.Parse(GetApigeeBaseURL())
+	q := u.Query()
+	q.Set("format", "bundle")
+	u.RawQuery = q.Encode()
+	u.Path = path.Join(u.Path, GetApigeeOrg(), entityType, name, "revisions", revision)
+
+	fileName = path.Join(folder, name+utils.DefaultFileSplitter+revision)
+	if err = DownloadResource(u.String(), fileName, ".zip", true); err != nil {
+		return "", err
+	}
+	return fileName + ".zip", nil
+}
+
+// deployedBundleRevision returns the revision of an API proxy or sharedflow deployed to the environment.
+// An error is returned when several revisions are deployed, during a rollout for ex
+func deployedBundleRevision(entityType string, name string) (string, error) {
+	revisions, err := DeployedRevisions(entityType, GetApigeeEnv(), name)
+	if err != nil {
+		return "", err
+	}
+	switch len(revisions) {
+	case 0:
+		return "", fmt.Errorf("%s is not deployed to the environment %s", name, GetApigeeEnv())
+	case 1:
+		return strconv.Itoa(revisions[0]), nil
+	}
+	return "", fmt.Errorf("%s has %d revisions deployed to the environment %s, a revision must be set",
+		name, len(revisions), GetApigeeEnv())
+}
+
 // ImportBundleAsync imports a sharedflow or api proxy bundle meantot be called asynchronously
 func ImportBundleAsync(entityType string, name string, bundlePath string, space string, wg *sync.WaitGroup) {
 	defer wg.Done()
@@ -0,0 +1,57 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDeployedBundleRevision(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/organizations/test/environments/test/apis/single/deployments":
+			fmt.Fprint(w, `{"deployments":[{"environment":"test","apiProxy":"single","revision":"3"}]}`)
+		case "/v1/organizations/test/environments/test/apis/rollout/deployments":
+			fmt.Fprint(w, `{"deployments":[{"environment":"test","apiProxy":"rollout","revision":"3"},
+				{"environment":"test","apiProxy":"rollout","revision":"4"}]}`)
+		default:
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer ts.Close()
+
+	NewApigeeClient(ApigeeClientOptions{NoOutput: true})
+	SetApigeeToken("test")
+	SetApigeeBaseURL(ts.URL + "/v1/organizations/")
+	defer SetApigeeBaseURL("")
+	_ = SetApigeeOrg("test")
+	SetApigeeEnv("test")
+
+	if revision, err := deployedBundleRevision("apis", "single"); err != nil || revision != "3" {
+		t.Fatalf("expected revision 3, got %s %v", revision, err)
+	}
+	if _, err := deployedBundleRevision("apis", "rollout"); err == nil ||
+		!strings.Contains(err.Error(), "has 2 revisions deployed") {
+		t.Fatalf("expected an error for several deployed revisions, got %v", err)
+	}
+	if _, err := deployedBundleRevision("apis", "undeployed"); err == nil ||
+		!strings.Contains(err.Error(), "is not deployed") {
+		t.Fatalf("expected an error for an undeployed proxy, got %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+var (
+	rootCtx        = context.Background()
+	rootCtxMu      sync.RWMutex
+	requestTimeout time.Duration
+)
+
+// SetContext sets the context used by requests that are not sent with one.
+// Canceling it stops in-flight requests and parallel imports and exports
+func SetContext(ctx context.Context) {
+	rootCtxMu.Lock()
+	defer rootCtxMu.Unlock()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	rootCtx = ctx
+}
+
+// GetContext returns the context set with SetContext
+func GetContext() context.Context {
+	rootCtxMu.RLock()
+	defer rootCtxMu.RUnlock()
+	return rootCtx
+}
+
+// Canceled returns true when the context set with SetContext is done
+func Canceled() bool {
+	return GetContext().Err() != nil
+}
+
+// SetRequestTimeout sets the time limit for each request, 0 means no limit
+func SetRequestTimeout(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	requestTimeout = d
+}
+
+// GetRequestTimeout
+func GetRequestTimeout() time.Duration {
+	return requestTimeout
+}
@@ -0,0 +1,210 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/clilog"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DeploymentTarget is a proxy or sharedflow revision deployed to an environment
+type DeploymentTarget struct {
+	EntityType  string `json:"-"` // apis or sharedflows
+	Name        string `json:"name"`
+	Revision    int    `json:"revision"`
+	Environment string `json:"environment"`
+}
+
+// DeploymentStatus is the state of a deployment, with the errors and the revisions
+// of each instance reported by the control plane
+type DeploymentStatus struct {
+	DeploymentTarget
+	State     string               `json:"state,omitempty"`
+	Errors    []DeploymentError    `json:"errors,omitempty"`
+	Instances []DeploymentInstance `json:"instances,omitempty"`
+}
+
+// DeploymentError is an error reported for a deployment
+type DeploymentError struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// DeploymentInstance holds the revisions deployed to a runtime instance
+type DeploymentInstance struct {
+	Instance          string `json:"instance,omitempty"`
+	DeployedRevisions []struct {
+		Revision   string `json:"revision,omitempty"`
+		Percentage int    `json:"percentage,omitempty"`
+	} `json:"deployedRevisions,omitempty"`
+}
+
+const progressing = "PROGRESSING"
+
+// WaitForDeployments polls the deployments every interval until none is PROGRESSING.
+// A timeout of 0 waits until the deployments complete. An error is returned when a
+// deployment is not READY, the timeout expires or the context is canceled
+func WaitForDeployments(targets []DeploymentTarget, interval time.Duration,
+	timeout time.Duration,
+) (statuses []DeploymentStatus, err error) {
+	ClientPrintHttpResponse.Set(false)
+	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
+
+	statuses = make([]DeploymentStatus, len(targets))
+	for i, target := range targets {
+		statuses[i] = DeploymentStatus{DeploymentTarget: target, State: progressing}
+	}
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for pending := len(statuses); pending > 0; {
+		select {
+		case <-GetContext().Done():
+			return statuses, fmt.Errorf("deployment wait interrupted: %w", GetContext().Err())
+		case <-expired:
+			return statuses, fmt.Errorf("%d deployments did not complete within %s", pending, timeout)
+		case <-ticker.C:
+		}
+		pending = 0
+		for i := range statuses {
+			if statuses[i].State != progressing {
+				continue
+			}
+			if err = statuses[i].refresh(); err != nil {
+				return statuses, fmt.Errorf("error fetching %s status: %w", statuses[i].kind(), err)
+			}
+			statuses[i].log(interval)
+			if statuses[i].State == progressing {
+				pending++
+			}
+		}
+	}
+
+	errs := []error{}
+	for _, s := range statuses {
+		if s.State != "READY" {
+			errs = append(errs, fmt.Errorf("%s deployment failed with status: %s", s.kind(), s.State))
+		}
+	}
+	return statuses, errors.Join(errs...)
+}
+
+// DeployedRevisions returns the revisions of a proxy or sharedflow deployed to an environment
+func DeployedRevisions(entityType string, environment string, name string) (revisions []int, err error) {
+	ClientPrintHttpResponse.Set(false)
+	defer ClientPrintHttpResponse.Set(GetCmdPrintHttpResponseSetting())
+
+	u, _ := url.Parse(GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, GetApigeeOrg(), "environments", environment, entityType, name, "deployments")
+	respBody, err := HttpClient(u.String())
+	if err != nil {
+		return nil, err
+	}
+	l := struct {
+		Deployments []struct {
+			Revision string `json:"revision,omitempty"`
+		} `json:"deployments,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	for _, d := range l.Deployments {
+		if revision, err := strconv.Atoi(d.Revision); err == nil {
+			revisions = append(revisions, revision)
+		}
+	}
+	return revisions, nil
+}
+
+// DeploymentSummary returns a line per deployment and the number that are READY
+func DeploymentSummary(statuses []DeploymentStatus) string {
+	var b strings.Builder
+	ready := 0
+	for _, s := range statuses {
+		fmt.Fprintf(&b, "%-12s %s\n", s.State, s.kind())
+		if s.State == "READY" {
+			ready++
+		}
+	}
+	fmt.Fprintf(&b, "\n%d of %d deployments are READY\n", ready, len(statuses))
+	return b.String()
+}
+
+func (s *DeploymentStatus) refresh() error {
+	u, _ := url.Parse(GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, GetApigeeOrg(), "environments", s.Environment, s.EntityType,
+		s.Name, "revisions", strconv.Itoa(s.Revision), "deployments")
+	respBody, err := HttpClient(u.String())
+	if err != nil {
+		return err
+	}
+	// the API returns the revision as a string, it is left out of the decoded fields
+	d := struct {
+		State     string               `json:"state,omitempty"`
+		Errors    []DeploymentError    `json:"errors,omitempty"`
+		Instances []DeploymentInstance `json:"instances,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &d); err != nil {
+		return err
+	}
+	s.State, s.Errors, s.Instances = d.State, d.Errors, d.Instances
+	if s.State == "" {
+		s.State = progressing
+	}
+	return nil
+}
+
+func (s *DeploymentStatus) log(interval time.Duration) {
+	switch s.State {
+	case progressing:
+		clilog.Info.Printf("%s deployment status is: %s. Waiting %s.\n", s.kind(), s.State, interval)
+	case "READY":
+		clilog.Info.Printf("%s deployment completed with status: %s\n", s.kind(), s.State)
+	default:
+		clilog.Error.Printf("%s deployment failed with status: %s\n", s.kind(), s.State)
+		for _, e := range s.Errors {
+			clilog.Error.Printf("\terror %d: %s\n", e.Code, e.Message)
+		}
+		for _, instance := range s.Instances {
+			revisions := []string{}
+			for _, r := range instance.DeployedRevisions {
+				revisions = append(revisions, fmt.Sprintf("revision %s at %d%%", r.Revision, r.Percentage))
+			}
+			clilog.Error.Printf("\tinstance %s: %s\n", instance.Instance, strings.Join(revisions, ", "))
+		}
+	}
+}
+
+func (s DeploymentStatus) kind() string {
+	entity := "Proxy"
+	if s.EntityType == "sharedflows" {
+		entity = "Sharedflow"
+	}
+	return fmt.Sprintf("%s %s revision %d in %s", entity, s.Name, s.Revision, s.Environment)
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWaitForDeployments(t *testing.T) {
+	polls := map[string]int{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		polls[r.URL.Path]++
+		switch {
+		case strings.Contains(r.URL.Path, "/apis/ready/") && polls[r.URL.Path] > 1:
+			fmt.Fprint(w, `{"environment":"test","apiProxy":"ready","revision":"2",
+				"deployStartTime":"1760770000000","state":"READY",
+				"instances":[{"instance":"i1","deployedRevisions":[{"revision":"2","percentage":100}]}]}`)
+		case strings.Contains(r.URL.Path, "/sharedflows/broken/"):
+			fmt.Fprint(w, `{"environment":"test","apiProxy":"broken","revision":"1",
+				"deployStartTime":"1760770000000","state":"ERROR","errors":[{"code":400,"message":"bad policy"}],
+				"instances":[{"instance":"i1","deployedRevisions":[{"revision":"1","percentage":100}]}]}`)
+		case strings.Contains(r.URL.Path, "/apis/invalid/"):
+			fmt.Fprint(w, `<html>Service Unavailable</html>`)
+		default:
+			fmt.Fprint(w, `{"environment":"test","apiProxy":"stuck","revision":"1",
+				"deployStartTime":"1760770000000","state":"PROGRESSING"}`)
+		}
+	}))
+	defer ts.Close()
+
+	NewApigeeClient(ApigeeClientOptions{NoOutput: true})
+	SetApigeeToken("test")
+	SetApigeeBaseURL(ts.URL + "/v1/organizations/")
+	defer SetApigeeBaseURL("")
+	_ = SetApigeeOrg("test")
+
+	statuses, err := WaitForDeployments([]DeploymentTarget{
+		{EntityType: "apis", Name: "ready", Revision: 2, Environment: "test"},
+		{EntityType: "sharedflows", Name: "broken", Revision: 1, Environment: "test"},
+	}, time.Millisecond, 0)
+	if err == nil || !strings.Contains(err.Error(), "Sharedflow broken revision 1 in test") {
+		t.Fatalf("expected the sharedflow deployment to fail, got %v", err)
+	}
+	if statuses[0].State != "READY" || statuses[1].State != "ERROR" || statuses[1].Errors[0].Message != "bad policy" {
+		t.Fatalf("unexpected statuses %+v", statuses)
+	}
+	if summary := DeploymentSummary(statuses); !strings.Contains(summary, "1 of 2 deployments are READY") {
+		t.Fatalf("unexpected summary %s", summary)
+	}
+
+	_, err = WaitForDeployments([]DeploymentTarget{
+		{EntityType: "apis", Name: "stuck", Revision: 1, Environment: "test"},
+	}, time.Millisecond, 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "did not complete within") {
+		t.Fatalf("expected a timeout, got %v", err)
+	}
+
+	_, err = WaitForDeployments([]DeploymentTarget{
+		{EntityType: "apis", Name: "invalid", Revision: 1, Environment: "test"},
+	}, time.Millisecond, 0)
+	if err == nil || !strings.Contains(err.Error(), "error fetching Proxy invalid revision 1 in test status") {
+		t.Fatalf("expected an error for an invalid response, got %v", err)
+	}
+}
@@ -57,6 +57,13 @@ var noAPIRateLimit = rate.NewLimiter(rate.Inf, 1)
 
 // PostHttpZip method is used to archives to Apigee control plane.
 func PostHttpZip(auth bool, method string, url string, headers map[string]string, zipfile string) (err error) {
+	return PostHttpZipWithContext(GetContext(), auth, method, url, headers, zipfile)
+}
+
+// PostHttpZipWithContext is PostHttpZip with a context to cancel the request
+func PostHttpZipWithContext(ctx context.Context, auth bool, method string, url string,
+	headers map[string]string, zipfile string,
+) (err error) {
 	var req *http.Request
 
 	payload, err := os.ReadFile(zipfile)
@@ -74,7 +81,7 @@ func PostHttpZip(auth bool, method string, url string, headers map[string]string
 	}
 
 	clilog.Debug.Println("Connecting to : ", url)
-	req, err = http.NewRequest(method, url, bytes.NewBuffer(payload))
+	req, err = http.NewRequestWithContext(ctx, method, url, bytes.NewBuffer(payload))
 	if err != nil {
 		clilog.Error.Println("error in client: ", err)
 		return err
@@ -110,6 +117,13 @@ func PostHttpZip(auth bool, method string, url string, headers map[string]string
 
 // PostHttpOctet method is used to send resources, proxy bundles, shared flows etc.
 func PostHttpOctet(update bool, url string, formParams map[string]string) (respBody []byte, err error) {
+	return PostHttpOctetWithContext(GetContext(), update, url, formParams)
+}
+
+// PostHttpOctetWithContext is PostHttpOctet with a context to cancel the request
+func PostHttpOctetWithContext(ctx context.Context, update bool, url string,
+	formParams map[string]string,
+) (respBody []byte, err error) {
 	body := &bytes.Buffer{}
 	writer := multipart.NewWriter(body)
 
@@ -156,9 +170,9 @@ func PostHttpOctet(update bool, url string, formParams map[string]string) (respB
 
 	clilog.Debug.Println("Connecting to : ", url)
 	if !update {
-		req, err = http.NewRequest("POST", url, body)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 	} else {
-		req, err = http.NewRequest("PUT", url, body)
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, url, body)
 	}
 
 	if err != nil {
@@ -181,7 +195,13 @@ func PostHttpOctet(update bool, url string, formParams map[string]string) (respB
 	return handleResponse(resp)
 }
 
+// DownloadFile sends a GET request and returns the response for the caller to read
 func DownloadFile(url string, auth bool) (resp *http.Response, err error) {
+	return DownloadFileWithContext(GetContext(), url, auth)
+}
+
+// DownloadFileWithContext is DownloadFile with a context to cancel the request
+func DownloadFileWithContext(ctx context.Context, url string, auth bool) (resp *http.Response, err error) {
 	err = GetHttpClient()
 	if err != nil {
 		return nil, err
@@ -192,7 +212,7 @@ func DownloadFile(url string, auth bool) (resp *http.Response, err error) {
 	}
 
 	clilog.Debug.Println("Connecting to : ", url)
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		clilog.Error.Println("error in client: ", err)
 		return nil, err
@@ -211,8 +231,10 @@ func DownloadFile(url string, auth bool) (resp *http.Response, err error) {
 		clilog.Error.Println("error connecting: ", err)
 		return nil, err
 	} else if resp.StatusCode > 299 {
-		clilog.Error.Printf("error in response, status %d: %s", resp.StatusCode, resp.Body)
-		return nil, errors.New("error in response")
+		defer resp.Body.Close()
+		respBody, _ := io.ReadAll(resp.Body)
+		clilog.Error.Printf("error in response, status %d: %s", resp.StatusCode, string(respBody))
+		return nil, NewAPIError(resp, respBody)
 	}
 
 	if resp == nil {
@@ -246,6 +268,8 @@ func DownloadResource(url string, name string, resType string, auth bool) error
 
 	resp, err := DownloadFile(url, auth)
 	if err != nil {
+		out.Close()
+		_ = os.Remove(filename)
 		return err
 	}
 
@@ -256,6 +280,9 @@ func DownloadResource(url string, name string, resType string, auth bool) error
 	_, err = io.Copy(out, resp.Body)
 	if err != nil {
 		clilog.Error.Println("error writing response to file: ", err)
+		// do not leave a partial file behind
+		out.Close()
+		_ = os.Remove(filename)
 		return err
 	}
 
@@ -265,6 +292,11 @@ func DownloadResource(url string, name string, resType string, auth bool) error
 
 // HttpClient method is used to GET,POST,PUT or DELETE JSON data
 func HttpClient(params ...string) (respBody []byte, err error) {
+	return HttpClientWithContext(GetContext(), params...)
+}
+
+// HttpClientWithContext is HttpClient with a context to cancel the request
+func HttpClientWithContext(ctx context.Context, params ...string) (respBody []byte, err error) {
 	// The first parameter instructs whether the output should be printed
 	// The second parameter is url. If only one parameter is sent, assume GET
 	// The third parameter is the payload. The two parameters are sent, assume POST
@@ -281,7 +313,7 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 	switch paramLen := len(params); paramLen {
 	case 1:
 		clilog.Debug.Printf("Connecting to: %s - %s", http.MethodGet, params[0])
-		req, err = http.NewRequest(http.MethodGet, params[0], nil)
+		req, err = http.NewRequestWithContext(ctx, http.MethodGet, params[0], nil)
 	case 2:
 		clilog.Debug.Printf("Connecting to: %s - %s", http.MethodPost, params[0])
 		payload := []byte(params[1])
@@ -296,13 +328,13 @@ func HttpClient(params ...string) (respBody []byte, err error) {
 				clilog.Debug.Println("Payload: ", string(jsonPayload))
 			}
 		}
-		req, err = http.NewRequest(http.MethodPost, params[0], bytes.NewBuffer([]byte(params[1])))
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, params[0], bytes.NewBuffer([]byte(params[1])))
 	case 3:
-		if req, err = getRequest(params); err != nil {
+		if req, err = getRequest(ctx, params); err != nil {
 			return nil, err
 		}
 	case 4:
-		if req, err = getRequest(params); err != nil {
+		if req, err = getRequest(ctx, params); err != nil {
 			return nil, err
 		}
 		contentType = params[3]
@@ -364,19 +396,42 @@ func PrettifyJSON(body []byte) ([]byte, error) {
 	return prettyJSON.Bytes(), nil
 }
 
-// Do the HTTP request
+// Do the HTTP request, retrying on throttling and transient errors
 func (c *RateLimitedHTTPClient) Do(req *http.Request) (*http.Response, error) {
-	ctx := context.Background()
-	// Wait until the rate is below Apigee limits
-	err := c.Ratelimiter.Wait(ctx)
-	if err != nil {
-		return nil, err
-	}
-	resp, err := c.client.Do(req)
-	if err != nil {
-		return nil, err
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		// Wait until the rate is below Apigee limits
+		err := c.Ratelimiter.Wait(ctx)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.client.Do(req)
+		if attempt >= GetMaxAttempts() || !shouldRetry(req, resp, err) {
+			if err != nil {
+				return nil, err
+			}
+			return resp, nil
+		}
+
+		delay := retryDelay(attempt, resp)
+		if err != nil {
+			clilog.Warning.Printf("attempt %d of %d for %s %s failed: %v, retrying in %s\n",
+				attempt, GetMaxAttempts(), req.Method, req.URL.Redacted(), err, delay)
+		} else {
+			clilog.Warning.Printf("attempt %d of %d for %s %s returned %d, retrying in %s\n",
+				attempt, GetMaxAttempts(), req.Method, req.URL.Redacted(), resp.StatusCode, delay)
+		}
+		drainBody(resp)
+
+		if err = rewindBody(req); err != nil {
+			return nil, err
+		}
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
-	return resp, nil
 }
 
 // GetHttpClient returns new http client with a rate limiter
@@ -401,12 +456,18 @@ func GetHttpClient() (err error) {
 					Transport: &http.Transport{
 						Proxy: http.ProxyURL(proxyUrl),
 					},
+					Timeout: GetRequestTimeout(),
 				},
 				Ratelimiter: apiRateLimit,
 			}
 		} else {
 			return err
 		}
+	} else if GetRequestTimeout() > 0 {
+		ApigeeAPIClient = &RateLimitedHTTPClient{
+			client:      &http.Client{Timeout: GetRequestTimeout()},
+			Ratelimiter: apiRateLimit,
+		}
 	} else {
 		ApigeeAPIClient = &RateLimitedHTTPClient{
 			client:      http.DefaultClient,
@@ -416,19 +477,19 @@ func GetHttpClient() (err error) {
 	return nil
 }
 
-func getRequest(params []string) (req *http.Request, err error) {
+func getRequest(ctx context.Context, params []string) (req *http.Request, err error) {
 	clilog.Debug.Printf("Connecting to: %s - %s", params[2], params[0])
 	if params[2] == "DELETE" {
-		req, err = http.NewRequest(http.MethodDelete, params[0], nil)
+		req, err = http.NewRequestWithContext(ctx, http.MethodDelete, params[0], nil)
 	} else if params[2] == "PUT" {
 		clilog.Debug.Println("Payload: ", params[1])
-		req, err = http.NewRequest(http.MethodPut, params[0], bytes.NewBuffer([]byte(params[1])))
+		req, err = http.NewRequestWithContext(ctx, http.MethodPut, params[0], bytes.NewBuffer([]byte(params[1])))
 	} else if params[2] == "PATCH" {
 		clilog.Debug.Println("Payload: ", params[1])
-		req, err = http.NewRequest(http.MethodPatch, params[0], bytes.NewBuffer([]byte(params[1])))
+		req, err = http.NewRequestWithContext(ctx, http.MethodPatch, params[0], bytes.NewBuffer([]byte(params[1])))
 	} else if params[2] == "POST" {
 		clilog.Debug.Println("Payload: ", params[1])
-		req, err = http.NewRequest(http.MethodPost, params[0], bytes.NewBuffer([]byte(params[1])))
+		req, err = http.NewRequestWithContext(ctx, http.MethodPost, params[0], bytes.NewBuffer([]byte(params[1])))
 	} else {
 		return nil, errors.New("unsupported method")
 	}
@@ -464,7 +525,7 @@ func handleResponse(resp *http.Response) (respBody []byte, err error) {
 	} else if resp.StatusCode > 399 {
 		clilog.Debug.Printf("status code %d, error in response: %s\n", resp.StatusCode, string(respBody))
 		clilog.HttpError.Println(string(respBody))
-		return nil, errors.New(getErrorMessage(resp.StatusCode))
+		return nil, NewAPIError(resp, respBody)
 	}
 	clilog.Debug.Println("Response: ", string(respBody))
 	return respBody, PrettyPrint(resp.Header.Get("Content-Type"), respBody)
@@ -0,0 +1,193 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"internal/clilog"
+	"internal/cmd/utils"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"sync"
+)
+
+// ManifestFileName is the name of the manifest written in an export folder
+const ManifestFileName = "manifest.json"
+
+// Manifest records the bundles written to an export folder, so that a later
+// export only downloads new or changed revisions
+type Manifest struct {
+	Bundles map[string]BundleRecord `json:"bundles"` // keyed by the bundle file name
+
+	folder string
+	mu     sync.Mutex
+	seen   map[string]bool
+}
+
+// BundleRecord is the revision, last modified time and content hash of an exported bundle
+type BundleRecord struct {
+	Name           string `json:"name"`
+	Revision       string `json:"revision"`
+	LastModifiedAt string `json:"lastModifiedAt"`
+	SHA256         string `json:"sha256"`
+}
+
+// ReadManifest reads the manifest of an export folder; an empty manifest is
+// returned when the folder has none
+func ReadManifest(folder string) (*Manifest, error) {
+	m := &Manifest{
+		Bundles: map[string]BundleRecord{},
+		folder:  folder,
+		seen:    map[string]bool{},
+	}
+	byteValue, err := os.ReadFile(path.Join(folder, ManifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return m, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal(byteValue, m); err != nil {
+		return nil, err
+	}
+	if m.Bundles == nil {
+		m.Bundles = map[string]BundleRecord{}
+	}
+	return m, nil
+}
+
+// Write saves the manifest in the export folder
+func (m *Manifest) Write() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	byteValue, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return WriteByteArrayToFile(path.Join(m.folder, ManifestFileName), false, byteValue)
+}
+
+// unchanged returns true when the bundle file matches the recorded revision,
+// last modified time and hash. The bundle is marked as seen
+func (m *Manifest) unchanged(fileName string, revision string, lastModifiedAt string) bool {
+	m.mu.Lock()
+	m.seen[fileName] = true
+	record, ok := m.Bundles[fileName]
+	m.mu.Unlock()
+
+	if !ok || record.Revision != revision || record.LastModifiedAt != lastModifiedAt {
+		return false
+	}
+	sum, err := hashFile(path.Join(m.folder, fileName))
+	return err == nil && sum == record.SHA256
+}
+
+func (m *Manifest) record(fileName string, name string, revision string, lastModifiedAt string) error {
+	sum, err := hashFile(path.Join(m.folder, fileName))
+	if err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.Bundles[fileName] = BundleRecord{
+		Name:           name,
+		Revision:       revision,
+		LastModifiedAt: lastModifiedAt,
+		SHA256:         sum,
+	}
+	return nil
+}
+
+// Prune deletes the bundle files of entities or revisions that were not part of
+// this export, and removes them from the manifest
+func (m *Manifest) Prune() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fileNames := []string{}
+	for fileName := range m.Bundles {
+		if !m.seen[fileName] {
+			fileNames = append(fileNames, fileName)
+		}
+	}
+	sort.Strings(fileNames)
+	for _, fileName := range fileNames {
+		clilog.Info.Printf("Removing %s, it no longer exists in the org\n", fileName)
+		if err := os.Remove(path.Join(m.folder, fileName)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+		delete(m.Bundles, fileName)
+	}
+	return nil
+}
+
+func hashFile(filePath string) (string, error) {
+	f, err := os.Open(filePath)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err = io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// BundleFileName returns the name of the file FetchBundle writes
+func BundleFileName(name string, revision string, allRevisions bool) string {
+	if allRevisions {
+		return name + utils.DefaultFileSplitter + revision + ".zip"
+	}
+	return name + ".zip"
+}
+
+// FetchChangedBundle downloads a bundle only when the revision or its last modified
+// time differs from the manifest. A nil manifest always downloads the bundle
+func FetchChangedBundle(entityType string, folder string, name string, revision string,
+	allRevisions bool, m *Manifest,
+) error {
+	if m == nil {
+		return FetchBundle(entityType, folder, name, revision, allRevisions)
+	}
+
+	u, _ := url.Parse(GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, GetApigeeOrg(), entityType, name, "revisions", revision)
+	respBody, err := HttpClient(u.String())
+	if err != nil {
+		return err
+	}
+	rev := struct {
+		LastModifiedAt string `json:"lastModifiedAt,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &rev); err != nil {
+		return err
+	}
+
+	fileName := BundleFileName(name, revision, allRevisions)
+	if m.unchanged(fileName, revision, rev.LastModifiedAt) {
+		clilog.Debug.Printf("Skipping %s, revision %s is unchanged\n", name, revision)
+		return nil
+	}
+	if err = FetchBundle(entityType, folder, name, revision, allRevisions); err != nil || DryRun() {
+		return err
+	}
+	return m.record(fileName, name, revision, rev.LastModifiedAt)
+}
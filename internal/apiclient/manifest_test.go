@@ -0,0 +1,85 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestFetchChangedBundle(t *testing.T) {
+	downloads := 0
+	lastModifiedAt := "1"
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("format") == "bundle" {
+			downloads++
+			fmt.Fprint(w, "bundle "+lastModifiedAt)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"test","revision":"1","lastModifiedAt":"%s"}`, lastModifiedAt)
+	}))
+	defer ts.Close()
+
+	NewApigeeClient(ApigeeClientOptions{NoOutput: true})
+	SetApigeeToken("test")
+	SetApigeeBaseURL(ts.URL + "/v1/organizations/")
+	defer SetApigeeBaseURL("")
+	_ = SetApigeeOrg("test")
+
+	folder := t.TempDir()
+	wd, _ := os.Getwd()
+	defer os.Chdir(wd)
+	_ = os.Chdir(folder)
+
+	fetch := func() {
+		m, err := ReadManifest(folder)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = FetchChangedBundle("apis", folder, "test", "1", false, m); err != nil {
+			t.Fatal(err)
+		}
+		if err = m.Prune(); err != nil {
+			t.Fatal(err)
+		}
+		if err = m.Write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	fetch()
+	fetch()
+	if downloads != 1 {
+		t.Fatalf("expected an unchanged bundle to be skipped, got %d downloads", downloads)
+	}
+
+	lastModifiedAt = "2"
+	fetch()
+	if downloads != 2 {
+		t.Fatalf("expected a changed bundle to be downloaded, got %d downloads", downloads)
+	}
+
+	m, _ := ReadManifest(folder)
+	if err := m.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(path.Join(folder, "test.zip")); !os.IsNotExist(err) {
+		t.Fatalf("expected the bundle of a deleted proxy to be removed")
+	}
+}
@@ -70,6 +70,9 @@ const (
 
 var apiRate Rate
 
+// baseURLOverride replaces the Apigee control plane endpoint when set
+var baseURLOverride string
+
 type API string
 
 const (
@@ -348,8 +351,17 @@ func GetAPIObserveURL() (apiObserveURL string) {
 	return fmt.Sprintf(apiObserveBaseURL, options.ProjectID, options.Region)
 }
 
+// SetApigeeBaseURL overrides the Apigee control plane endpoint, for ex: with a fake control plane
+// in tests. An empty string restores the default endpoint
+func SetApigeeBaseURL(u string) {
+	baseURLOverride = u
+}
+
 // GetApigeeBaseURL
 func GetApigeeBaseURL() string {
+	if baseURLOverride != "" {
+		return baseURLOverride
+	}
 	if options.Region != "" {
 		return fmt.Sprintf(baseDRZURL, options.Region)
 	}
@@ -0,0 +1,148 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultMaxAttempts is the number of times a request is sent before giving up
+const DefaultMaxAttempts = 3
+
+// DefaultMaxRetryWait is the longest apigeecli will wait between two attempts
+const DefaultMaxRetryWait = 30 * time.Second
+
+var (
+	maxAttempts    = DefaultMaxAttempts
+	maxRetryWait   = DefaultMaxRetryWait
+	retryBaseDelay = time.Second
+)
+
+// SetMaxAttempts sets the max number of attempts per request, 1 disables retries
+func SetMaxAttempts(n int) {
+	if n < 1 {
+		n = 1
+	}
+	maxAttempts = n
+}
+
+// GetMaxAttempts
+func GetMaxAttempts() int {
+	return maxAttempts
+}
+
+// SetMaxRetryWait sets the upper bound for the wait between attempts
+func SetMaxRetryWait(d time.Duration) {
+	if d < 0 {
+		d = 0
+	}
+	maxRetryWait = d
+}
+
+// GetMaxRetryWait
+func GetMaxRetryWait() time.Duration {
+	return maxRetryWait
+}
+
+// isIdempotent returns true for methods that can be safely sent more than once
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// shouldRetry decides if a request should be sent again. Idempotent requests are
+// retried on connection errors, 429 and 5xx responses. Other requests are only
+// retried on 429, since the server did not process them.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	// the body cannot be sent again
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if err != nil {
+		return isIdempotent(req.Method)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotent(req.Method)
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt. The Retry-After
+// header is honored when present, else exponential backoff with jitter is used.
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	var delay time.Duration
+	if d, ok := parseRetryAfter(resp); ok {
+		delay = d
+	} else {
+		backoff := retryBaseDelay << (attempt - 1)
+		// wait between half and the full backoff
+		delay = backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+	}
+	if delay > maxRetryWait {
+		delay = maxRetryWait
+	}
+	return delay
+}
+
+// parseRetryAfter reads the Retry-After header, in seconds or as a http date
+func parseRetryAfter(resp *http.Response) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if t, err := http.ParseTime(retryAfter); err == nil {
+		d := time.Until(t)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// rewindBody resets the request body so it can be sent again
+func rewindBody(req *http.Request) (err error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil
+	}
+	req.Body, err = req.GetBody()
+	return err
+}
+
+// drainBody reads and closes the response so the connection can be reused
+func drainBody(resp *http.Response) {
+	if resp == nil || resp.Body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+	resp.Body.Close()
+}
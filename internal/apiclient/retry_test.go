@@ -0,0 +1,144 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apiclient
+
+import (
+	"bytes"
+	"context"
+	"internal/clilog"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestClient() *RateLimitedHTTPClient {
+	clilog.Init(false, false, false, true)
+	retryBaseDelay = time.Millisecond
+	return &RateLimitedHTTPClient{
+		client:      http.DefaultClient,
+		Ratelimiter: noAPIRateLimit,
+	}
+}
+
+func TestRetryOnUnavailable(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		body, _ := io.ReadAll(r.Body)
+		if string(body) != "payload" {
+			t.Errorf("attempt %d received body %q", calls, string(body))
+		}
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	SetMaxAttempts(3)
+	req, _ := http.NewRequest(http.MethodPut, ts.URL, bytes.NewBufferString("payload"))
+	resp, err := newTestClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 3 {
+		t.Fatalf("expected 200 after 3 calls, got %d after %d calls", resp.StatusCode, calls)
+	}
+}
+
+func TestNoRetryOnPostServerError(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer ts.Close()
+
+	SetMaxAttempts(3)
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString("payload"))
+	resp, err := newTestClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if calls != 1 {
+		t.Fatalf("expected a single call, got %d", calls)
+	}
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+
+	SetMaxAttempts(2)
+	SetMaxRetryWait(10 * time.Millisecond)
+	defer SetMaxRetryWait(DefaultMaxRetryWait)
+
+	start := time.Now()
+	req, _ := http.NewRequest(http.MethodPost, ts.URL, bytes.NewBufferString("payload"))
+	resp, err := newTestClient().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || calls != 2 {
+		t.Fatalf("expected 200 after 2 calls, got %d after %d calls", resp.StatusCode, calls)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatalf("Retry-After was not capped by max retry wait")
+	}
+}
+
+func TestRetryStopsOnCancel(t *testing.T) {
+	calls := 0
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	SetMaxAttempts(5)
+	SetMaxRetryWait(time.Minute)
+	defer SetMaxRetryWait(DefaultMaxRetryWait)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	client := newTestClient()
+	retryBaseDelay = time.Minute
+
+	start := time.Now()
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, ts.URL, nil)
+	resp, err := client.Do(req)
+	if err == nil {
+		resp.Body.Close()
+		t.Fatalf("expected the canceled context to stop retries")
+	}
+	if calls != 1 || time.Since(start) > 5*time.Second {
+		t.Fatalf("expected a single call before cancel, got %d", calls)
+	}
+}
@@ -307,7 +307,7 @@ func getMetadata(metadata string) (respBpdy []byte, err error) {
 	} else if resp.StatusCode > 399 {
 		clilog.Debug.Printf("status code %d, error in response: %s\n", resp.StatusCode, string(respBody))
 		clilog.HttpError.Println(string(respBody))
-		return nil, errors.New(getErrorMessage(resp.StatusCode))
+		return nil, NewAPIError(resp, respBody)
 	}
 	return respBody, err
 }
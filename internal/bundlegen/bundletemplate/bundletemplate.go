@@ -0,0 +1,229 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundletemplate
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"internal/bundlegen/policies"
+
+	"github.com/pb33f/libopenapi"
+	"gopkg.in/yaml.v3"
+)
+
+// Values are the values available to the templates of a bundle
+type Values struct {
+	Name        string
+	BasePath    string
+	Description string
+	Spec        *Spec
+	Params      map[string]any
+}
+
+// Spec holds the values of an OpenAPI 3 document
+type Spec struct {
+	Title       string
+	Version     string
+	Description string
+	BasePath    string
+	Operations  []Operation
+}
+
+// Operation of an OpenAPI 3 document, with the condition of the matching flow
+type Operation struct {
+	OperationID string
+	Method      string
+	Path        string
+	PathPattern string
+	Condition   string
+	Summary     string
+	Description string
+}
+
+var (
+	pathParameter = regexp.MustCompile(`{[^}]*}`)
+	textEscaper   = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+// ReadSpec reads the values of an OpenAPI 3 document
+func ReadSpec(content []byte) (*Spec, error) {
+	document, err := libopenapi.NewDocument(content)
+	if err != nil {
+		return nil, err
+	}
+	model, errs := document.BuildV3Model()
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	spec := &Spec{}
+	if model.Model.Info != nil {
+		spec.Title = model.Model.Info.Title
+		spec.Version = model.Model.Info.Version
+		spec.Description = model.Model.Info.Description
+	}
+	if len(model.Model.Servers) > 0 {
+		if u, err := url.Parse(model.Model.Servers[0].URL); err == nil {
+			spec.BasePath = u.Path
+		}
+	}
+	if model.Model.Paths == nil {
+		return spec, nil
+	}
+	for path := model.Model.Paths.PathItems.First(); path != nil; path = path.Next() {
+		pathPattern := pathParameter.ReplaceAllLiteralString(path.Key(), "*")
+		for op := path.Value().GetOperations().First(); op != nil; op = op.Next() {
+			method := strings.ToUpper(op.Key())
+			operation := Operation{
+				OperationID: op.Value().OperationId,
+				Method:      method,
+				Path:        path.Key(),
+				PathPattern: pathPattern,
+				Condition:   "(proxy.pathsuffix MatchesPath \"" + pathPattern + "\") and (request.verb = \"" + method + "\")",
+				Summary:     op.Value().Summary,
+				Description: op.Value().Description,
+			}
+			if operation.OperationID == "" {
+				operation.OperationID = strings.ToLower(method) + "_" + path.Key()
+			}
+			spec.Operations = append(spec.Operations, operation)
+		}
+	}
+	return spec, nil
+}
+
+// ReadParams reads the parameters of a YAML or JSON file
+func ReadParams(fileName string) (params map[string]any, err error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if err = yaml.Unmarshal(content, &params); err != nil {
+		return nil, fmt.Errorf("invalid params file %s: %v", fileName, err)
+	}
+	return params, nil
+}
+
+// templateRoot returns the apiproxy folder of a bundle template
+func templateRoot(templateDir string) (string, error) {
+	if filepath.Base(filepath.Clean(templateDir)) == "apiproxy" {
+		return templateDir, nil
+	}
+	root := filepath.Join(templateDir, "apiproxy")
+	if info, err := os.Stat(root); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("the template folder %s must contain an apiproxy folder", templateDir)
+	}
+	return root, nil
+}
+
+func templateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"lower":   strings.ToLower,
+		"upper":   strings.ToUpper,
+		"replace": func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"xml":     textEscaper.Replace,
+		"policy":  policies.GetPolicyTemplate,
+	}
+}
+
+// Render renders the files of a bundle template to the apiproxy folder of the destination.
+// The paths and the XML files are templates; the other files are copied
+func Render(templateDir string, values Values, destination string) error {
+	root, err := templateRoot(templateDir)
+	if err != nil {
+		return err
+	}
+	return filepath.WalkDir(root, func(fileName string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if strings.HasPrefix(d.Name(), ".") {
+			if d.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(root, fileName)
+		if err != nil {
+			return err
+		}
+		if relativePath, err = render(relativePath, relativePath, values); err != nil {
+			return err
+		}
+		// a rendered path, ex: a/../../x, must stay in the apiproxy folder
+		if relativePath = filepath.Clean(relativePath); !filepath.IsLocal(relativePath) || relativePath == "." {
+			return fmt.Errorf("invalid path rendered from %s", fileName)
+		}
+
+		content, err := os.ReadFile(fileName)
+		if err != nil {
+			return err
+		}
+		if strings.EqualFold(filepath.Ext(fileName), ".xml") {
+			rendered, err := render(fileName, string(content), values)
+			if err != nil {
+				return err
+			}
+			if err = checkXML(rendered); err != nil {
+				return fmt.Errorf("%s is not valid XML once rendered: %v", fileName, err)
+			}
+			content = []byte(rendered)
+		}
+
+		target := filepath.Join(destination, "apiproxy", relativePath)
+		if err = os.MkdirAll(filepath.Dir(target), os.ModePerm); err != nil {
+			return err
+		}
+		return os.WriteFile(target, content, 0o644)
+	})
+}
+
+func render(name string, text string, values Values) (string, error) {
+	t, err := template.New(name).Funcs(templateFuncs()).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var rendered bytes.Buffer
+	if err = t.Execute(&rendered, values); err != nil {
+		return "", err
+	}
+	return rendered.String(), nil
+}
+
+func checkXML(content string) error {
+	decoder := xml.NewDecoder(strings.NewReader(content))
+	for {
+		if _, err := decoder.Token(); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundletemplate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const ordersSpec = `openapi: 3.0.3
+info:
+  title: Orders
+  version: "1.0"
+  description: Orders & returns
+servers:
+  - url: https://api.example.com/orders
+paths:
+  /orders/{id}:
+    get:
+      operationId: getOrder
+      responses:
+        "200":
+          description: ok
+`
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	for name, content := range files {
+		fileName := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadSpec(t *testing.T) {
+	spec, err := ReadSpec([]byte(ordersSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spec.Title != "Orders" || spec.BasePath != "/orders" || len(spec.Operations) != 1 {
+		t.Fatalf("unexpected spec %+v", spec)
+	}
+	operation := spec.Operations[0]
+	if operation.OperationID != "getOrder" || operation.Method != "GET" || operation.PathPattern != "/orders/*" {
+		t.Errorf("unexpected operation %+v", operation)
+	}
+	if operation.Condition != `(proxy.pathsuffix MatchesPath "/orders/*") and (request.verb = "GET")` {
+		t.Errorf("unexpected condition %s", operation.Condition)
+	}
+}
+
+func TestRender(t *testing.T) {
+	templateDir := t.TempDir()
+	writeFiles(t, templateDir, map[string]string{
+		"apiproxy/{{.Name}}.xml": `<APIProxy name="{{.Name}}"><Description>{{xml .Spec.Description}}</Description></APIProxy>`,
+		"apiproxy/proxies/default.xml": `<ProxyEndpoint name="default"><Flows>{{range .Spec.Operations}}` +
+			`<Flow name="{{.OperationID}}"><Condition>{{xml .Condition}}</Condition></Flow>{{end}}</Flows>` +
+			`<HTTPProxyConnection><BasePath>{{.BasePath}}</BasePath></HTTPProxyConnection></ProxyEndpoint>`,
+		"apiproxy/targets/default.xml":         `<TargetEndpoint name="default"><URL>{{.Params.backend}}</URL></TargetEndpoint>`,
+		"apiproxy/resources/jsc/a.js":          `var a = "{{.Name}}";`,
+		"apiproxy/.DS_Store":                   "ignored",
+		"apiproxy/policies/Spike-Arrest-1.xml": `{{policy "spike-arrest.xml"}}`,
+	})
+	spec, err := ReadSpec([]byte(ordersSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	values := Values{
+		Name:     "orders",
+		BasePath: "/v1/orders",
+		Spec:     spec,
+		Params:   map[string]any{"backend": "https://orders.example.com"},
+	}
+
+	destination := t.TempDir()
+	if err = Render(templateDir, values, destination); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]string{
+		"orders.xml":                  "<Description>Orders &amp; returns</Description>",
+		"proxies/default.xml":         `<Flow name="getOrder"><Condition>(proxy.pathsuffix MatchesPath "/orders/*")`,
+		"targets/default.xml":         "<URL>https://orders.example.com</URL>",
+		"resources/jsc/a.js":          `var a = "{{.Name}}";`,
+		"policies/Spike-Arrest-1.xml": `name="Spike-Arrest-1"`,
+	} {
+		content, err := os.ReadFile(filepath.Join(destination, "apiproxy", name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(content), want) {
+			t.Errorf("expected %s in %s, got %s", want, name, content)
+		}
+	}
+	if _, err = os.Stat(filepath.Join(destination, "apiproxy", ".DS_Store")); !os.IsNotExist(err) {
+		t.Errorf("expected dotfiles to be skipped")
+	}
+
+	values.Params = map[string]any{}
+	if err = Render(templateDir, values, t.TempDir()); err == nil {
+		t.Errorf("expected an error for a missing param")
+	}
+
+	escaping := t.TempDir()
+	writeFiles(t, escaping, map[string]string{"apiproxy/resources/{{.Params.folder}}/x.js": "var x;"})
+	values.Params = map[string]any{"folder": "../../.."}
+	if err = Render(escaping, values, t.TempDir()); err == nil || !strings.Contains(err.Error(), "invalid path") {
+		t.Errorf("expected an invalid path error, got %v", err)
+	}
+
+	writeFiles(t, templateDir, map[string]string{"apiproxy/proxies/bad.xml": "<ProxyEndpoint>{{.Name}}"})
+	values.Params = map[string]any{"backend": "https://orders.example.com"}
+	if err = Render(templateDir, values, t.TempDir()); err == nil || !strings.Contains(err.Error(), "not valid XML") {
+		t.Errorf("expected an invalid XML error, got %v", err)
+	}
+}
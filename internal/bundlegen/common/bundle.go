@@ -0,0 +1,123 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package common
+
+import (
+	"archive/zip"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Bundle is the content of an API proxy or sharedflow bundle
+type Bundle struct {
+	// Root is apiproxy or sharedflowbundle
+	Root string
+	// Files are the contents of the files, keyed by their path in the bundle, for ex: apiproxy/proxies/default.xml
+	Files map[string][]byte
+}
+
+// In reports whether a file is an XML file of a folder of the bundle, for ex: apiproxy/policies
+func (b Bundle) In(name string, folder string) bool {
+	return path.Dir(name) == path.Join(b.Root, folder) && strings.HasSuffix(name, ".xml")
+}
+
+// Descriptor returns the XML file at the root of the bundle
+func (b Bundle) Descriptor() string {
+	for _, name := range b.Names() {
+		if path.Dir(name) == b.Root && strings.HasSuffix(name, ".xml") {
+			return name
+		}
+	}
+	return ""
+}
+
+// Names returns the paths of the files of the bundle, sorted
+func (b Bundle) Names() (names []string) {
+	for name := range b.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ReadBundle reads the files of a bundle zip or of a folder that is or contains
+// an apiproxy or sharedflowbundle folder
+func ReadBundle(bundlePath string) (b Bundle, err error) {
+	b.Files = map[string][]byte{}
+	stat, err := os.Stat(bundlePath)
+	if err != nil {
+		return b, err
+	}
+	if stat.IsDir() {
+		base := bundlePath
+		if n := filepath.Base(bundlePath); n == "apiproxy" || n == "sharedflowbundle" {
+			base = filepath.Dir(bundlePath)
+		}
+		err = filepath.WalkDir(base, func(p string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(base, p)
+			if err != nil {
+				return err
+			}
+			b.Files[filepath.ToSlash(rel)], err = os.ReadFile(p)
+			return err
+		})
+	} else {
+		var r *zip.ReadCloser
+		if r, err = zip.OpenReader(bundlePath); err != nil {
+			return b, err
+		}
+		defer r.Close()
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return b, err
+			}
+			b.Files[f.Name], err = io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return b, err
+			}
+		}
+	}
+	if err != nil {
+		return b, err
+	}
+	for name := range b.Files {
+		if root := strings.Split(name, "/")[0]; root == "apiproxy" || root == "sharedflowbundle" {
+			b.Root = root
+		}
+	}
+	if b.Root == "" {
+		return b, fmt.Errorf("%s does not contain an apiproxy or sharedflowbundle folder", bundlePath)
+	}
+	for name := range b.Files {
+		if !strings.HasPrefix(name, b.Root+"/") {
+			delete(b.Files, name)
+		}
+	}
+	return b, nil
+}
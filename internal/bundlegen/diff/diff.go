@@ -0,0 +1,204 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	proxytypes "internal/bundlegen/common"
+)
+
+// Options of a bundle diff
+type Options struct {
+	// XML compares XML files ignoring attribute order and whitespace
+	XML bool
+	// Context is the number of unchanged lines around a change
+	Context int
+}
+
+// Status of a file in the diff
+type Status string
+
+const (
+	Added    Status = "added"
+	Removed  Status = "removed"
+	Modified Status = "modified"
+)
+
+// FileDiff is the diff of a file of the bundles
+type FileDiff struct {
+	Name   string
+	Status Status
+	// Unified is the unified diff of the file, empty for binary files
+	Unified string
+	Binary  bool
+}
+
+// Source is a bundle to compare: a local zip or folder, a revision or the revision deployed to an environment
+type Source struct {
+	Path        string
+	Name        string
+	Revision    string
+	Environment string
+}
+
+// Fetcher downloads a revision of a bundle, or the revision deployed to an environment
+// when the revision is empty, and returns the path of the zip file
+type Fetcher func(name string, revision string, environment string) (string, error)
+
+var sourcePattern = regexp.MustCompile(`^([\w.\-]+)@([\w.\-]+)$`)
+
+// ParseSource parses name@revision, name@environment or the path of a zip file or a folder
+func ParseSource(s string) (src Source, err error) {
+	if _, err = os.Stat(s); err == nil {
+		return Source{Path: s}, nil
+	}
+	m := sourcePattern.FindStringSubmatch(s)
+	if m == nil {
+		return src, fmt.Errorf("%s is not a local bundle, name@revision or name@environment", s)
+	}
+	if _, err = strconv.Atoi(m[2]); err == nil {
+		return Source{Name: m[1], Revision: m[2]}, nil
+	}
+	return Source{Name: m[1], Environment: m[2]}, nil
+}
+
+// Read reads the bundle of a source, with fetch for the revisions in Apigee
+func (s Source) Read(fetch Fetcher) (b proxytypes.Bundle, err error) {
+	bundlePath := s.Path
+	if bundlePath == "" {
+		if bundlePath, err = fetch(s.Name, s.Revision, s.Environment); err != nil {
+			return b, err
+		}
+	}
+	return proxytypes.ReadBundle(bundlePath)
+}
+
+// Sources reads the bundles of two sources and compares them
+func Sources(from string, to string, fetch Fetcher, opts Options) ([]FileDiff, error) {
+	bundles := []proxytypes.Bundle{}
+	for _, s := range []string{from, to} {
+		src, err := ParseSource(s)
+		if err != nil {
+			return nil, err
+		}
+		b, err := src.Read(fetch)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", s, err)
+		}
+		bundles = append(bundles, b)
+	}
+	return Bundles(bundles[0], bundles[1], opts)
+}
+
+// IsLocal reports whether a source is a local zip file or folder
+func IsLocal(s string) bool {
+	src, err := ParseSource(s)
+	return err == nil && src.Path != ""
+}
+
+// Bundles compares the files of two bundles and returns the files that are different, sorted by name
+func Bundles(a proxytypes.Bundle, b proxytypes.Bundle, opts Options) ([]FileDiff, error) {
+	if a.Root != b.Root {
+		return nil, fmt.Errorf("cannot compare a %s bundle with a %s bundle", a.Root, b.Root)
+	}
+	names := map[string]bool{}
+	for name := range a.Files {
+		names[name] = true
+	}
+	for name := range b.Files {
+		names[name] = true
+	}
+	sorted := []string{}
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	diffs := []FileDiff{}
+	for _, name := range sorted {
+		before, inA := a.Files[name]
+		after, inB := b.Files[name]
+		d := FileDiff{Name: name, Status: Modified}
+		switch {
+		case !inA:
+			d.Status = Added
+		case !inB:
+			d.Status = Removed
+		case bytes.Equal(before, after):
+			continue
+		}
+		if isBinary(before) || isBinary(after) {
+			d.Binary = true
+			diffs = append(diffs, d)
+			continue
+		}
+		if opts.XML && strings.HasSuffix(name, ".xml") {
+			before, after = normalizeOrRaw(before), normalizeOrRaw(after)
+			if inA && inB && bytes.Equal(before, after) {
+				continue
+			}
+		}
+		fromName, toName := "a/"+name, "b/"+name
+		if !inA {
+			fromName = "/dev/null"
+		}
+		if !inB {
+			toName = "/dev/null"
+		}
+		// files that differ only in a trailing newline have no diff
+		if d.Unified = Unified(fromName, toName, lines(before), lines(after), opts.Context); d.Unified != "" {
+			diffs = append(diffs, d)
+		}
+	}
+	return diffs, nil
+}
+
+// Write writes the unified diffs of the files
+func Write(w io.Writer, diffs []FileDiff) {
+	for _, d := range diffs {
+		if d.Binary {
+			fmt.Fprintf(w, "Binary file %s %s\n", d.Name, d.Status)
+			continue
+		}
+		fmt.Fprint(w, d.Unified)
+	}
+}
+
+func normalizeOrRaw(content []byte) []byte {
+	if normalized, err := NormalizeXML(content); err == nil {
+		return normalized
+	}
+	return content
+}
+
+func isBinary(content []byte) bool {
+	return bytes.IndexByte(content, 0) != -1
+}
+
+func lines(content []byte) []string {
+	if len(content) == 0 {
+		return nil
+	}
+	l := strings.SplitAfter(strings.TrimSuffix(string(content), "\n")+"\n", "\n")
+	return l[:len(l)-1]
+}
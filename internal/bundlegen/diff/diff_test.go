@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	proxytypes "internal/bundlegen/common"
+)
+
+func TestUnified(t *testing.T) {
+	a := lines([]byte("1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"))
+	b := lines([]byte("1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n"))
+	want := `--- a/f
++++ b/f
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -8,3 +8,4 @@
+ 8
+ 9
+ 10
++11
+`
+	if got := Unified("a/f", "b/f", a, b, 3); got != want {
+		t.Errorf("got\n%s\nwant\n%s", got, want)
+	}
+	if got := Unified("a/f", "b/f", a, a, 3); got != "" {
+		t.Errorf("got %s for equal files", got)
+	}
+}
+
+func TestBundles(t *testing.T) {
+	a := proxytypes.Bundle{Root: "apiproxy", Files: map[string][]byte{
+		"apiproxy/policies/AM.xml": []byte(`<AssignMessage name="AM" enabled="true">
+    <Set><Payload>{"a":1}</Payload></Set>
+</AssignMessage>`),
+		"apiproxy/policies/Old.xml":   []byte(`<Quota name="Old"/>`),
+		"apiproxy/resources/jsc/a.js": []byte("var a = 1;\n"),
+	}}
+	b := proxytypes.Bundle{Root: "apiproxy", Files: map[string][]byte{
+		"apiproxy/policies/AM.xml": []byte(`<AssignMessage enabled="true"  name="AM">
+  <Set>
+    <Payload>{"a":1}</Payload>
+  </Set>
+</AssignMessage>
+`),
+		"apiproxy/resources/jsc/a.js": []byte("var a = 2;\n"),
+	}}
+
+	diffs, err := Bundles(a, b, Options{XML: true, Context: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 2 {
+		t.Fatalf("got %d diffs, want 2: %v", len(diffs), diffs)
+	}
+	if diffs[0].Name != "apiproxy/policies/Old.xml" || diffs[0].Status != Removed ||
+		!strings.Contains(diffs[0].Unified, "+++ /dev/null") {
+		t.Errorf("unexpected diff %v", diffs[0])
+	}
+	if diffs[1].Status != Modified || !strings.Contains(diffs[1].Unified, "+var a = 2;") {
+		t.Errorf("unexpected diff %v", diffs[1])
+	}
+
+	if diffs, err = Bundles(a, b, Options{Context: 3}); err != nil {
+		t.Fatal(err)
+	}
+	if len(diffs) != 3 {
+		t.Errorf("got %d diffs without XML mode, want 3", len(diffs))
+	}
+	if _, err = Bundles(a, proxytypes.Bundle{Root: "sharedflowbundle"}, Options{}); err == nil {
+		t.Error("expected an error comparing an API proxy with a sharedflow")
+	}
+}
+
+func TestParseSource(t *testing.T) {
+	tests := map[string]Source{
+		"orders@12":   {Name: "orders", Revision: "12"},
+		"orders@prod": {Name: "orders", Environment: "prod"},
+		".":           {Path: "."},
+	}
+	for s, want := range tests {
+		got, err := ParseSource(s)
+		if err != nil {
+			t.Errorf("%s: %v", s, err)
+		} else if got != want {
+			t.Errorf("%s: got %v, want %v", s, got, want)
+		}
+	}
+	if _, err := ParseSource("not a bundle"); err == nil {
+		t.Error("expected an error for an invalid source")
+	}
+}
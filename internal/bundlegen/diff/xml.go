@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package diff
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var (
+	textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+)
+
+// element is an XML element, a text or a comment of a normalized document
+type element struct {
+	name     string
+	attrs    []string
+	text     string
+	comment  bool
+	children []*element
+}
+
+// NormalizeXML formats an XML document with one element per line, sorted attributes
+// and trimmed text, so that documents that differ only in formatting are equal
+func NormalizeXML(content []byte) ([]byte, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	root := &element{}
+	stack := []*element{root}
+	for {
+		tok, err := d.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		parent := stack[len(stack)-1]
+		switch t := tok.(type) {
+		case xml.StartElement:
+			e := &element{name: qualifiedName(t.Name)}
+			for _, a := range t.Attr {
+				e.attrs = append(e.attrs, qualifiedName(a.Name)+`="`+attrEscaper.Replace(a.Value)+`"`)
+			}
+			sort.Strings(e.attrs)
+			parent.children = append(parent.children, e)
+			stack = append(stack, e)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if text := strings.TrimSpace(string(t)); text != "" {
+				parent.children = append(parent.children, &element{text: textEscaper.Replace(text)})
+			}
+		case xml.Comment:
+			parent.children = append(parent.children, &element{text: strings.TrimSpace(string(t)), comment: true})
+		}
+	}
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("unexpected end of document")
+	}
+	b := bytes.Buffer{}
+	for _, c := range root.children {
+		c.write(&b, 0)
+	}
+	return b.Bytes(), nil
+}
+
+func (e *element) write(b *bytes.Buffer, depth int) {
+	indent := strings.Repeat("  ", depth)
+	switch {
+	case e.comment:
+		fmt.Fprintf(b, "%s<!-- %s -->\n", indent, e.text)
+		return
+	case e.name == "":
+		// multi-line text, like a script or a payload, keeps its lines without their indentation
+		for _, line := range strings.Split(e.text, "\n") {
+			fmt.Fprintf(b, "%s%s\n", indent, strings.TrimSpace(line))
+		}
+		return
+	}
+	start := e.name
+	if len(e.attrs) > 0 {
+		start += " " + strings.Join(e.attrs, " ")
+	}
+	switch {
+	case len(e.children) == 0:
+		fmt.Fprintf(b, "%s<%s/>\n", indent, start)
+	case len(e.children) == 1 && e.children[0].name == "" && !e.children[0].comment &&
+		!strings.Contains(e.children[0].text, "\n"):
+		fmt.Fprintf(b, "%s<%s>%s</%s>\n", indent, start, e.children[0].text, e.name)
+	default:
+		fmt.Fprintf(b, "%s<%s>\n", indent, start)
+		for _, c := range e.children {
+			c.write(b, depth+1)
+		}
+		fmt.Fprintf(b, "%s</%s>\n", indent, e.name)
+	}
+}
+
+func qualifiedName(n xml.Name) string {
+	if n.Space == "" {
+		return n.Local
+	}
+	return n.Space + ":" + n.Local
+}
@@ -132,6 +132,10 @@ func GenerateAPIProxyDefFromOASv2(name string,
 		return fmt.Errorf("the Open API document not loaded")
 	}
 
+	// the policies of the extensions and mocks of a previous generation must not leak into this bundle
+	extensionPolicyContent = map[string]string{}
+	mockPolicyContent = map[string]string{}
+
 	// load security schemes
 	if docModel.Model.Components != nil && docModel.Model.Components.SecuritySchemes != nil {
 		loadSecurityRequirementsv2(docModel.Model.Components.SecuritySchemes)
@@ -149,7 +153,7 @@ func GenerateAPIProxyDefFromOASv2(name string,
 	apiproxy.SetConfigurationVersion()
 	if targetOptions.IntegrationBackend.IntegrationName != "" {
 		apiproxy.AddIntegrationEndpoint(DEFAULT)
-	} else {
+	} else if !targetOptions.Mock {
 		apiproxy.AddTargetEndpoint(DEFAULT)
 	}
 
@@ -177,7 +181,13 @@ func GenerateAPIProxyDefFromOASv2(name string,
 	}
 
 	// decide on the type of target
-	if targetOptions.IntegrationBackend.IntegrationName != "" { // assume an integration endpoint
+	if targetOptions.Mock { // the responses are set by the proxy endpoint
+		if basePath != "" {
+			proxies.NewProxyEndpointWithoutTarget(basePath)
+		} else {
+			proxies.NewProxyEndpointWithoutTarget(u.Path)
+		}
+	} else if targetOptions.IntegrationBackend.IntegrationName != "" { // assume an integration endpoint
 		proxies.AddStepToPreFlowRequest("set-integration-request")
 		apiproxy.AddPolicy("set-integration-request")
 		proxies.NewProxyEndpoint(u.Path, false)
@@ -251,6 +261,19 @@ func GenerateAPIProxyDefFromOASv2(name string,
 				proxies.AddStepToPreFlowRequest("Quota-" + quota.QuotaName)
 			}
 		}
+		// add any preflow policies of the x-apigee extensions
+		policySteps, err := processApigeeExtensionsv2(docModel.Model.Extensions)
+		if err != nil {
+			return err
+		}
+		for _, policyStep := range policySteps {
+			if policyStep.Response {
+				proxies.AddStepToPreFlowResponse(policyStep.Name)
+			} else {
+				proxies.AddStepToPreFlowRequest(policyStep.Name)
+			}
+			apiproxy.AddPolicy(policyStep.Name)
+		}
 	}
 
 	if addCORS {
@@ -262,7 +285,7 @@ func GenerateAPIProxyDefFromOASv2(name string,
 		proxies.AddStepToPreFlowRequest("OpenAPI-Spec-Validation-1")
 	}
 
-	if err = generateFlowsv2(docModel.Model.Paths); err != nil {
+	if err = generateFlowsv2(docModel.Model.Paths, targetOptions.Mock); err != nil {
 		return err
 	}
 
@@ -494,7 +517,7 @@ func getQuotaDefinitionv2(extension *yaml.Node) ([]quotaDef, error) {
 	return quotas, nil
 }
 
-func generateFlowsv2(paths *v3.Paths) (err error) {
+func generateFlowsv2(paths *v3.Paths, mock bool) (err error) {
 	if paths == nil {
 		return nil
 	}
@@ -528,6 +551,22 @@ func generateFlowsv2(paths *v3.Paths) (err error) {
 					}
 				}
 			}
+			for _, policyStep := range pathDetail.PolicySteps {
+				if policyStep.Response {
+					err = proxies.AddStepToFlowResponse(policyStep.Name, pathDetail.OperationID, "")
+				} else {
+					err = proxies.AddStepToFlowRequest(policyStep.Name, pathDetail.OperationID)
+				}
+				if err != nil {
+					return err
+				}
+				apiproxy.AddPolicy(policyStep.Name)
+			}
+		}
+		if mock {
+			if err = generateMockResponsesv2(first.Value(), pathMap); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -707,6 +746,10 @@ func processPathExtensionsv2(extensions *orderedmap.Map[string, *yaml.Node], pat
 			errs = append(errs, err)
 		}
 	}
+	// add any policies of the x-apigee extensions
+	if pathDetail.PolicySteps, err = processApigeeExtensionsv2(extensions); err != nil {
+		errs = append(errs, fmt.Errorf("%s: %v", pathDetail.OperationID, err))
+	}
 	return pathDetail, errors.Join(errs...)
 }
 
@@ -41,7 +41,7 @@ func TestSpecs(t *testing.T) {
 }
 
 func testLoadDocument(specName string, t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -0,0 +1,302 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"bytes"
+	"fmt"
+	"internal/bundlegen/policies"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+const apigeeExtensionPrefix = "x-apigee-"
+
+// policyStepDef is a step of a policy generated from an x-apigee extension
+type policyStepDef struct {
+	Name     string
+	Response bool
+}
+
+type responseCacheExtDef struct {
+	Name            string   `yaml:"name"`
+	ExpirySeconds   int      `yaml:"expiry-seconds"`
+	KeyFragmentRefs []string `yaml:"key-fragment-refs"`
+}
+
+type jsonThreatProtectionExtDef struct {
+	Name                  string `yaml:"name"`
+	ArrayElementCount     int    `yaml:"array-element-count"`
+	ContainerDepth        int    `yaml:"container-depth"`
+	ObjectEntryCount      int    `yaml:"object-entry-count"`
+	ObjectEntryNameLength int    `yaml:"object-entry-name-length"`
+	StringValueLength     int    `yaml:"string-value-length"`
+}
+
+type headersExtDef struct {
+	Name    string            `yaml:"name"`
+	Message string            `yaml:"message"`
+	Set     map[string]string `yaml:"set"`
+	Remove  []string          `yaml:"remove"`
+}
+
+type corsExtDef struct {
+	Name             string `yaml:"name"`
+	AllowOrigins     string `yaml:"allow-origins"`
+	AllowMethods     string `yaml:"allow-methods"`
+	AllowHeaders     string `yaml:"allow-headers"`
+	ExposeHeaders    string `yaml:"expose-headers"`
+	MaxAge           int    `yaml:"max-age"`
+	AllowCredentials *bool  `yaml:"allow-credentials"`
+}
+
+type kvmExtDef struct {
+	Name  string `yaml:"name"`
+	Map   string `yaml:"map"`
+	Scope string `yaml:"scope"`
+	Get   []struct {
+		Key      string `yaml:"key"`
+		AssignTo string `yaml:"assign-to"`
+	} `yaml:"get"`
+}
+
+type messageLoggingExtDef struct {
+	Name        string `yaml:"name"`
+	LogName     string `yaml:"log-name"`
+	ContentType string `yaml:"content-type"`
+	Message     string `yaml:"message"`
+}
+
+// apigeeExtensions maps the supported x-apigee extensions to the function generating their policies
+var apigeeExtensions = map[string]func(node *yaml.Node) ([]policyStepDef, error){
+	"x-apigee-response-cache":         getResponseCacheStepsv2,
+	"x-apigee-json-threat-protection": getJSONThreatProtectionStepsv2,
+	"x-apigee-headers":                getHeadersStepsv2,
+	"x-apigee-cors":                   getCORSStepsv2,
+	"x-apigee-kvm":                    getKVMStepsv2,
+	"x-apigee-message-logging":        getMessageLoggingStepsv2,
+}
+
+// processApigeeExtensionsv2 generates the policies of the x-apigee extensions of the document or an operation
+// and returns their steps. Other extensions are ignored, unknown x-apigee extensions are errors
+func processApigeeExtensionsv2(extensions *orderedmap.Map[string, *yaml.Node]) (steps []policyStepDef, err error) {
+	if extensions == nil {
+		return nil, nil
+	}
+	for first := extensions.First(); first != nil; first = first.Next() {
+		if !strings.HasPrefix(first.Key(), apigeeExtensionPrefix) {
+			continue
+		}
+		getSteps, ok := apigeeExtensions[first.Key()]
+		if !ok {
+			return nil, fmt.Errorf("unknown extension %s, supported extensions are %s",
+				first.Key(), strings.Join(getApigeeExtensionNames(), ", "))
+		}
+		extensionSteps, err := getSteps(first.Value())
+		if err != nil {
+			return nil, fmt.Errorf("%s extension: %v", first.Key(), err)
+		}
+		steps = append(steps, extensionSteps...)
+	}
+	return steps, nil
+}
+
+func getApigeeExtensionNames() []string {
+	names := []string{}
+	for name := range apigeeExtensions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// decodeExtensionv2 decodes a list of extension entries, rejecting unknown fields
+func decodeExtensionv2[T any](node *yaml.Node) (entries []T, err error) {
+	content, err := yaml.Marshal(node)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(&entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// addExtensionPolicy stores the policy XML contents; a policy name can only be reused for the same policy
+func addExtensionPolicy(policyName string, content string) error {
+	if existing, ok := extensionPolicyContent[policyName]; ok && existing != content {
+		return fmt.Errorf("the policy %s is defined more than once with different settings", policyName)
+	}
+	extensionPolicyContent[policyName] = content
+	return nil
+}
+
+func getResponseCacheStepsv2(node *yaml.Node) (steps []policyStepDef, err error) {
+	entries, err := decodeExtensionv2[responseCacheExtDef](node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Name == "" {
+			return nil, fmt.Errorf("must have a name")
+		}
+		timeout := ""
+		if e.ExpirySeconds != 0 {
+			timeout = strconv.Itoa(e.ExpirySeconds)
+		}
+		policyName := "Response-Cache-" + e.Name
+		if err = addExtensionPolicy(policyName, policies.AddResponseCachePolicy(policyName, e.KeyFragmentRefs, timeout)); err != nil {
+			return nil, err
+		}
+		// the cache is looked up with the request and populated with the response
+		steps = append(steps, policyStepDef{Name: policyName}, policyStepDef{Name: policyName, Response: true})
+	}
+	return steps, nil
+}
+
+func getJSONThreatProtectionStepsv2(node *yaml.Node) (steps []policyStepDef, err error) {
+	entries, err := decodeExtensionv2[jsonThreatProtectionExtDef](node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Name == "" {
+			return nil, fmt.Errorf("must have a name")
+		}
+		policyName := "JSON-Threat-Protection-" + e.Name
+		if err = addExtensionPolicy(policyName, policies.AddJSONThreatProtectionPolicy(policyName,
+			e.ArrayElementCount, e.ContainerDepth, e.ObjectEntryCount, e.ObjectEntryNameLength, e.StringValueLength)); err != nil {
+			return nil, err
+		}
+		steps = append(steps, policyStepDef{Name: policyName})
+	}
+	return steps, nil
+}
+
+func getHeadersStepsv2(node *yaml.Node) (steps []policyStepDef, err error) {
+	entries, err := decodeExtensionv2[headersExtDef](node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Name == "" {
+			return nil, fmt.Errorf("must have a name")
+		}
+		if e.Message == "" {
+			e.Message = "request"
+		}
+		if e.Message != "request" && e.Message != "response" {
+			return nil, fmt.Errorf("message must be request or response, found %s", e.Message)
+		}
+		if len(e.Set) == 0 && len(e.Remove) == 0 {
+			return nil, fmt.Errorf("%s must set or remove headers", e.Name)
+		}
+		policyName := "Headers-" + e.Name
+		if err = addExtensionPolicy(policyName, policies.AddHeadersPolicy(policyName, e.Message, e.Set, e.Remove)); err != nil {
+			return nil, err
+		}
+		steps = append(steps, policyStepDef{Name: policyName, Response: e.Message == "response"})
+	}
+	return steps, nil
+}
+
+func getCORSStepsv2(node *yaml.Node) (steps []policyStepDef, err error) {
+	entries, err := decodeExtensionv2[corsExtDef](node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Name == "" {
+			return nil, fmt.Errorf("must have a name")
+		}
+		maxAge, allowCredentials := "", ""
+		if e.MaxAge != 0 {
+			maxAge = strconv.Itoa(e.MaxAge)
+		}
+		if e.AllowCredentials != nil {
+			allowCredentials = strconv.FormatBool(*e.AllowCredentials)
+		}
+		policyName := "CORS-" + e.Name
+		if err = addExtensionPolicy(policyName, policies.AddCustomCORSPolicy(policyName, e.AllowOrigins, e.AllowMethods,
+			e.AllowHeaders, e.ExposeHeaders, maxAge, allowCredentials)); err != nil {
+			return nil, err
+		}
+		steps = append(steps, policyStepDef{Name: policyName})
+	}
+	return steps, nil
+}
+
+func getKVMStepsv2(node *yaml.Node) (steps []policyStepDef, err error) {
+	entries, err := decodeExtensionv2[kvmExtDef](node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Name == "" {
+			return nil, fmt.Errorf("must have a name")
+		}
+		if e.Map == "" {
+			return nil, fmt.Errorf("%s must have a map", e.Name)
+		}
+		if e.Scope != "" && e.Scope != "organization" && e.Scope != "environment" &&
+			e.Scope != "apiproxy" && e.Scope != "policy" {
+			return nil, fmt.Errorf("scope must be organization, environment, apiproxy or policy, found %s", e.Scope)
+		}
+		if len(e.Get) == 0 {
+			return nil, fmt.Errorf("%s must get at least one key", e.Name)
+		}
+		gets := map[string]string{}
+		for _, get := range e.Get {
+			if get.Key == "" || get.AssignTo == "" {
+				return nil, fmt.Errorf("%s: every entry of get must have a key and assign-to", e.Name)
+			}
+			gets[get.AssignTo] = get.Key
+		}
+		policyName := "KVM-" + e.Name
+		if err = addExtensionPolicy(policyName, policies.AddKVMGetPolicy(policyName, e.Map, e.Scope, gets)); err != nil {
+			return nil, err
+		}
+		steps = append(steps, policyStepDef{Name: policyName})
+	}
+	return steps, nil
+}
+
+func getMessageLoggingStepsv2(node *yaml.Node) (steps []policyStepDef, err error) {
+	entries, err := decodeExtensionv2[messageLoggingExtDef](node)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.Name == "" {
+			return nil, fmt.Errorf("must have a name")
+		}
+		if e.LogName == "" {
+			return nil, fmt.Errorf("%s must have a log-name", e.Name)
+		}
+		policyName := "Message-Logging-" + e.Name
+		if err = addExtensionPolicy(policyName, policies.AddMessageLoggingPolicy(policyName, e.LogName,
+			e.ContentType, e.Message)); err != nil {
+			return nil, err
+		}
+		steps = append(steps, policyStepDef{Name: policyName, Response: true})
+	}
+	return steps, nil
+}
@@ -0,0 +1,132 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"internal/bundlegen/proxies"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/orderedmap"
+	"gopkg.in/yaml.v3"
+)
+
+func extensionsOf(t *testing.T, content string) *orderedmap.Map[string, *yaml.Node] {
+	var nodes map[string]yaml.Node
+	if err := yaml.Unmarshal([]byte(content), &nodes); err != nil {
+		t.Fatal(err)
+	}
+	extensions := orderedmap.New[string, *yaml.Node]()
+	for name, node := range nodes {
+		extensions.Set(name, &node)
+	}
+	return extensions
+}
+
+func TestProcessApigeeExtensions(t *testing.T) {
+	steps, err := processApigeeExtensionsv2(extensionsOf(t, `
+x-google-ratelimit: ignored
+x-apigee-response-cache:
+  - name: orders
+    expiry-seconds: 60
+    key-fragment-refs: [request.uri, request.header.accept]
+x-apigee-headers:
+  - name: hsts
+    message: response
+    set:
+      Strict-Transport-Security: max-age=31536000
+    remove: [Server]
+x-apigee-kvm:
+  - name: config
+    map: orders-config
+    get:
+      - key: backend-key
+        assign-to: private.backend.key
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	responseSteps := 0
+	for _, step := range steps {
+		if step.Response {
+			responseSteps++
+		}
+	}
+	if len(steps) != 4 || responseSteps != 2 {
+		t.Errorf("unexpected steps %+v", steps)
+	}
+
+	policies := GetExtensionPolicies()
+	for name, want := range map[string]string{
+		"Response-Cache-orders": `<KeyFragment ref="request.header.accept" type="string"/>`,
+		"Headers-hsts":          `<Header name="Strict-Transport-Security">max-age=31536000</Header>`,
+		"KVM-config":            `<Get assignTo="private.backend.key">`,
+	} {
+		if !strings.Contains(policies[name], want) {
+			t.Errorf("expected %s in %s", want, policies[name])
+		}
+	}
+	if !strings.Contains(policies["Headers-hsts"], `type="response"`) {
+		t.Errorf("expected the headers to be set on the response, got %s", policies["Headers-hsts"])
+	}
+
+	for _, invalid := range []string{
+		"x-apigee-unknown: [{name: a}]",
+		"x-apigee-cors: [{name: a, allow-origin: '*'}]",
+		"x-apigee-kvm: [{name: a, get: [{key: k, assign-to: v}]}]",
+		"x-apigee-headers: [{name: hsts, set: {a: b}}]",
+	} {
+		if _, err = processApigeeExtensionsv2(extensionsOf(t, invalid)); err == nil {
+			t.Errorf("expected an error for %s", invalid)
+		}
+	}
+}
+
+func TestGenerateFlowsWithApigeeExtensions(t *testing.T) {
+	pathDetail, err := processPathExtensionsv2(extensionsOf(t, `
+x-apigee-json-threat-protection:
+  - name: strict
+    container-depth: 5
+x-apigee-message-logging:
+  - name: audit
+    log-name: orders-audit
+`), pathDetailDef{OperationID: "createOrder"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	proxies.AddFlow(pathDetail.OperationID, "/orders", "post", "")
+	for _, policyStep := range pathDetail.PolicySteps {
+		if policyStep.Response {
+			err = proxies.AddStepToFlowResponse(policyStep.Name, pathDetail.OperationID, "")
+		} else {
+			err = proxies.AddStepToFlowRequest(policyStep.Name, pathDetail.OperationID)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	proxyEndpoint, err := proxies.GetProxyEndpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	flow := proxyEndpoint[strings.Index(proxyEndpoint, `<Flow name="createOrder">`):]
+	if !strings.Contains(flow, "<Request>\n    <Step>\n     <Name>JSON-Threat-Protection-strict</Name>") ||
+		!strings.Contains(flow, "<Response>\n    <Step>\n     <Name>Message-Logging-audit</Name>") {
+		t.Errorf("unexpected flow %s", flow)
+	}
+	if !strings.Contains(GetExtensionPolicies()["JSON-Threat-Protection-strict"], "<ContainerDepth>5</ContainerDepth>") {
+		t.Errorf("unexpected policy %s", GetExtensionPolicies()["JSON-Threat-Protection-strict"])
+	}
+}
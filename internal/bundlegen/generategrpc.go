@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"fmt"
+	"internal/bundlegen/proxies"
+	"strings"
+
+	apiproxy "internal/bundlegen/apiproxydef"
+
+	targets "internal/bundlegen/targets"
+)
+
+// GetGrpcBasePath returns the default base path of a gRPC proxy: the service name
+// when the proto file has a single service, / otherwise
+func GetGrpcBasePath(protoDef ProtoDef) string {
+	if len(protoDef.Services) == 1 {
+		return "/" + protoDef.FullName(protoDef.Services[0])
+	}
+	return "/"
+}
+
+// GenerateAPIProxyDefFromProto generates a gRPC proxy with a flow per method and a target endpoint
+// per service. gRPC targets must use a target server of the GRPC protocol
+func GenerateAPIProxyDefFromProto(name string,
+	description string,
+	protoDocName string,
+	protoDef ProtoDef,
+	basePath string,
+	targetServerName string,
+) (err error) {
+	apiproxy.SetDisplayName(name)
+	apiproxy.SetCreatedAt()
+	apiproxy.SetLastModifiedAt()
+	apiproxy.SetConfigurationVersion()
+	apiproxy.AddProxyEndpoint(DEFAULT)
+
+	if description != "" {
+		apiproxy.SetDescription(description)
+	} else {
+		apiproxy.SetDescription("Generated API Proxy from " + protoDocName)
+	}
+
+	if basePath == "" {
+		basePath = GetGrpcBasePath(protoDef)
+	}
+	apiproxy.SetBasePath(basePath)
+	prefix := strings.TrimSuffix(basePath, "/")
+
+	for _, service := range protoDef.Services {
+		if !strings.HasPrefix("/"+protoDef.FullName(service)+"/", prefix+"/") {
+			return fmt.Errorf("the base path %s does not match the service %s", basePath, protoDef.FullName(service))
+		}
+	}
+
+	for i, service := range protoDef.Services {
+		servicePath := "/" + protoDef.FullName(service)
+		// the first service is the default route, the others are routed by path
+		targetEndpointName := DEFAULT
+		if i > 0 {
+			targetEndpointName = service.Name
+			proxies.AddRoute(service.Name, targetEndpointName,
+				"proxy.pathsuffix MatchesPath \""+strings.TrimPrefix(servicePath, prefix)+"/**\"")
+		}
+		if targets.IsExists(targetEndpointName) {
+			return fmt.Errorf("the service %s is defined more than once", service.Name)
+		}
+		apiproxy.AddTargetEndpoint(targetEndpointName)
+		targets.NewTargetEndpoint(targetEndpointName, "", "", targetServerName, "", "", "")
+	}
+
+	proxies.NewProxyEndpoint(basePath, true)
+
+	for _, service := range protoDef.Services {
+		servicePath := "/" + protoDef.FullName(service)
+		for _, method := range service.Methods {
+			// gRPC requests are POST requests to /package.Service/Method
+			proxies.AddFlow(service.Name+"."+method.Name,
+				strings.TrimPrefix(servicePath, prefix)+"/"+method.Name, "post", "")
+		}
+	}
+
+	return err
+}
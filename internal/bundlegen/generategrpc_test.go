@@ -0,0 +1,122 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"internal/bundlegen/proxies"
+	"strings"
+	"testing"
+
+	targets "internal/bundlegen/targets"
+)
+
+const testProto = `syntax = "proto3";
+
+// The greeting services
+package helloworld;
+
+option go_package = "example.com/helloworld";
+
+import "google/protobuf/empty.proto";
+
+service Greeter {
+  // Sends a greeting
+  rpc SayHello (HelloRequest) returns (HelloReply) {}
+  rpc SayHelloStream (stream HelloRequest) returns (stream HelloReply) {
+    option deprecated = true;
+  };
+}
+
+/* a second service */
+service Health {
+  option deprecated = false;
+  rpc Check (google.protobuf.Empty) returns (HelloReply);
+}
+
+message HelloRequest {
+  string name = 1;
+  message Nested { string value = 1; }
+}
+
+message HelloReply {
+  string message = 1 [json_name = "msg"];
+}
+`
+
+func TestParseProto(t *testing.T) {
+	protoDef, err := ParseProto([]byte(testProto))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if protoDef.Package != "helloworld" || len(protoDef.Services) != 2 {
+		t.Fatalf("unexpected proto %+v", protoDef)
+	}
+	greeter := protoDef.Services[0]
+	if protoDef.FullName(greeter) != "helloworld.Greeter" || len(greeter.Methods) != 2 {
+		t.Fatalf("unexpected service %+v", greeter)
+	}
+	stream := greeter.Methods[1]
+	if stream.Name != "SayHelloStream" || !stream.ClientStreaming || !stream.ServerStreaming {
+		t.Errorf("unexpected method %+v", stream)
+	}
+	check := protoDef.Services[1].Methods
+	if len(check) != 1 || check[0].InputType != "google.protobuf.Empty" {
+		t.Errorf("unexpected methods %+v", check)
+	}
+
+	if _, err = ParseProto([]byte(`syntax = "proto3"; message Empty {}`)); err == nil {
+		t.Error("expected an error for a proto file without services")
+	}
+	if _, err = ParseProto([]byte(`service Greeter { rpc SayHello (HelloRequest) }`)); err == nil {
+		t.Error("expected an error for an incomplete rpc")
+	}
+}
+
+func TestGenerateAPIProxyDefFromProto(t *testing.T) {
+	// the generators of the previous tests leave their target endpoints
+	targets.TargetEndpoints = nil
+	protoDef, err := ParseProto([]byte(testProto))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = GenerateAPIProxyDefFromProto("greeter", "", "helloworld.proto", protoDef, "/helloworld.Greeter", "grpc"); err == nil {
+		t.Error("expected an error for a base path not matching all the services")
+	}
+	if err = GenerateAPIProxyDefFromProto("greeter", "", "helloworld.proto", protoDef, "", "grpc"); err != nil {
+		t.Fatal(err)
+	}
+	if len(targets.TargetEndpoints) != 2 || targets.TargetEndpoints[1].Name != "Health" {
+		t.Errorf("unexpected target endpoints %+v", targets.TargetEndpoints)
+	}
+	proxyEndpoint, err := proxies.GetProxyEndpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`<BasePath>/</BasePath>`,
+		`<Flow name="Greeter.SayHello">`,
+		`(proxy.pathsuffix MatchesPath "/helloworld.Greeter/SayHello") and (request.verb = "POST")`,
+		`<Condition>proxy.pathsuffix MatchesPath "/helloworld.Health/**"</Condition>`,
+	} {
+		if !strings.Contains(proxyEndpoint, want) {
+			t.Errorf("expected %s in %s", want, proxyEndpoint)
+		}
+	}
+	// the routes by path come before the default route; the proxy endpoint also holds
+	// the routes of the previous tests, the routes of this one are the last ones
+	if strings.LastIndex(proxyEndpoint, `<RouteRule name="Health">`) > strings.LastIndex(proxyEndpoint, `<RouteRule name="default">`) {
+		t.Errorf("unexpected order of the route rules in %s", proxyEndpoint)
+	}
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/bundlegen/policies"
+	"internal/bundlegen/proxies"
+	"regexp"
+	"strconv"
+	"strings"
+
+	apiproxy "internal/bundlegen/apiproxydef"
+
+	"github.com/pb33f/libopenapi/datamodel/high/base"
+	v3 "github.com/pb33f/libopenapi/datamodel/high/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// MockStatusHeader is the request header used to pick the status code of a mock response
+const MockStatusHeader = "x-mock-status"
+
+// maxMockDepth limits the nesting of payloads generated from (recursive) schemas
+const maxMockDepth = 8
+
+// mockPolicyNameChars are the characters of an operation id replaced in a policy name
+var mockPolicyNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type mockResponseDef struct {
+	StatusCode  string
+	ContentType string
+	Payload     string
+}
+
+// generateMockResponsesv2 adds an AssignMessage policy per documented response of the operations of a path.
+// The first 2xx response is returned without the status header, the others when the status header asks for them
+func generateMockResponsesv2(pathItem *v3.PathItem, pathMap map[string]pathDetailDef) error {
+	for op := pathItem.GetOperations().First(); op != nil; op = op.Next() {
+		pathDetail, ok := pathMap[op.Key()]
+		if !ok || op.Value().Responses == nil {
+			continue
+		}
+		responses, err := getMockResponsesv2(op.Value().Responses)
+		if err != nil {
+			return fmt.Errorf("unable to generate a mock response for %s: %v", pathDetail.OperationID, err)
+		}
+		for i, response := range responses {
+			policyName := mockPolicyName(pathDetail.OperationID, response.StatusCode)
+			// the default response must not run with the others, it would leak its payload into them
+			condition := "request.header." + MockStatusHeader + " = null"
+			if i > 0 {
+				condition = "request.header." + MockStatusHeader + " = \"" + response.StatusCode + "\""
+			}
+			if err = proxies.AddStepToFlowResponse("Mock-"+policyName, pathDetail.OperationID, condition); err != nil {
+				return err
+			}
+			apiproxy.AddPolicy("Mock-" + policyName)
+			// store policy XML contents
+			mockPolicyContent[policyName] = policies.AddMockResponsePolicy("Mock-"+policyName,
+				response.StatusCode, response.ContentType, response.Payload)
+		}
+	}
+	return nil
+}
+
+// getMockResponsesv2 returns the responses of an operation, the default (first 2xx) response first
+func getMockResponsesv2(responses *v3.Responses) (mockResponses []mockResponseDef, err error) {
+	seen := map[string]bool{}
+	defaultIndex := -1
+	if responses.Codes != nil {
+		for code := responses.Codes.First(); code != nil; code = code.Next() {
+			statusCode := strings.ReplaceAll(strings.ToUpper(code.Key()), "X", "0")
+			if _, err = strconv.Atoi(statusCode); err != nil || seen[statusCode] {
+				continue
+			}
+			seen[statusCode] = true
+			mockResponse, err := getMockResponsev2(statusCode, code.Value())
+			if err != nil {
+				return nil, err
+			}
+			if defaultIndex == -1 && strings.HasPrefix(statusCode, "2") {
+				defaultIndex = len(mockResponses)
+			}
+			mockResponses = append(mockResponses, mockResponse)
+		}
+	}
+	if len(mockResponses) == 0 && responses.Default != nil {
+		mockResponse, err := getMockResponsev2("200", responses.Default)
+		if err != nil {
+			return nil, err
+		}
+		return []mockResponseDef{mockResponse}, nil
+	}
+	if defaultIndex > 0 {
+		mockResponses[0], mockResponses[defaultIndex] = mockResponses[defaultIndex], mockResponses[0]
+	}
+	return mockResponses, nil
+}
+
+// getMockResponsev2 builds the payload of a response from the example, the first of the examples
+// or the schema of its JSON content (or its first content when there is no JSON)
+func getMockResponsev2(statusCode string, response *v3.Response) (mockResponse mockResponseDef, err error) {
+	mockResponse.StatusCode = statusCode
+	if response == nil || response.Content == nil || response.Content.Len() == 0 {
+		return mockResponse, nil
+	}
+
+	contentType, mediaType := response.Content.First().Key(), response.Content.First().Value()
+	if jsonMediaType, ok := response.Content.Get("application/json"); ok {
+		contentType, mediaType = "application/json", jsonMediaType
+	}
+	mockResponse.ContentType = contentType
+
+	var value any
+	switch {
+	case mediaType.Example != nil:
+		if err = mediaType.Example.Decode(&value); err != nil {
+			return mockResponse, err
+		}
+	case mediaType.Examples != nil && mediaType.Examples.Len() > 0:
+		if example := mediaType.Examples.First().Value(); example != nil && example.Value != nil {
+			if err = example.Value.Decode(&value); err != nil {
+				return mockResponse, err
+			}
+		}
+	default:
+		value = sampleFromSchemav2(mediaType.Schema, 0)
+	}
+
+	if s, ok := value.(string); ok && !strings.Contains(contentType, "json") {
+		mockResponse.Payload = s
+		return mockResponse, nil
+	}
+	payload, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return mockResponse, err
+	}
+	mockResponse.Payload = string(payload)
+	return mockResponse, nil
+}
+
+// sampleFromSchemav2 builds a value matching the schema, preferring the values documented in the schema
+func sampleFromSchemav2(schemaProxy *base.SchemaProxy, depth int) any {
+	if schemaProxy == nil || depth > maxMockDepth {
+		return nil
+	}
+	schema := schemaProxy.Schema()
+	if schema == nil {
+		return nil
+	}
+
+	for _, node := range []*yaml.Node{schema.Example, schema.Const, schema.Default} {
+		if value, ok := decodeNodev2(node); ok {
+			return value
+		}
+	}
+	if len(schema.Examples) > 0 {
+		if value, ok := decodeNodev2(schema.Examples[0]); ok {
+			return value
+		}
+	}
+	if len(schema.Enum) > 0 {
+		if value, ok := decodeNodev2(schema.Enum[0]); ok {
+			return value
+		}
+	}
+
+	if len(schema.AllOf) > 0 {
+		sample := map[string]any{}
+		for _, s := range schema.AllOf {
+			if properties, ok := sampleFromSchemav2(s, depth+1).(map[string]any); ok {
+				for k, v := range properties {
+					sample[k] = v
+				}
+			}
+		}
+		return sample
+	}
+	if len(schema.OneOf) > 0 {
+		return sampleFromSchemav2(schema.OneOf[0], depth+1)
+	}
+	if len(schema.AnyOf) > 0 {
+		return sampleFromSchemav2(schema.AnyOf[0], depth+1)
+	}
+
+	schemaType := ""
+	for _, t := range schema.Type {
+		if t != "null" {
+			schemaType = t
+			break
+		}
+	}
+	if schemaType == "" && schema.Properties != nil {
+		schemaType = "object"
+	}
+
+	switch schemaType {
+	case "object":
+		sample := map[string]any{}
+		if schema.Properties != nil {
+			for property := schema.Properties.First(); property != nil; property = property.Next() {
+				sample[property.Key()] = sampleFromSchemav2(property.Value(), depth+1)
+			}
+		}
+		return sample
+	case "array":
+		if schema.Items != nil && schema.Items.IsA() {
+			return []any{sampleFromSchemav2(schema.Items.A, depth+1)}
+		}
+		return []any{}
+	case "string":
+		return sampleStringv2(schema.Format)
+	case "integer", "number":
+		return 0
+	case "boolean":
+		return true
+	}
+	return nil
+}
+
+func sampleStringv2(format string) string {
+	switch format {
+	case "date-time":
+		return "1970-01-01T00:00:00Z"
+	case "date":
+		return "1970-01-01"
+	case "email":
+		return "user@example.com"
+	case "uuid":
+		return "00000000-0000-0000-0000-000000000000"
+	case "uri", "url":
+		return "https://example.com"
+	}
+	return "string"
+}
+
+func decodeNodev2(node *yaml.Node) (value any, ok bool) {
+	if node == nil {
+		return nil, false
+	}
+	if err := node.Decode(&value); err != nil {
+		return nil, false
+	}
+	return value, true
+}
+
+// mockPolicyName returns a policy name made of the operation and status code
+func mockPolicyName(operationID string, statusCode string) string {
+	return strings.Trim(mockPolicyNameChars.ReplaceAllLiteralString(operationID, "-"), "-") + "-" + statusCode
+}
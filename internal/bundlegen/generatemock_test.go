@@ -0,0 +1,129 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"internal/bundlegen/proxies"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+const mockSpec = `openapi: 3.0.0
+info:
+  title: pets
+  version: "1"
+servers:
+  - url: https://api.example.com/pets
+paths:
+  /pets/{id}:
+    get:
+      operationId: getPet
+      responses:
+        "401":
+          description: Unauthorized
+        "404":
+          description: Not found
+          content:
+            application/json:
+              example:
+                error: not found
+        "200":
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  id:
+                    type: integer
+                  name:
+                    type: string
+                    example: rex
+                  tags:
+                    type: array
+                    items:
+                      type: string
+                      format: uuid
+    delete:
+      operationId: deletePet
+      responses:
+        "204":
+          description: Deleted
+`
+
+func TestGenerateMockResponses(t *testing.T) {
+	folder := t.TempDir()
+	if err := os.WriteFile(filepath.Join(folder, "pets.yaml"), []byte(mockSpec), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDocument(folder, "", "pets.yaml", false); err != nil {
+		t.Fatal(err)
+	}
+	// policies of the extensions and mocks of a previous generation
+	extensionPolicyContent["Headers-stale"] = "<AssignMessage name=\"Headers-stale\"/>"
+	mockPolicyContent["stale-200"] = "<AssignMessage name=\"Mock-stale-200\"/>"
+	if err := GenerateAPIProxyDefFromOASv2("pets", "", "", "pets.yaml", true, false,
+		TargetOptions{Mock: true}); err != nil {
+		t.Fatal(err)
+	}
+	if policies := GetExtensionPolicies(); len(policies) != 0 {
+		t.Errorf("expected no extension policies from a spec without extensions, got %v", policies)
+	}
+
+	mockPolicies := GetMockPolicies()
+	if _, ok := mockPolicies["stale-200"]; ok {
+		t.Errorf("unexpected mock policy of a previous generation")
+	}
+	ok := mockPolicies["getPet-200"]
+	if !strings.Contains(ok, "<StatusCode>200</StatusCode>") || !strings.Contains(ok, `"name": "rex"`) ||
+		!strings.Contains(ok, `"00000000-0000-0000-0000-000000000000"`) {
+		t.Errorf("unexpected getPet-200 policy %s", ok)
+	}
+	if notFound := mockPolicies["getPet-404"]; !strings.Contains(notFound, `"error": "not found"`) {
+		t.Errorf("unexpected getPet-404 policy %s", notFound)
+	}
+	if unauthorized := mockPolicies["getPet-401"]; strings.Contains(unauthorized, "<Payload") {
+		t.Errorf("unexpected getPet-401 policy %s", unauthorized)
+	}
+	if deleted := mockPolicies["deletePet-204"]; strings.Contains(deleted, "<Payload") {
+		t.Errorf("unexpected deletePet-204 policy %s", deleted)
+	}
+
+	proxyEndpoint, err := proxies.GetProxyEndpoint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(proxyEndpoint, `<RouteRule name="default"></RouteRule>`) {
+		t.Errorf("expected a route rule without target, got %s", proxyEndpoint)
+	}
+	// the 2xx response is the default, the others are picked with the status header. The default
+	// does not run with a status header, the 401 without content would return its payload otherwise
+	defaultStep := strings.Index(proxyEndpoint, "<Name>Mock-getPet-200</Name>")
+	notFoundStep := strings.Index(proxyEndpoint, "<Name>Mock-getPet-404</Name>")
+	if defaultStep == -1 || notFoundStep < defaultStep ||
+		!strings.Contains(proxyEndpoint, `<Condition>request.header.x-mock-status = "404"</Condition>`) ||
+		!strings.Contains(proxyEndpoint, `<Condition>request.header.x-mock-status = "401"</Condition>`) {
+		t.Errorf("unexpected mock steps in %s", proxyEndpoint)
+	}
+	for _, step := range []string{"Mock-getPet-200", "Mock-deletePet-204"} {
+		re := regexp.MustCompile(`<Name>` + step + `</Name>\s*<Condition>request.header.x-mock-status = null</Condition>`)
+		if !re.MatchString(proxyEndpoint) {
+			t.Errorf("expected the default step %s to run without the status header in %s", step, proxyEndpoint)
+		}
+	}
+}
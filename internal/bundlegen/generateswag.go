@@ -880,6 +880,14 @@ func GetQuotaPolicies() map[string]string {
 	return quotaPolicyContent
 }
 
+func GetMockPolicies() map[string]string {
+	return mockPolicyContent
+}
+
+func GetExtensionPolicies() map[string]string {
+	return extensionPolicyContent
+}
+
 func isFileYaml(name string) bool {
 	if filepath.Ext(name) == ".yaml" || filepath.Ext(name) == ".yml" {
 		return true
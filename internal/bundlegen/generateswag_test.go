@@ -39,7 +39,7 @@ func TestSwagSpecs(t *testing.T) {
 
 func testLoadSwaggerFromFile(specName string, t *testing.T) {
 	var err error
-	if err = clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err = clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -0,0 +1,456 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	proxytypes "internal/bundlegen/common"
+)
+
+// Severity is the severity of a finding
+type Severity string
+
+const (
+	Error   Severity = "error"
+	Warning Severity = "warning"
+	Note    Severity = "note"
+)
+
+// Rule is a check run by the linter
+type Rule struct {
+	ID          string
+	Severity    Severity
+	Description string
+}
+
+// Finding is a rule violation in a file of the bundle
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	File     string   `json:"file"`
+	Line     int      `json:"line,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Options are the inputs of the linter besides the bundle
+type Options struct {
+	// ExportFolder is a folder created by apigeecli organizations export. When set,
+	// the target servers and KVMs referenced by the bundle must be in it
+	ExportFolder string
+}
+
+// Rules are the checks run by Lint
+var Rules = []Rule{
+	{"undefined-policy", Error, "A policy listed in the bundle descriptor has no policy file"},
+	{"unused-policy", Warning, "A policy is not referenced by any step"},
+	{"missing-policy", Error, "A step references a policy that does not exist"},
+	{"duplicate-condition", Warning, "Conditional flows of an endpoint have the same condition"},
+	{"missing-target-server", Error, "A target server is referenced but absent from the export folder"},
+	{"missing-kvm", Error, "A key value map is referenced but absent from the export folder"},
+	{"hardcoded-credential", Error, "A credential is set as a literal value"},
+	{"missing-fault-rules", Warning, "An endpoint has no fault rules and no default fault rule"},
+}
+
+type endpointDef struct {
+	XMLName              xml.Name                `xml:""`
+	Name                 string                  `xml:"name,attr"`
+	FaultRules           *faultRulesDef          `xml:"FaultRules"`
+	DefaultFaultRule     *faultRuleDef           `xml:"DefaultFaultRule"`
+	PreFlow              proxytypes.PreFlowDef   `xml:"PreFlow"`
+	PostFlow             proxytypes.PostFlowDef  `xml:"PostFlow"`
+	Flows                proxytypes.FlowsDef     `xml:"Flows"`
+	PostClientFlow       postClientFlowDef       `xml:"PostClientFlow"`
+	HTTPTargetConnection httpTargetConnectionDef `xml:"HTTPTargetConnection"`
+	// Step are the steps of a sharedflow
+	Step []proxytypes.StepDef `xml:"Step"`
+}
+
+type faultRulesDef struct {
+	FaultRule []faultRuleDef `xml:"FaultRule"`
+}
+
+type faultRuleDef struct {
+	Name string               `xml:"name,attr"`
+	Step []proxytypes.StepDef `xml:"Step"`
+}
+
+type postClientFlowDef struct {
+	Response proxytypes.ResponseFlowDef `xml:"Response"`
+}
+
+type httpTargetConnectionDef struct {
+	LoadBalancer struct {
+		Server []struct {
+			Name string `xml:"name,attr"`
+		} `xml:"Server"`
+	} `xml:"LoadBalancer"`
+}
+
+type descriptorDef struct {
+	Policies struct {
+		Policy []string `xml:"Policy"`
+	} `xml:"Policies"`
+}
+
+type policyDef struct {
+	XMLName       xml.Name `xml:""`
+	Name          string   `xml:"name,attr"`
+	MapIdentifier string   `xml:"mapIdentifier,attr"`
+}
+
+// exportSplitter separates the parts of the file names of an export folder
+const exportSplitter = "__"
+
+type bundle struct {
+	proxytypes.Bundle
+}
+
+// credentialElements are the elements whose literal text is a credential
+var credentialElements = map[string]bool{
+	"password": true, "secret": true, "clientsecret": true, "apikey": true,
+	"privatekey": true, "secretkey": true, "secretaccesskey": true, "sharedsecret": true,
+}
+
+// credentialNames are the header and query parameter names whose literal value is a credential
+var credentialNames = map[string]bool{
+	"authorization": true, "apikey": true, "api_key": true, "x-api-key": true,
+	"client_secret": true, "password": true,
+}
+
+var secretPatterns = []struct {
+	kind string
+	re   *regexp.Regexp
+}{
+	{"private key", regexp.MustCompile(`-----BEGIN ([A-Z]+ )?PRIVATE KEY-----`)},
+	{"AWS access key", regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`)},
+	{"Google API key", regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{"basic auth", regexp.MustCompile(`(?i)\bBasic [A-Za-z0-9+/]{12,}={0,2}`)},
+	{"credentials in URL", regexp.MustCompile(`https?://[^/\s:@"<]+:[^/\s:@"<]+@`)},
+}
+
+// Lint checks an API proxy or sharedflow bundle, a zip file or a folder, without
+// connecting to Apigee. The findings are sorted by file and line
+func Lint(bundlePath string, opts Options) (findings []Finding, err error) {
+	bb, err := proxytypes.ReadBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	b := bundle{bb}
+
+	policies := map[string]string{}
+	kvms := map[string]string{}
+	endpoints := map[string]endpointDef{}
+	for _, name := range b.Names() {
+		content := b.Files[name]
+		switch {
+		case b.In(name, "policies"):
+			p := policyDef{}
+			if err = xml.Unmarshal(content, &p); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			if p.Name == "" {
+				p.Name = strings.TrimSuffix(path.Base(name), ".xml")
+			}
+			policies[p.Name] = name
+			if p.XMLName.Local == "KeyValueMapOperations" && p.MapIdentifier != "" {
+				kvms[p.MapIdentifier] = name
+			}
+		case b.In(name, "proxies"), b.In(name, "targets"), b.In(name, "sharedflows"):
+			e := endpointDef{}
+			if err = xml.Unmarshal(content, &e); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			endpoints[name] = e
+		}
+	}
+
+	if descriptor := b.Descriptor(); descriptor != "" {
+		d := descriptorDef{}
+		if err = xml.Unmarshal(b.Files[descriptor], &d); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", descriptor, err)
+		}
+		for _, p := range d.Policies.Policy {
+			if _, ok := policies[strings.TrimSpace(p)]; !ok {
+				findings = append(findings, b.finding("undefined-policy", descriptor, ">"+strings.TrimSpace(p)+"<",
+					"policy %s is listed but there is no policy file for it", strings.TrimSpace(p)))
+			}
+		}
+	}
+
+	referenced := map[string]bool{}
+	for name, e := range endpoints {
+		for _, s := range e.steps() {
+			referenced[s] = true
+			if _, ok := policies[s]; !ok {
+				findings = append(findings, b.finding("missing-policy", name, ">"+s+"<",
+					"step references policy %s, which does not exist", s))
+			}
+		}
+		findings = append(findings, b.duplicateConditions(name, e)...)
+		if !b.In(name, "sharedflows") && (e.FaultRules == nil || len(e.FaultRules.FaultRule) == 0) &&
+			e.DefaultFaultRule == nil {
+			findings = append(findings, b.finding("missing-fault-rules", name, "",
+				"endpoint %s has no fault rules and no default fault rule", e.Name))
+		}
+	}
+	for p, name := range policies {
+		if !referenced[p] {
+			findings = append(findings, b.finding("unused-policy", name, "",
+				"policy %s is not referenced by any step", p))
+		}
+	}
+
+	if opts.ExportFolder != "" {
+		targetServers, maps, err := readExport(opts.ExportFolder)
+		if err != nil {
+			return nil, err
+		}
+		for name, e := range endpoints {
+			for _, s := range e.HTTPTargetConnection.LoadBalancer.Server {
+				if !targetServers[s.Name] {
+					findings = append(findings, b.finding("missing-target-server", name, `"`+s.Name+`"`,
+						"target server %s is not in the export folder", s.Name))
+				}
+			}
+		}
+		for m, name := range kvms {
+			if !maps[m] {
+				findings = append(findings, b.finding("missing-kvm", name, `"`+m+`"`,
+					"key value map %s is not in the export folder", m))
+			}
+		}
+	}
+
+	for _, name := range b.Names() {
+		findings = append(findings, b.credentials(name)...)
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		if findings[i].Line != findings[j].Line {
+			return findings[i].Line < findings[j].Line
+		}
+		return findings[i].Rule < findings[j].Rule
+	})
+	// a policy referenced by several steps of a file is reported once
+	return slices.Compact(findings), nil
+}
+
+// Count returns the number of findings of a severity
+func Count(findings []Finding, severity Severity) (count int) {
+	for _, f := range findings {
+		if f.Severity == severity {
+			count++
+		}
+	}
+	return count
+}
+
+func (e endpointDef) steps() (names []string) {
+	add := func(steps []*proxytypes.StepDef) {
+		for _, s := range steps {
+			names = append(names, strings.TrimSpace(s.Name))
+		}
+	}
+	add(e.PreFlow.Request.Step)
+	add(e.PreFlow.Response.Step)
+	add(e.PostFlow.Request.Step)
+	add(e.PostFlow.Response.Step)
+	add(e.PostClientFlow.Response.Step)
+	for _, f := range e.Flows.Flow {
+		add(f.Request.Step)
+		add(f.Response.Step)
+	}
+	faultRules := []faultRuleDef{}
+	if e.FaultRules != nil {
+		faultRules = append(faultRules, e.FaultRules.FaultRule...)
+	}
+	if e.DefaultFaultRule != nil {
+		faultRules = append(faultRules, *e.DefaultFaultRule)
+	}
+	for _, r := range faultRules {
+		for _, s := range r.Step {
+			names = append(names, strings.TrimSpace(s.Name))
+		}
+	}
+	for _, s := range e.Step {
+		names = append(names, strings.TrimSpace(s.Name))
+	}
+	return names
+}
+
+func (b bundle) duplicateConditions(name string, e endpointDef) (findings []Finding) {
+	seen := map[string]string{}
+	for _, f := range e.Flows.Flow {
+		condition := strings.Join(strings.Fields(html.UnescapeString(f.Condition.ConditionData)), " ")
+		if condition == "" {
+			continue
+		}
+		if first, ok := seen[condition]; ok {
+			findings = append(findings, b.finding("duplicate-condition", name, `name="`+f.Name+`"`,
+				"flow %s has the same condition as flow %s and is never executed", f.Name, first))
+			continue
+		}
+		seen[condition] = f.Name
+	}
+	return findings
+}
+
+// credentials finds literal credentials in the XML elements and the resources of the bundle
+func (b bundle) credentials(name string) (findings []Finding) {
+	content := b.Files[name]
+	if strings.HasSuffix(name, ".xml") {
+		d := xml.NewDecoder(bytes.NewReader(content))
+		stack := []xml.StartElement{}
+		for {
+			tok, err := d.Token()
+			if err != nil {
+				break
+			}
+			switch t := tok.(type) {
+			case xml.StartElement:
+				stack = append(stack, t)
+			case xml.EndElement:
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			case xml.CharData:
+				value := strings.TrimSpace(string(t))
+				if len(stack) == 0 || value == "" || strings.HasPrefix(value, "{") {
+					continue
+				}
+				if element := credentialElement(stack); element != "" {
+					line, _ := d.InputPos()
+					findings = append(findings, Finding{
+						Rule: "hardcoded-credential", Severity: Error, File: name, Line: line,
+						Message: fmt.Sprintf("%s is set as a literal value, use a ref to a KVM entry or a variable", element),
+					})
+				}
+			}
+		}
+	}
+	for _, p := range secretPatterns {
+		if loc := p.re.FindIndex(content); loc != nil {
+			findings = append(findings, Finding{
+				Rule: "hardcoded-credential", Severity: Error, File: name,
+				Line:    bytes.Count(content[:loc[0]], []byte("\n")) + 1,
+				Message: fmt.Sprintf("%s found in the file", p.kind),
+			})
+		}
+	}
+	return findings
+}
+
+// credentialElement returns the name of the credential an element holds, if any
+func credentialElement(stack []xml.StartElement) string {
+	e := stack[len(stack)-1]
+	for _, a := range e.Attr {
+		if a.Name.Local == "ref" {
+			return ""
+		}
+	}
+	if credentialElements[strings.ToLower(e.Name.Local)] {
+		return e.Name.Local
+	}
+	if e.Name.Local == "Value" && len(stack) > 1 && credentialElements[strings.ToLower(stack[len(stack)-2].Name.Local)] {
+		return stack[len(stack)-2].Name.Local
+	}
+	if e.Name.Local == "Header" || e.Name.Local == "QueryParam" || e.Name.Local == "FormParam" {
+		for _, a := range e.Attr {
+			if a.Name.Local == "name" && credentialNames[strings.ToLower(a.Value)] {
+				return a.Value + " " + strings.ToLower(e.Name.Local)
+			}
+		}
+	}
+	return ""
+}
+
+func (b bundle) finding(rule string, file string, needle string, format string, a ...interface{}) Finding {
+	f := Finding{Rule: rule, File: file, Message: fmt.Sprintf(format, a...)}
+	for _, r := range Rules {
+		if r.ID == rule {
+			f.Severity = r.Severity
+		}
+	}
+	if i := bytes.Index(b.Files[file], []byte(needle)); needle != "" && i >= 0 {
+		f.Line = bytes.Count(b.Files[file][:i], []byte("\n")) + 1
+	} else {
+		f.Line = 1
+	}
+	return f
+}
+
+// readExport reads the target server and KVM names of an organizations export folder
+func readExport(folder string) (targetServers map[string]bool, maps map[string]bool, err error) {
+	targetServers, maps = map[string]bool{}, map[string]bool{}
+	err = filepath.WalkDir(folder, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		name := filepath.Base(p)
+		switch {
+		case strings.HasSuffix(name, exportSplitter+"targetservers.json"):
+			l := []struct {
+				Name string `json:"name"`
+			}{}
+			if err = readJSON(p, &l); err != nil {
+				return err
+			}
+			for _, t := range l {
+				targetServers[t.Name] = true
+			}
+		case strings.HasSuffix(name, exportSplitter+"kvms.json"):
+			l := []string{}
+			if err = readJSON(p, &l); err != nil {
+				return err
+			}
+			for _, m := range l {
+				maps[m] = true
+			}
+		case strings.Contains(name, exportSplitter+"kvmfile"+exportSplitter):
+			// for ex: env__test__map__kvmfile__0.json
+			parts := strings.Split(name, exportSplitter)
+			maps[parts[len(parts)-3]] = true
+		}
+		return nil
+	})
+	return targetServers, maps, err
+}
+
+func readJSON(fileName string, v interface{}) error {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(content, v); err != nil {
+		return fmt.Errorf("error parsing %s: %w", fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testBundle = map[string]string{
+	"apiproxy/orders.xml": `<APIProxy name="orders">
+  <Policies>
+    <Policy>VerifyKey</Policy>
+    <Policy>Deleted</Policy>
+  </Policies>
+</APIProxy>`,
+	"apiproxy/proxies/default.xml": `<ProxyEndpoint name="default">
+  <PreFlow name="PreFlow">
+    <Request>
+      <Step><Name>VerifyKey</Name></Step>
+      <Step><Name>Missing</Name></Step>
+    </Request>
+  </PreFlow>
+  <Flows>
+    <Flow name="list">
+      <Condition>(proxy.pathsuffix MatchesPath "/orders") and (request.verb = "GET")</Condition>
+    </Flow>
+    <Flow name="list-again">
+      <Condition>(proxy.pathsuffix MatchesPath "/orders")  and (request.verb = "GET")</Condition>
+    </Flow>
+  </Flows>
+  <RouteRule name="default"><TargetEndpoint>default</TargetEndpoint></RouteRule>
+</ProxyEndpoint>`,
+	"apiproxy/targets/default.xml": `<TargetEndpoint name="default">
+  <DefaultFaultRule name="all"><Step><Name>GetSecret</Name></Step></DefaultFaultRule>
+  <HTTPTargetConnection>
+    <LoadBalancer><Server name="backend"/><Server name="absent"/></LoadBalancer>
+  </HTTPTargetConnection>
+</TargetEndpoint>`,
+	"apiproxy/policies/VerifyKey.xml": `<VerifyAPIKey name="VerifyKey"><APIKey ref="request.header.x-apikey"/></VerifyAPIKey>`,
+	"apiproxy/policies/GetSecret.xml": `<KeyValueMapOperations name="GetSecret" mapIdentifier="secrets">
+  <Get assignTo="private.secret"><Key><Parameter>secret</Parameter></Key></Get>
+</KeyValueMapOperations>`,
+	"apiproxy/policies/Unused.xml": `<AssignMessage name="Unused">
+  <Set>
+    <Headers><Header name="Authorization">Bearer abc</Header></Headers>
+  </Set>
+</AssignMessage>`,
+}
+
+func TestLint(t *testing.T) {
+	folder := t.TempDir()
+	for name, content := range testBundle {
+		if err := os.MkdirAll(filepath.Join(folder, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	export := t.TempDir()
+	if err := os.WriteFile(filepath.Join(export, "test__targetservers.json"),
+		[]byte(`[{"name":"backend","host":"example.com"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := Lint(filepath.Join(folder, "apiproxy"), Options{ExportFolder: export})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]int{}
+	for _, f := range findings {
+		got[f.Rule]++
+	}
+	want := map[string]int{
+		"undefined-policy":      1,
+		"missing-policy":        1,
+		"unused-policy":         1,
+		"duplicate-condition":   1,
+		"missing-fault-rules":   1,
+		"missing-target-server": 1,
+		"missing-kvm":           1,
+		"hardcoded-credential":  1,
+	}
+	for rule, count := range want {
+		if got[rule] != count {
+			t.Errorf("%s: got %d findings, want %d: %v", rule, got[rule], count, findings)
+		}
+	}
+
+	b := bytes.Buffer{}
+	if err = WriteSARIF(&b, findings); err != nil {
+		t.Fatal(err)
+	}
+	log := sarifLog{}
+	if err = json.Unmarshal(b.Bytes(), &log); err != nil {
+		t.Fatal(err)
+	}
+	if len(log.Runs) != 1 || len(log.Runs[0].Results) != len(findings) {
+		t.Fatalf("unexpected SARIF log %s", b.String())
+	}
+	b.Reset()
+	err = Run(&b, filepath.Join(folder, "apiproxy"), Options{ExportFolder: export}, "text")
+	if want := fmt.Sprintf("lint found %d errors", Count(findings, Error)); err == nil || err.Error() != want {
+		t.Fatalf("expected %q, got %v", want, err)
+	}
+	if !strings.HasSuffix(b.String(), fmt.Sprintf("%d errors, %d warnings\n", Count(findings, Error), Count(findings, Warning))) {
+		t.Fatalf("unexpected text report %s", b.String())
+	}
+	if err = CheckFormat("yaml"); err == nil {
+		t.Fatal("expected an invalid output error")
+	}
+}
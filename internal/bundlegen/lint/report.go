@@ -0,0 +1,166 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+const sarifSchema = "https://json.schemastore.org/sarif-2.1.0.json"
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level Severity `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     Severity        `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation struct {
+		ArtifactLocation struct {
+			URI string `json:"uri"`
+		} `json:"artifactLocation"`
+		Region struct {
+			StartLine int `json:"startLine"`
+		} `json:"region"`
+	} `json:"physicalLocation"`
+}
+
+// Formats are the output formats of Write
+var Formats = []string{"text", "json", "sarif"}
+
+// Write writes the findings in a format of Formats
+func Write(w io.Writer, findings []Finding, format string) error {
+	switch format {
+	case "text":
+		WriteText(w, findings)
+		return nil
+	case "json":
+		return WriteJSON(w, findings)
+	case "sarif":
+		return WriteSARIF(w, findings)
+	}
+	return CheckFormat(format)
+}
+
+// CheckFormat returns an error when format is not one of Formats
+func CheckFormat(format string) error {
+	if !slices.Contains(Formats, format) {
+		return fmt.Errorf("invalid output %s, must be one of %s", format, strings.Join(Formats, ", "))
+	}
+	return nil
+}
+
+// Run lints a bundle, writes the findings in format and returns an error
+// when some findings are errors
+func Run(w io.Writer, bundlePath string, opts Options, format string) error {
+	findings, err := Lint(bundlePath, opts)
+	if err != nil {
+		return err
+	}
+	if err = Write(w, findings, format); err != nil {
+		return err
+	}
+	if count := Count(findings, Error); count > 0 {
+		return fmt.Errorf("lint found %d errors", count)
+	}
+	return nil
+}
+
+// WriteText writes the findings one per line, for ex: apiproxy/proxies/default.xml:12: error ...
+func WriteText(w io.Writer, findings []Finding) {
+	for _, f := range findings {
+		fmt.Fprintf(w, "%s:%d: %s %s: %s\n", f.File, f.Line, f.Severity, f.Rule, f.Message)
+	}
+	fmt.Fprintf(w, "%d errors, %d warnings\n", Count(findings, Error), Count(findings, Warning))
+}
+
+// WriteJSON writes the findings as a JSON array
+func WriteJSON(w io.Writer, findings []Finding) error {
+	if findings == nil {
+		findings = []Finding{}
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(findings)
+}
+
+// WriteSARIF writes the findings as a SARIF 2.1.0 log, with the file paths relative
+// to the folder that contains the apiproxy or sharedflowbundle folder
+func WriteSARIF(w io.Writer, findings []Finding) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "apigeecli",
+			InformationURI: "https://github.com/apigee/apigeecli",
+		}},
+		Results: []sarifResult{},
+	}
+	for _, r := range Rules {
+		sr := sarifRule{ID: r.ID, ShortDescription: sarifMessage{Text: r.Description}}
+		sr.DefaultConfiguration.Level = r.Severity
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sr)
+	}
+	for _, f := range findings {
+		l := sarifLocation{}
+		l.PhysicalLocation.ArtifactLocation.URI = f.File
+		l.PhysicalLocation.Region.StartLine = max(f.Line, 1)
+		run.Results = append(run.Results, sarifResult{
+			RuleID:    f.Rule,
+			Level:     f.Severity,
+			Message:   sarifMessage{Text: f.Message},
+			Locations: []sarifLocation{l},
+		})
+	}
+	e := json.NewEncoder(w)
+	e.SetIndent("", "  ")
+	return e.Encode(sarifLog{Schema: sarifSchema, Version: "2.1.0", Runs: []sarifRun{run}})
+}
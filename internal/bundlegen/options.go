@@ -31,4 +31,5 @@ type HttpBackendOptions struct {
 type TargetOptions struct {
 	IntegrationBackend IntegrationBackendOptions
 	HttpBackend        HttpBackendOptions
+	Mock               bool
 }
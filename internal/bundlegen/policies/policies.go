@@ -17,6 +17,7 @@ package policies
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -223,6 +224,78 @@ var extractJwtHeaderPolicy = `<?xml version="1.0" encoding="UTF-8" standalone="y
 	<IgnoreUnresolvedVariables>true</IgnoreUnresolvedVariables>
 </ExtractVariables>`
 
+var mockResponsePolicy = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<AssignMessage async="false" continueOnError="false" enabled="true" name="Mock-Response-1">
+    <DisplayName>Mock-Response-1</DisplayName>
+    <Properties/>
+    <Set>
+        <Payload contentType="content_type" variablePrefix="@" variableSuffix="#">mock_payload</Payload>
+        <StatusCode>200</StatusCode>
+    </Set>
+    <IgnoreUnresolvedVariables>true</IgnoreUnresolvedVariables>
+    <AssignTo createNew="false" transport="http" type="response"/>
+</AssignMessage>`
+
+var responseCachePolicy = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<ResponseCache async="false" continueOnError="false" enabled="true" name="Response-Cache-1">
+    <DisplayName>Response-Cache-1</DisplayName>
+    <Properties/>
+    <CacheKey>
+        <KeyFragment ref="request.uri" type="string"/>
+    </CacheKey>
+    <Scope>Exclusive</Scope>
+    <ExpirySettings>
+        <TimeoutInSeconds>300</TimeoutInSeconds>
+    </ExpirySettings>
+</ResponseCache>`
+
+var jsonThreatProtectionPolicy = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<JSONThreatProtection async="false" continueOnError="false" enabled="true" name="JSON-Threat-Protection-1">
+    <DisplayName>JSON-Threat-Protection-1</DisplayName>
+    <Properties/>
+    <ArrayElementCount>20</ArrayElementCount>
+    <ContainerDepth>10</ContainerDepth>
+    <ObjectEntryCount>15</ObjectEntryCount>
+    <ObjectEntryNameLength>50</ObjectEntryNameLength>
+    <Source>request</Source>
+    <StringValueLength>500</StringValueLength>
+</JSONThreatProtection>`
+
+var headersPolicy = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<AssignMessage async="false" continueOnError="false" enabled="true" name="Headers-1">
+    <DisplayName>Headers-1</DisplayName>
+    <Properties/>
+    <Remove>
+        <Headers>remove_headers
+        </Headers>
+    </Remove>
+    <Set>
+        <Headers>set_headers
+        </Headers>
+    </Set>
+    <IgnoreUnresolvedVariables>true</IgnoreUnresolvedVariables>
+    <AssignTo createNew="false" transport="http" type="request"/>
+</AssignMessage>`
+
+var kvmPolicy = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<KeyValueMapOperations async="false" continueOnError="false" enabled="true" name="KVM-1" mapIdentifier="map_name">
+    <DisplayName>KVM-1</DisplayName>
+    <Properties/>
+    <ExpiryTimeInSecs>300</ExpiryTimeInSecs>get_entries
+    <Scope>environment</Scope>
+</KeyValueMapOperations>`
+
+var messageLoggingPolicy = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<MessageLogging async="false" continueOnError="true" enabled="true" name="Message-Logging-1">
+    <DisplayName>Message-Logging-1</DisplayName>
+    <Properties/>
+    <CloudLogging>
+        <LogName>projects/{organization.name}/logs/log_name</LogName>
+        <Message contentType="text/plain">{request.verb} {proxy.pathsuffix} {response.status.code}</Message>
+        <ResourceType>api</ResourceType>
+    </CloudLogging>
+</MessageLogging>`
+
 var copyAuth = false
 
 func AddSetIntegrationRequestPolicy(integration string, apitrigger string) string {
@@ -393,6 +466,149 @@ func AddExtractJwtHeaderPolicy(name string, headerName string, prefixName string
 	return policyString
 }
 
+// AddMockResponsePolicy returns an AssignMessage policy setting the status code and payload of the response;
+// the payload is left out when the content type is empty
+func AddMockResponsePolicy(name string, statusCode string, contentType string, payload string) string {
+	policyString := strings.ReplaceAll(mockResponsePolicy, "Mock-Response-1", name)
+	policyString = strings.ReplaceAll(policyString, "<StatusCode>200</StatusCode>", "<StatusCode>"+statusCode+"</StatusCode>")
+	if contentType == "" {
+		return strings.ReplaceAll(policyString,
+			"\n        <Payload contentType=\"content_type\" variablePrefix=\"@\" variableSuffix=\"#\">mock_payload</Payload>", "")
+	}
+	escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	policyString = strings.ReplaceAll(policyString, "content_type", contentType)
+	return strings.ReplaceAll(policyString, "mock_payload", escaper.Replace(payload))
+}
+
+// AddCustomCORSPolicy returns a CORS policy; empty values keep the values of the Add-CORS policy
+func AddCustomCORSPolicy(name string, allowOrigins string, allowMethods string, allowHeaders string,
+	exposeHeaders string, maxAge string, allowCredentials string,
+) string {
+	policyString := strings.ReplaceAll(corsPolicy, "name=\"Add-CORS\"", "name=\""+name+"\"")
+	policyString = strings.ReplaceAll(policyString, "<DisplayName>Add CORS</DisplayName>", "<DisplayName>"+name+"</DisplayName>")
+	for element, value := range map[string]string{
+		"AllowOrigins":     allowOrigins,
+		"AllowMethods":     allowMethods,
+		"AllowHeaders":     allowHeaders,
+		"ExposeHeaders":    exposeHeaders,
+		"MaxAge":           maxAge,
+		"AllowCredentials": allowCredentials,
+	} {
+		if value != "" {
+			re := regexp.MustCompile("<" + element + ">.*</" + element + ">")
+			policyString = re.ReplaceAllLiteralString(policyString, "<"+element+">"+value+"</"+element+">")
+		}
+	}
+	return policyString
+}
+
+// AddResponseCachePolicy returns a ResponseCache policy keyed by the request uri or by the key fragment references
+func AddResponseCachePolicy(name string, keyFragmentRefs []string, timeoutInSeconds string) string {
+	policyString := strings.ReplaceAll(responseCachePolicy, "Response-Cache-1", name)
+	if len(keyFragmentRefs) > 0 {
+		keyFragments := []string{}
+		for _, ref := range keyFragmentRefs {
+			keyFragments = append(keyFragments, "<KeyFragment ref=\""+ref+"\" type=\"string\"/>")
+		}
+		policyString = strings.ReplaceAll(policyString, "<KeyFragment ref=\"request.uri\" type=\"string\"/>",
+			strings.Join(keyFragments, "\n        "))
+	}
+	if timeoutInSeconds != "" {
+		policyString = strings.ReplaceAll(policyString, "<TimeoutInSeconds>300</TimeoutInSeconds>",
+			"<TimeoutInSeconds>"+timeoutInSeconds+"</TimeoutInSeconds>")
+	}
+	return policyString
+}
+
+// AddJSONThreatProtectionPolicy returns a JSONThreatProtection policy; zero limits keep the default limits
+func AddJSONThreatProtectionPolicy(name string, arrayElementCount int, containerDepth int,
+	objectEntryCount int, objectEntryNameLength int, stringValueLength int,
+) string {
+	policyString := strings.ReplaceAll(jsonThreatProtectionPolicy, "JSON-Threat-Protection-1", name)
+	for element, value := range map[string]int{
+		"ArrayElementCount":     arrayElementCount,
+		"ContainerDepth":        containerDepth,
+		"ObjectEntryCount":      objectEntryCount,
+		"ObjectEntryNameLength": objectEntryNameLength,
+		"StringValueLength":     stringValueLength,
+	} {
+		if value != 0 {
+			re := regexp.MustCompile("<" + element + ">[0-9]+</" + element + ">")
+			policyString = re.ReplaceAllLiteralString(policyString, fmt.Sprintf("<%s>%d</%s>", element, value, element))
+		}
+	}
+	return policyString
+}
+
+// AddHeadersPolicy returns an AssignMessage policy setting and removing headers of the request or the response
+func AddHeadersPolicy(name string, message string, setHeaders map[string]string, removeHeaders []string) string {
+	policyString := strings.ReplaceAll(headersPolicy, "Headers-1", name)
+	policyString = strings.ReplaceAll(policyString, "type=\"request\"", "type=\""+message+"\"")
+
+	escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;")
+	if len(removeHeaders) == 0 {
+		policyString = strings.ReplaceAll(policyString,
+			"\n    <Remove>\n        <Headers>remove_headers\n        </Headers>\n    </Remove>", "")
+	} else {
+		headers := ""
+		for _, header := range removeHeaders {
+			headers += "\n            <Header name=\"" + escaper.Replace(header) + "\"/>"
+		}
+		policyString = strings.ReplaceAll(policyString, "remove_headers", headers)
+	}
+	if len(setHeaders) == 0 {
+		policyString = strings.ReplaceAll(policyString,
+			"\n    <Set>\n        <Headers>set_headers\n        </Headers>\n    </Set>", "")
+	} else {
+		names := []string{}
+		for header := range setHeaders {
+			names = append(names, header)
+		}
+		sort.Strings(names)
+		headers := ""
+		for _, header := range names {
+			headers += "\n            <Header name=\"" + escaper.Replace(header) + "\">" + escaper.Replace(setHeaders[header]) + "</Header>"
+		}
+		policyString = strings.ReplaceAll(policyString, "set_headers", headers)
+	}
+	return policyString
+}
+
+// AddKVMGetPolicy returns a KeyValueMapOperations policy assigning the values of keys (by variable) of a map
+func AddKVMGetPolicy(name string, mapIdentifier string, scope string, entries map[string]string) string {
+	policyString := strings.ReplaceAll(kvmPolicy, "KVM-1", name)
+	policyString = strings.ReplaceAll(policyString, "map_name", mapIdentifier)
+	if scope != "" {
+		policyString = strings.ReplaceAll(policyString, "<Scope>environment</Scope>", "<Scope>"+scope+"</Scope>")
+	}
+	variables := []string{}
+	for variable := range entries {
+		variables = append(variables, variable)
+	}
+	sort.Strings(variables)
+	gets := ""
+	for _, variable := range variables {
+		gets += "\n    <Get assignTo=\"" + variable + "\">\n        <Key>\n            <Parameter>" +
+			entries[variable] + "</Parameter>\n        </Key>\n    </Get>"
+	}
+	return strings.ReplaceAll(policyString, "get_entries", gets)
+}
+
+// AddMessageLoggingPolicy returns a MessageLogging policy writing to a Cloud Logging log of the project of the org
+func AddMessageLoggingPolicy(name string, logName string, contentType string, message string) string {
+	policyString := strings.ReplaceAll(messageLoggingPolicy, "Message-Logging-1", name)
+	policyString = strings.ReplaceAll(policyString, "log_name", logName)
+	if contentType != "" {
+		policyString = strings.ReplaceAll(policyString, "contentType=\"text/plain\"", "contentType=\""+contentType+"\"")
+	}
+	if message != "" {
+		escaper := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+		policyString = strings.ReplaceAll(policyString, "{request.verb} {proxy.pathsuffix} {response.status.code}",
+			escaper.Replace(message))
+	}
+	return policyString
+}
+
 // TODO: Unused at the moment
 func AddSetAuthVarPolicy(auth bool) string {
 	policyString := strings.ReplaceAll(setAuthVariablePolicy, "<Value>false</Value>", fmt.Sprintf("<Value>%t</Value>", auth))
@@ -0,0 +1,96 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policies
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// policyTemplates maps the file names of the policy templates to the built-in templates.
+// An overriding template must keep the placeholders of the built-in template, ex: Spike-Arrest-1
+var policyTemplates = map[string]*string{
+	"oas-validation.xml":          &oasPolicyTemplate,
+	"verify-api-key.xml":          &verifyApiKeyPolicy,
+	"oauth2.xml":                  &oauth2Policy,
+	"cors.xml":                    &corsPolicy,
+	"spike-arrest.xml":            &spikeArrestPolicy,
+	"quota.xml":                   &quotaPolicy1,
+	"quota-product.xml":           &quotaPolicy2,
+	"graphql.xml":                 &graphQLPolicy,
+	"set-target-ref.xml":          &setTargetEndpointRefPolicy,
+	"set-target.xml":              &setTargetEndpointPolicy,
+	"set-auth-variable.xml":       &setAuthVariablePolicy,
+	"copy-auth-header.xml":        &copyAuthHeaderPolicy,
+	"set-integration-request.xml": &setIntegrationRequestPolicy,
+	"verify-jwt.xml":              &verifyJwtPolicy,
+	"raise-fault.xml":             &rasiseFaultPolicy,
+	"extract-jwt-query.xml":       &extractJwtQueryPolicy,
+	"extract-jwt-header.xml":      &extractJwtHeaderPolicy,
+	"mock-response.xml":           &mockResponsePolicy,
+	"response-cache.xml":          &responseCachePolicy,
+	"json-threat-protection.xml":  &jsonThreatProtectionPolicy,
+	"headers.xml":                 &headersPolicy,
+	"kvm.xml":                     &kvmPolicy,
+	"message-logging.xml":         &messageLoggingPolicy,
+}
+
+// GetPolicyTemplateNames returns the file names of the policy templates
+func GetPolicyTemplateNames() []string {
+	names := []string{}
+	for name := range policyTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetPolicyTemplate returns a policy template, overridden or built-in
+func GetPolicyTemplate(name string) (string, error) {
+	policyTemplate, ok := policyTemplates[name]
+	if !ok {
+		return "", fmt.Errorf("unknown policy template %s, policy templates are %s",
+			name, strings.Join(GetPolicyTemplateNames(), ", "))
+	}
+	return *policyTemplate, nil
+}
+
+// OverridePolicyTemplates replaces the built-in policy templates with the files of a folder
+// named after the policy templates, ex: cors.xml
+func OverridePolicyTemplates(folder string) error {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		policyTemplate, ok := policyTemplates[entry.Name()]
+		if !ok {
+			return fmt.Errorf("unknown policy template %s, policy templates are %s",
+				entry.Name(), strings.Join(GetPolicyTemplateNames(), ", "))
+		}
+		content, err := os.ReadFile(filepath.Join(folder, entry.Name()))
+		if err != nil {
+			return err
+		}
+		*policyTemplate = string(content)
+	}
+	return nil
+}
@@ -0,0 +1,240 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bundlegen
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ProtoDef holds the services of a proto file
+type ProtoDef struct {
+	Package  string
+	Services []ProtoServiceDef
+}
+
+type ProtoServiceDef struct {
+	Name    string
+	Methods []ProtoMethodDef
+}
+
+type ProtoMethodDef struct {
+	Name            string
+	InputType       string
+	OutputType      string
+	ClientStreaming bool
+	ServerStreaming bool
+}
+
+// FullName returns the name of the service qualified with the package of the proto file
+func (p ProtoDef) FullName(service ProtoServiceDef) string {
+	if p.Package == "" {
+		return service.Name
+	}
+	return p.Package + "." + service.Name
+}
+
+type protoParser struct {
+	tokens []string
+	pos    int
+}
+
+// ParseProto reads the package and the services of a proto file. Messages, enums and options are skipped
+func ParseProto(content []byte) (protoDef ProtoDef, err error) {
+	tokens, err := tokenizeProto(string(content))
+	if err != nil {
+		return protoDef, err
+	}
+	p := &protoParser{tokens: tokens}
+	for !p.done() {
+		switch p.peek() {
+		case "package":
+			p.next()
+			protoDef.Package = p.next()
+			if err = p.expect(";"); err != nil {
+				return protoDef, err
+			}
+		case "service":
+			p.next()
+			service, err := p.parseService()
+			if err != nil {
+				return protoDef, err
+			}
+			protoDef.Services = append(protoDef.Services, service)
+		default:
+			if err = p.skipStatement(); err != nil {
+				return protoDef, err
+			}
+		}
+	}
+	if len(protoDef.Services) == 0 {
+		return protoDef, fmt.Errorf("no service found in the proto file")
+	}
+	return protoDef, nil
+}
+
+func (p *protoParser) parseService() (service ProtoServiceDef, err error) {
+	service.Name = p.next()
+	if err = p.expect("{"); err != nil {
+		return service, err
+	}
+	for p.peek() != "}" {
+		if p.done() {
+			return service, fmt.Errorf("service %s is not closed", service.Name)
+		}
+		if p.peek() != "rpc" {
+			if err = p.skipStatement(); err != nil {
+				return service, err
+			}
+			continue
+		}
+		p.next()
+		method := ProtoMethodDef{Name: p.next()}
+		if method.ClientStreaming, method.InputType, err = p.parseMessageType(); err != nil {
+			return service, fmt.Errorf("rpc %s: %v", method.Name, err)
+		}
+		if err = p.expect("returns"); err != nil {
+			return service, fmt.Errorf("rpc %s: %v", method.Name, err)
+		}
+		if method.ServerStreaming, method.OutputType, err = p.parseMessageType(); err != nil {
+			return service, fmt.Errorf("rpc %s: %v", method.Name, err)
+		}
+		// the method ends with a semicolon or a block of options
+		if err = p.skipStatement(); err != nil {
+			return service, err
+		}
+		if p.peek() == ";" {
+			p.next()
+		}
+		service.Methods = append(service.Methods, method)
+	}
+	p.next()
+	return service, nil
+}
+
+func (p *protoParser) parseMessageType() (stream bool, messageType string, err error) {
+	if err = p.expect("("); err != nil {
+		return false, "", err
+	}
+	if p.peek() == "stream" {
+		p.next()
+		stream = true
+	}
+	messageType = p.next()
+	return stream, messageType, p.expect(")")
+}
+
+// skipStatement skips the tokens up to a semicolon or a balanced block
+func (p *protoParser) skipStatement() error {
+	depth := 0
+	for !p.done() {
+		switch p.next() {
+		case "{":
+			depth++
+		case "}":
+			depth--
+			if depth == 0 {
+				return nil
+			}
+			if depth < 0 {
+				return fmt.Errorf("unexpected }")
+			}
+		case ";":
+			if depth == 0 {
+				return nil
+			}
+		}
+	}
+	if depth > 0 {
+		return fmt.Errorf("unexpected end of the proto file")
+	}
+	return nil
+}
+
+func (p *protoParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *protoParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *protoParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *protoParser) expect(token string) error {
+	if got := p.next(); got != token {
+		return fmt.Errorf("expected %q, found %q", token, got)
+	}
+	return nil
+}
+
+// tokenizeProto splits a proto file in identifiers, strings and symbols, dropping the comments
+func tokenizeProto(content string) (tokens []string, err error) {
+	for i := 0; i < len(content); {
+		c := content[i]
+		switch {
+		case unicode.IsSpace(rune(c)):
+			i++
+		case strings.HasPrefix(content[i:], "//"):
+			if end := strings.IndexByte(content[i:], '\n'); end != -1 {
+				i += end
+			} else {
+				i = len(content)
+			}
+		case strings.HasPrefix(content[i:], "/*"):
+			end := strings.Index(content[i+2:], "*/")
+			if end == -1 {
+				return nil, fmt.Errorf("comment is not closed")
+			}
+			i += end + 4
+		case c == '"' || c == '\'':
+			end := i + 1
+			for end < len(content) && content[end] != c {
+				if content[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(content) {
+				return nil, fmt.Errorf("string is not closed")
+			}
+			tokens = append(tokens, content[i:end+1])
+			i = end + 1
+		case isProtoIdentChar(c):
+			end := i
+			for end < len(content) && isProtoIdentChar(content[end]) {
+				end++
+			}
+			tokens = append(tokens, content[i:end])
+			i = end
+		default:
+			tokens = append(tokens, string(c))
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isProtoIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
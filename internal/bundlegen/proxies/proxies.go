@@ -23,7 +23,8 @@ import (
 	proxytypes "internal/bundlegen/common"
 )
 
-type proxyEndpointDef struct {
+// ProxyEndpointDef is the XML model of a proxy endpoint
+type ProxyEndpointDef struct {
 	XMLName             xml.Name               `xml:"ProxyEndpoint"`
 	Name                string                 `xml:"name,attr"`
 	Description         string                 `xml:"Description,omitempty"`
@@ -51,7 +52,13 @@ type httpProxyConnectionDef struct {
 	VirtualHost []string `xml:"VirtualHost"`
 }
 
-var proxyEndpoint proxyEndpointDef
+var proxyEndpoint ProxyEndpointDef
+
+// ParseProxyEndpoint reads a proxy endpoint of a bundle
+func ParseProxyEndpoint(content []byte) (e ProxyEndpointDef, err error) {
+	err = xml.Unmarshal(content, &e)
+	return e, err
+}
 
 func GetProxyEndpoint() (string, error) {
 	proxyBody, err := xml.MarshalIndent(proxyEndpoint, "", " ")
@@ -79,6 +86,16 @@ func NewProxyEndpoint(basePath string, targetEndpoint bool) {
 	proxyEndpoint.RouteRule = append(proxyEndpoint.RouteRule, routeRule)
 }
 
+// NewProxyEndpointWithoutTarget creates a proxy endpoint whose route rule has no target;
+// the response is built by the policies of the proxy endpoint
+func NewProxyEndpointWithoutTarget(basePath string) {
+	proxyEndpoint.Name = "default"
+	proxyEndpoint.PreFlow.Name = "PreFlow"
+	proxyEndpoint.PostFlow.Name = "PostFlow"
+	proxyEndpoint.HTTPProxyConnection.BasePath = basePath
+	proxyEndpoint.RouteRule = append(proxyEndpoint.RouteRule, routeRuleDef{Name: "default"})
+}
+
 func AddFlow(operationId string, keyPath string, method string, description string) {
 	flow := proxytypes.FlowDef{}
 	flow.Name = operationId
@@ -106,6 +123,12 @@ func AddStepToPreFlowRequest(name string) {
 	proxyEndpoint.PreFlow.Request.Step = append(proxyEndpoint.PreFlow.Request.Step, &step)
 }
 
+func AddStepToPreFlowResponse(name string) {
+	step := proxytypes.StepDef{}
+	step.Name = name
+	proxyEndpoint.PreFlow.Response.Step = append(proxyEndpoint.PreFlow.Response.Step, &step)
+}
+
 func AddStepToFlowRequest(name string, flowName string) error {
 	for flowKey, flow := range proxyEndpoint.Flows.Flow {
 		if flow.Name == flowName {
@@ -118,6 +141,20 @@ func AddStepToFlowRequest(name string, flowName string) error {
 	return fmt.Errorf("flow name not found")
 }
 
+// AddStepToFlowResponse adds a step, executed when the condition is true, to the response of a flow
+func AddStepToFlowResponse(name string, flowName string, condition string) error {
+	for flowKey, flow := range proxyEndpoint.Flows.Flow {
+		if flow.Name == flowName {
+			step := proxytypes.StepDef{}
+			step.Name = name
+			step.Condition = condition
+			proxyEndpoint.Flows.Flow[flowKey].Response.Step = append(proxyEndpoint.Flows.Flow[flowKey].Response.Step, &step)
+			return nil
+		}
+	}
+	return fmt.Errorf("flow name not found")
+}
+
 func AddRoute(name string, endpoint string, condition string) {
 	routeRule := routeRuleDef{}
 	routeRule.Name = name
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"internal/apiclient"
 	"internal/bundlegen"
+	"internal/bundlegen/bundletemplate"
 	"internal/bundlegen/targets"
 	"internal/clilog"
 	"io"
@@ -111,7 +112,7 @@ func GenerateAPIProxyBundleFromOAS(name string,
 			return err
 		}
 
-	} else {
+	} else if !targetOptions.Mock {
 
 		if err = os.Mkdir(targetDirPath, os.ModePerm); err != nil {
 			return err
@@ -192,6 +193,20 @@ func GenerateAPIProxyBundleFromOAS(name string,
 		}
 	}
 
+	// add policies of the x-apigee extensions
+	for extensionPolicyName, extensionPolicyContent := range genapi.GetExtensionPolicies() {
+		if err = writeXMLData(policiesDirPath+string(os.PathSeparator)+extensionPolicyName+".xml", extensionPolicyContent); err != nil {
+			return err
+		}
+	}
+
+	// add mock response policies
+	for mockPolicyName, mockPolicyContent := range genapi.GetMockPolicies() {
+		if err = writeXMLData(policiesDirPath+string(os.PathSeparator)+"Mock-"+mockPolicyName+".xml", mockPolicyContent); err != nil {
+			return err
+		}
+	}
+
 	if !skipPolicy {
 		// add oas policy
 		if err = writeXMLData(policiesDirPath+string(os.PathSeparator)+"OpenAPI-Spec-Validation-1.xml",
@@ -333,6 +348,79 @@ func GenerateAPIProxyBundleFromGQL(name string,
 	return nil
 }
 
+func GenerateAPIProxyBundleFromProto(name string) (err error) {
+	var apiProxyData, proxyEndpointData, targetEndpointData string
+
+	tmpDir, err := os.MkdirTemp("", "proxy")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir) // clean up
+
+	tmpProxyRootDir := path.Join(tmpDir, proxyRootDir)
+
+	if err = os.Mkdir(tmpProxyRootDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	// write API Proxy file
+	if apiProxyData, err = apiproxy.GetAPIProxy(); err != nil {
+		return err
+	}
+
+	err = writeXMLData(tmpProxyRootDir+string(os.PathSeparator)+name+".xml", apiProxyData)
+	if err != nil {
+		return err
+	}
+
+	proxiesDirPath := tmpProxyRootDir + string(os.PathSeparator) + "proxies"
+	targetDirPath := tmpProxyRootDir + string(os.PathSeparator) + "targets"
+
+	if err = os.Mkdir(proxiesDirPath, os.ModePerm); err != nil {
+		return err
+	}
+
+	if proxyEndpointData, err = proxies.GetProxyEndpoint(); err != nil {
+		return err
+	}
+
+	err = writeXMLData(proxiesDirPath+string(os.PathSeparator)+"default.xml", proxyEndpointData)
+	if err != nil {
+		return err
+	}
+
+	if err = os.Mkdir(targetDirPath, os.ModePerm); err != nil {
+		return err
+	}
+
+	for _, targetEndpoint := range targets.TargetEndpoints {
+		if targetEndpointData, err = target.GetTargetEndpoint(targetEndpoint); err != nil {
+			return err
+		}
+
+		if err = writeXMLData(targetDirPath+string(os.PathSeparator)+targetEndpoint.Name+".xml", targetEndpointData); err != nil {
+			return err
+		}
+	}
+
+	return archiveBundle(tmpProxyRootDir, name+".zip", false)
+}
+
+// GenerateAPIProxyBundleFromTemplate renders a bundle template to name.zip
+func GenerateAPIProxyBundleFromTemplate(name string, templateDir string, values bundletemplate.Values) (err error) {
+	tmpDir, err := os.MkdirTemp("", "proxy")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir) // clean up
+
+	if err = bundletemplate.Render(templateDir, values, tmpDir); err != nil {
+		return err
+	}
+
+	return archiveBundle(path.Join(tmpDir, proxyRootDir), name+".zip", false)
+}
+
 func GenerateIntegrationAPIProxyBundle(name string, integration string, apitrigger string, skipPolicy bool) (err error) {
 	var apiProxyData, proxyEndpointData, integrationEndpointData string
 
@@ -31,7 +31,7 @@ func TestGenerateAPIProxyBundleFromOAS(t *testing.T) {
 	var contents []byte
 	var err error
 
-	if err = clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err = clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -0,0 +1,453 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package specgen
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	proxytypes "internal/bundlegen/common"
+	"internal/bundlegen/proxies"
+
+	"gopkg.in/yaml.v3"
+)
+
+// InferredExtension marks the operations generated from the proxy flows
+const InferredExtension = "x-apigeecli-inferred"
+
+// Confidence of an inferred operation
+const (
+	High = "high"
+	Low  = "low"
+)
+
+// Options of the spec generation
+type Options struct {
+	// Title and Version override the values read from the bundle
+	Title   string
+	Version string
+}
+
+// Document is an OpenAPI 3.1 document
+type Document struct {
+	OpenAPI    string                               `json:"openapi" yaml:"openapi"`
+	Info       Info                                 `json:"info" yaml:"info"`
+	Servers    []Server                             `json:"servers,omitempty" yaml:"servers,omitempty"`
+	Paths      map[string]map[string]map[string]any `json:"paths" yaml:"paths"`
+	Components *Components                          `json:"components,omitempty" yaml:"components,omitempty"`
+}
+
+// Info of the document
+type Info struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Version     string `json:"version" yaml:"version"`
+}
+
+// Server of the document
+type Server struct {
+	URL string `json:"url" yaml:"url"`
+}
+
+// Components of the document
+type Components struct {
+	SecuritySchemes map[string]map[string]string `json:"securitySchemes,omitempty" yaml:"securitySchemes,omitempty"`
+}
+
+type descriptorDef struct {
+	Name        string `xml:"name,attr"`
+	Revision    string `xml:"revision,attr"`
+	DisplayName string `xml:"DisplayName"`
+	Description string `xml:"Description"`
+}
+
+// policyDef holds the elements of the policies read for the spec
+type policyDef struct {
+	XMLName xml.Name
+	Name    string `xml:"name,attr"`
+	APIKey  struct {
+		Ref string `xml:"ref,attr"`
+	} `xml:"APIKey"`
+	Operation   string `xml:"Operation"`
+	OASResource string `xml:"OASResource"`
+}
+
+// route is a path and verbs read from a flow condition
+type route struct {
+	path       string
+	verbs      []string
+	confidence string
+	reasons    []string
+}
+
+var (
+	pathCondition = regexp.MustCompile(`(?i)proxy\.pathsuffix\s+(MatchesPath|LikePath|~/|Matches|Like|JavaRegex|~~|` +
+		`StartsWith|=\||Equals|Is|==|=)\s+"([^"]*)"`)
+	verbCondition    = regexp.MustCompile(`(?i)request\.verb\s+(Equals|Is|==|=)\s+"([A-Za-z]+)"`)
+	negatedCondition = regexp.MustCompile(`(?i)!=|\bnot\b|\bNotEquals\b|\bIsNot\b|!\s*\(`)
+	regexGroup       = regexp.MustCompile(`\([^)]*\)|\[[^\]]*\][+*]|\.[+*]`)
+	templateParam    = regexp.MustCompile(`\{([^}]+)\}`)
+	methods          = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+)
+
+// Generate writes an OpenAPI 3.1 skeleton from the proxy endpoints of an API proxy bundle. Paths and
+// operations are read from the conditions of the conditional flows, security schemes from the
+// VerifyAPIKey, OAuthV2 and VerifyJWT policies, and operations from the OAS resources of OASValidation
+// policies. Operations inferred from the flows are marked with the x-apigeecli-inferred extension
+func Generate(b proxytypes.Bundle, opts Options) (doc *Document, err error) {
+	if b.Root != "apiproxy" {
+		return nil, fmt.Errorf("specs can only be generated from API proxy bundles")
+	}
+	doc = &Document{OpenAPI: "3.1.0", Paths: map[string]map[string]map[string]any{}}
+	descriptor := descriptorDef{}
+	if name := b.Descriptor(); name != "" {
+		if err = xml.Unmarshal(b.Files[name], &descriptor); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", name, err)
+		}
+	}
+	doc.Info = Info{Title: descriptor.DisplayName, Description: strings.TrimSpace(descriptor.Description), Version: "1"}
+	if doc.Info.Title == "" {
+		doc.Info.Title = descriptor.Name
+	}
+	if descriptor.Revision != "" {
+		doc.Info.Version = descriptor.Revision
+	}
+	if opts.Title != "" {
+		doc.Info.Title = opts.Title
+	}
+	if doc.Info.Title == "" {
+		doc.Info.Title = "API"
+	}
+	if opts.Version != "" {
+		doc.Info.Version = opts.Version
+	}
+
+	policies := map[string]policyDef{}
+	endpoints := []proxies.ProxyEndpointDef{}
+	for _, name := range b.Names() {
+		switch {
+		case b.In(name, "policies"):
+			p := policyDef{}
+			if err = xml.Unmarshal(b.Files[name], &p); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			policies[p.Name] = p
+		case b.In(name, "proxies"):
+			e, err := proxies.ParseProxyEndpoint(b.Files[name])
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			endpoints = append(endpoints, e)
+		}
+	}
+	if len(endpoints) == 0 {
+		return nil, fmt.Errorf("the bundle has no proxy endpoint")
+	}
+
+	// with a single proxy endpoint, the base path is the server; otherwise it prefixes the paths
+	prefix := func(e proxies.ProxyEndpointDef) string { return e.HTTPProxyConnection.BasePath }
+	if len(endpoints) == 1 {
+		doc.Servers = []Server{{URL: endpoints[0].HTTPProxyConnection.BasePath}}
+		prefix = func(proxies.ProxyEndpointDef) string { return "" }
+	}
+
+	schemes := map[string]map[string]string{}
+	for _, e := range endpoints {
+		preFlow := securityOf(e.PreFlow.Request.Step, policies, schemes)
+		for _, spec := range oasResources(e.PreFlow.Request.Step, policies, b) {
+			addResourceOperations(doc, spec, prefix(e), preFlow)
+		}
+		for _, f := range e.Flows.Flow {
+			r, ok := parseCondition(html.UnescapeString(f.Condition.ConditionData))
+			if !ok {
+				continue
+			}
+			security := slices.Concat(preFlow, securityOf(f.Request.Step, policies, schemes))
+			for _, spec := range oasResources(f.Request.Step, policies, b) {
+				addResourceOperations(doc, spec, prefix(e), security)
+			}
+			addFlowOperations(doc, f, r, joinPath(prefix(e), r.path), security)
+		}
+	}
+	if len(schemes) > 0 {
+		doc.Components = &Components{SecuritySchemes: schemes}
+	}
+	return doc, nil
+}
+
+// Marshal writes a document as YAML, or as JSON when the format is json
+func Marshal(doc *Document, format string) ([]byte, error) {
+	if format == "json" {
+		return json.MarshalIndent(doc, "", "  ")
+	}
+	b := bytes.Buffer{}
+	e := yaml.NewEncoder(&b)
+	e.SetIndent(2)
+	if err := e.Encode(doc); err != nil {
+		return nil, err
+	}
+	err := e.Close()
+	return b.Bytes(), err
+}
+
+// parseCondition reads the path and the verbs of a flow condition
+func parseCondition(condition string) (r route, ok bool) {
+	m := pathCondition.FindAllStringSubmatch(condition, -1)
+	if len(m) == 0 {
+		return r, false
+	}
+	r.confidence = High
+	if len(m) > 1 {
+		r.confidence = Low
+		r.reasons = append(r.reasons, "several path conditions, the first one is used")
+	}
+	r.path = m[0][2]
+	switch op := strings.ToLower(m[0][1]); op {
+	case "matchespath", "likepath", "~/":
+		if strings.Contains(r.path, "**") {
+			r.confidence = Low
+			r.reasons = append(r.reasons, "** matches several path segments")
+		}
+		r.path = pathTemplate(r.path)
+	case "matches", "like", "~":
+		r.confidence = Low
+		r.reasons = append(r.reasons, "Matches pattern converted to path parameters")
+		r.path = pathTemplate(r.path)
+	case "javaregex", "~~":
+		r.confidence = Low
+		r.reasons = append(r.reasons, "JavaRegex pattern converted to path parameters")
+		r.path = pathTemplate(regexGroup.ReplaceAllString(strings.Trim(r.path, "^$"), "*"))
+	case "startswith", "=|":
+		r.confidence = Low
+		r.reasons = append(r.reasons, "StartsWith matches the paths under "+r.path)
+	}
+	if negatedCondition.MatchString(condition) {
+		r.confidence = Low
+		r.reasons = append(r.reasons, "the condition has a negation")
+	}
+
+	for _, v := range verbCondition.FindAllStringSubmatch(condition, -1) {
+		verb := strings.ToLower(v[2])
+		if slices.Contains(methods, verb) && !slices.Contains(r.verbs, verb) {
+			r.verbs = append(r.verbs, verb)
+		}
+	}
+	if len(r.verbs) == 0 {
+		r.verbs = []string{"get"}
+		r.confidence = Low
+		r.reasons = append(r.reasons, "no request.verb in the condition, GET is assumed")
+	}
+	return r, true
+}
+
+// pathTemplate converts the wildcards of a path pattern to path parameters named after the previous segment
+func pathTemplate(pattern string) string {
+	segments := strings.Split(pattern, "/")
+	names := map[string]int{}
+	for i, s := range segments {
+		if !strings.Contains(s, "*") {
+			continue
+		}
+		name := "param"
+		if s == "**" {
+			name = "path"
+		} else if i > 0 && segments[i-1] != "" && !strings.Contains(segments[i-1], "{") {
+			name = strings.TrimSuffix(strings.Trim(segments[i-1], "{}"), "s") + "Id"
+		}
+		names[name]++
+		if names[name] > 1 {
+			name = fmt.Sprintf("%s%d", name, names[name])
+		}
+		segments[i] = "{" + name + "}"
+	}
+	return strings.Join(segments, "/")
+}
+
+func addFlowOperations(doc *Document, f proxytypes.FlowDef, r route, p string, security []map[string][]string) {
+	// a path of an OAS resource may name its parameters differently
+	for existing := range doc.Paths {
+		if templateParam.ReplaceAllString(existing, "{}") == templateParam.ReplaceAllString(p, "{}") {
+			p = existing
+			break
+		}
+	}
+	for _, verb := range r.verbs {
+		if doc.Paths[p] == nil {
+			doc.Paths[p] = map[string]map[string]any{}
+		}
+		if _, exists := doc.Paths[p][verb]; exists {
+			// operations of an OAS resource are kept
+			continue
+		}
+		operationID := f.Name
+		if len(r.verbs) > 1 {
+			operationID += "-" + verb
+		}
+		op := map[string]any{
+			"operationId": operationID,
+			"responses":   map[string]any{"default": map[string]any{"description": "Response of the " + f.Name + " flow"}},
+			InferredExtension: map[string]any{
+				"confidence": r.confidence,
+				"flow":       f.Name,
+				"condition":  strings.TrimSpace(html.UnescapeString(f.Condition.ConditionData)),
+			},
+		}
+		if len(r.reasons) > 0 {
+			op[InferredExtension].(map[string]any)["reasons"] = r.reasons
+		}
+		if f.Description != "" {
+			op["summary"] = f.Description
+		}
+		if params := pathParameters(p); len(params) > 0 {
+			op["parameters"] = params
+		}
+		if len(security) > 0 {
+			op["security"] = security
+		}
+		doc.Paths[p][verb] = op
+	}
+}
+
+func pathParameters(p string) (params []map[string]any) {
+	for _, m := range templateParam.FindAllStringSubmatch(p, -1) {
+		params = append(params, map[string]any{
+			"name": m[1], "in": "path", "required": true, "schema": map[string]string{"type": "string"},
+		})
+	}
+	return params
+}
+
+// securityOf returns the security requirements of the VerifyAPIKey, OAuthV2 and VerifyJWT steps
+func securityOf(steps []*proxytypes.StepDef, policies map[string]policyDef, schemes map[string]map[string]string) (
+	security []map[string][]string,
+) {
+	for _, s := range steps {
+		p, ok := policies[strings.TrimSpace(s.Name)]
+		if !ok {
+			continue
+		}
+		var scheme map[string]string
+		switch p.XMLName.Local {
+		case "VerifyAPIKey":
+			scheme = map[string]string{"type": "apiKey", "in": "header", "name": "x-apikey"}
+			if kind, name, found := strings.Cut(strings.TrimPrefix(p.APIKey.Ref, "request."), "."); found {
+				switch kind {
+				case "queryparam":
+					scheme["in"], scheme["name"] = "query", name
+				case "header":
+					scheme["name"] = name
+				}
+			}
+		case "OAuthV2":
+			if p.Operation != "" && p.Operation != "VerifyAccessToken" {
+				continue
+			}
+			scheme = map[string]string{"type": "http", "scheme": "bearer"}
+		case "VerifyJWT":
+			scheme = map[string]string{"type": "http", "scheme": "bearer", "bearerFormat": "JWT"}
+		default:
+			continue
+		}
+		schemes[p.Name] = scheme
+		security = append(security, map[string][]string{p.Name: {}})
+	}
+	return security
+}
+
+// oasResources returns the OAS documents validated by the OASValidation steps
+func oasResources(steps []*proxytypes.StepDef, policies map[string]policyDef, b proxytypes.Bundle) (specs []map[string]any) {
+	for _, s := range steps {
+		p, ok := policies[strings.TrimSpace(s.Name)]
+		if !ok || p.XMLName.Local != "OASValidation" || !strings.HasPrefix(p.OASResource, "oas://") {
+			continue
+		}
+		content, ok := b.Files[path.Join(b.Root, "resources", "oas", strings.TrimPrefix(p.OASResource, "oas://"))]
+		if !ok {
+			continue
+		}
+		var spec any
+		if err := yaml.Unmarshal(content, &spec); err != nil {
+			continue
+		}
+		if m, ok := normalize(spec).(map[string]any); ok {
+			specs = append(specs, m)
+		}
+	}
+	return specs
+}
+
+// addResourceOperations adds the operations of an OAS resource, which replace the operations inferred from the flows
+func addResourceOperations(doc *Document, spec map[string]any, prefix string, security []map[string][]string) {
+	paths, _ := spec["paths"].(map[string]any)
+	keys := []string{}
+	for p := range paths {
+		keys = append(keys, p)
+	}
+	sort.Strings(keys)
+	for _, p := range keys {
+		item, _ := paths[p].(map[string]any)
+		full := joinPath(prefix, p)
+		for _, verb := range methods {
+			op, ok := item[verb].(map[string]any)
+			if !ok {
+				continue
+			}
+			if doc.Paths[full] == nil {
+				doc.Paths[full] = map[string]map[string]any{}
+			}
+			if _, ok = op["security"]; !ok && len(security) > 0 {
+				op["security"] = security
+			}
+			doc.Paths[full][verb] = op
+		}
+	}
+}
+
+// normalize converts the maps of a YAML document to map[string]any, so that it can be written as JSON
+func normalize(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, e := range t {
+			t[k] = normalize(e)
+		}
+		return t
+	case map[any]any:
+		m := map[string]any{}
+		for k, e := range t {
+			m[fmt.Sprint(k)] = normalize(e)
+		}
+		return m
+	case []any:
+		for i, e := range t {
+			t[i] = normalize(e)
+		}
+	}
+	return v
+}
+
+func joinPath(prefix string, p string) string {
+	if prefix == "" {
+		return p
+	}
+	return strings.TrimSuffix(prefix, "/") + "/" + strings.TrimPrefix(p, "/")
+}
@@ -0,0 +1,105 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package specgen
+
+import (
+	"encoding/json"
+	"testing"
+
+	proxytypes "internal/bundlegen/common"
+)
+
+var testBundle = proxytypes.Bundle{Root: "apiproxy", Files: map[string][]byte{
+	"apiproxy/orders.xml": []byte(`<APIProxy name="orders" revision="4"><DisplayName>Orders</DisplayName></APIProxy>`),
+	"apiproxy/proxies/default.xml": []byte(`<ProxyEndpoint name="default">
+  <PreFlow name="PreFlow"><Request><Step><Name>VerifyKey</Name></Step></Request></PreFlow>
+  <Flows>
+    <Flow name="getOrder">
+      <Description>Get an order</Description>
+      <Condition>(proxy.pathsuffix MatchesPath &quot;/orders/*&quot;) and (request.verb = &quot;GET&quot;)</Condition>
+    </Flow>
+    <Flow name="updateOrder">
+      <Condition>(proxy.pathsuffix MatchesPath "/orders/*") and ((request.verb = "PUT") or (request.verb = "PATCH"))</Condition>
+      <Request><Step><Name>VerifyToken</Name></Step></Request>
+    </Flow>
+    <Flow name="search">
+      <Condition>proxy.pathsuffix JavaRegex "/search/[^/]+"</Condition>
+    </Flow>
+    <Flow name="items">
+      <Request><Step><Name>Validate</Name></Step></Request>
+      <Condition>(proxy.pathsuffix MatchesPath "/items/*") and (request.verb = "GET")</Condition>
+    </Flow>
+    <Flow name="NotFound"/>
+  </Flows>
+  <HTTPProxyConnection><BasePath>/v1</BasePath></HTTPProxyConnection>
+</ProxyEndpoint>`),
+	"apiproxy/policies/VerifyKey.xml":   []byte(`<VerifyAPIKey name="VerifyKey"><APIKey ref="request.queryparam.apikey"/></VerifyAPIKey>`),
+	"apiproxy/policies/VerifyToken.xml": []byte(`<OAuthV2 name="VerifyToken"><Operation>VerifyAccessToken</Operation></OAuthV2>`),
+	"apiproxy/policies/Validate.xml":    []byte(`<OASValidation name="Validate"><OASResource>oas://items.yaml</OASResource></OASValidation>`),
+	"apiproxy/resources/oas/items.yaml": []byte(`openapi: 3.0.0
+paths:
+  /items/{id}:
+    get:
+      operationId: getItem
+      responses:
+        200:
+          description: OK
+`),
+}}
+
+func TestGenerate(t *testing.T) {
+	doc, err := Generate(testBundle, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if doc.Info.Title != "Orders" || doc.Info.Version != "4" || doc.Servers[0].URL != "/v1" {
+		t.Errorf("unexpected info %v and servers %v", doc.Info, doc.Servers)
+	}
+
+	getOrder := doc.Paths["/orders/{orderId}"]["get"]
+	if getOrder == nil || getOrder["summary"] != "Get an order" {
+		t.Fatalf("unexpected paths %v", doc.Paths)
+	}
+	if getOrder[InferredExtension].(map[string]any)["confidence"] != High {
+		t.Errorf("getOrder: expected high confidence, got %v", getOrder[InferredExtension])
+	}
+	if len(getOrder["security"].([]map[string][]string)) != 1 {
+		t.Errorf("getOrder: unexpected security %v", getOrder["security"])
+	}
+	if patch := doc.Paths["/orders/{orderId}"]["patch"]; patch == nil || len(patch["security"].([]map[string][]string)) != 2 {
+		t.Errorf("updateOrder-patch: unexpected operation %v", patch)
+	}
+	search := doc.Paths["/search/{searchId}"]["get"]
+	if search == nil || search[InferredExtension].(map[string]any)["confidence"] != Low {
+		t.Errorf("search: expected a low confidence operation, got %v", doc.Paths)
+	}
+	if item := doc.Paths["/items/{id}"]["get"]; item == nil || item["operationId"] != "getItem" {
+		t.Errorf("items: expected the operation of the OAS resource, got %v", doc.Paths)
+	}
+	if len(doc.Paths) != 3 {
+		t.Errorf("got %d paths, want 3", len(doc.Paths))
+	}
+	if doc.Components.SecuritySchemes["VerifyKey"]["in"] != "query" {
+		t.Errorf("unexpected security schemes %v", doc.Components.SecuritySchemes)
+	}
+
+	content, err := Marshal(doc, "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(content) {
+		t.Errorf("invalid JSON %s", content)
+	}
+}
@@ -0,0 +1,345 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testrunner
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Resolver returns the value of a flow variable and whether it is set
+type Resolver func(name string) (value string, ok bool)
+
+type tokenKind int
+
+const (
+	tokWord tokenKind = iota
+	tokString
+	tokOp
+	tokLParen
+	tokRParen
+)
+
+type token struct {
+	kind tokenKind
+	text string
+}
+
+type operand struct {
+	value   string
+	found   bool
+	literal bool
+	null    bool
+}
+
+// symbolOps are the operator symbols, the longest first
+var symbolOps = []string{"!=", ":=", "=|", "==", ">=", "<=", "~~", "~/", "&&", "||", "=", ">", "<", "~", "!"}
+
+// wordOps are the operator names and the symbol each one is an alias of
+var wordOps = map[string]string{
+	"equals": "=", "is": "=", "notequals": "!=", "isnot": "!=", "equalscaseinsensitive": ":=",
+	"greaterthan": ">", "greaterthanorequals": ">=", "lesserthan": "<", "lesserthanorequals": "<=",
+	"startswith": "=|", "matches": "~", "like": "~", "javaregex": "~~", "matchespath": "~/",
+	"likepath": "~/", "and": "&&", "or": "||", "not": "!",
+}
+
+// Evaluate evaluates an Apigee flow condition, for ex:
+// (proxy.pathsuffix MatchesPath "/orders/*") and (request.verb = "GET").
+// An empty condition is true
+func Evaluate(condition string, resolve Resolver) (bool, error) {
+	tokens, err := tokenize(condition)
+	if err != nil {
+		return false, err
+	}
+	if len(tokens) == 0 {
+		return true, nil
+	}
+	p := &parser{tokens: tokens, resolve: resolve}
+	result, err := p.or()
+	if err != nil {
+		return false, err
+	}
+	if p.pos < len(p.tokens) {
+		return false, fmt.Errorf("unexpected %q in condition %s", p.tokens[p.pos].text, condition)
+	}
+	return result, nil
+}
+
+type parser struct {
+	tokens  []token
+	pos     int
+	resolve Resolver
+}
+
+func (p *parser) peekOp(ops ...string) bool {
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokOp {
+		return false
+	}
+	for _, op := range ops {
+		if p.tokens[p.pos].text == op {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) or() (bool, error) {
+	result, err := p.and()
+	if err != nil {
+		return false, err
+	}
+	for p.peekOp("||") {
+		p.pos++
+		right, err := p.and()
+		if err != nil {
+			return false, err
+		}
+		result = result || right
+	}
+	return result, nil
+}
+
+func (p *parser) and() (bool, error) {
+	result, err := p.not()
+	if err != nil {
+		return false, err
+	}
+	for p.peekOp("&&") {
+		p.pos++
+		right, err := p.not()
+		if err != nil {
+			return false, err
+		}
+		result = result && right
+	}
+	return result, nil
+}
+
+func (p *parser) not() (bool, error) {
+	if p.peekOp("!") {
+		p.pos++
+		result, err := p.not()
+		return !result, err
+	}
+	return p.primary()
+}
+
+func (p *parser) primary() (bool, error) {
+	if p.pos >= len(p.tokens) {
+		return false, fmt.Errorf("incomplete condition")
+	}
+	if p.tokens[p.pos].kind == tokLParen {
+		p.pos++
+		result, err := p.or()
+		if err != nil {
+			return false, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokRParen {
+			return false, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return result, nil
+	}
+
+	left, err := p.operand()
+	if err != nil {
+		return false, err
+	}
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokOp || p.peekOp("&&", "||", "!") {
+		return left.found && left.value != "" && !strings.EqualFold(left.value, "false"), nil
+	}
+	op := p.tokens[p.pos].text
+	p.pos++
+	right, err := p.operand()
+	if err != nil {
+		return false, err
+	}
+	return compare(op, left, right)
+}
+
+func (p *parser) operand() (operand, error) {
+	if p.pos >= len(p.tokens) {
+		return operand{}, fmt.Errorf("missing operand")
+	}
+	t := p.tokens[p.pos]
+	p.pos++
+	switch t.kind {
+	case tokString:
+		return operand{value: t.text, found: true, literal: true}, nil
+	case tokWord:
+		if strings.EqualFold(t.text, "null") {
+			return operand{null: true, literal: true}, nil
+		}
+		if strings.EqualFold(t.text, "true") || strings.EqualFold(t.text, "false") {
+			return operand{value: strings.ToLower(t.text), found: true, literal: true}, nil
+		}
+		if _, err := strconv.ParseFloat(t.text, 64); err == nil {
+			return operand{value: t.text, found: true, literal: true}, nil
+		}
+		value, ok := p.resolve(t.text)
+		return operand{value: value, found: ok}, nil
+	}
+	return operand{}, fmt.Errorf("unexpected %q", t.text)
+}
+
+func compare(op string, left operand, right operand) (bool, error) {
+	if left.null || right.null {
+		v := left
+		if left.null {
+			v = right
+		}
+		switch op {
+		case "=", "==":
+			return !v.found, nil
+		case "!=":
+			return v.found, nil
+		}
+		return false, nil
+	}
+	if !left.found || !right.found {
+		return op == "!=", nil
+	}
+
+	l, r := left.value, right.value
+	lf, lerr := strconv.ParseFloat(l, 64)
+	rf, rerr := strconv.ParseFloat(r, 64)
+	numeric := lerr == nil && rerr == nil
+	switch op {
+	case "=", "==":
+		if numeric {
+			return lf == rf, nil
+		}
+		return l == r, nil
+	case "!=":
+		if numeric {
+			return lf != rf, nil
+		}
+		return l != r, nil
+	case ":=":
+		return strings.EqualFold(l, r), nil
+	case ">", ">=", "<", "<=":
+		c := strings.Compare(l, r)
+		if numeric {
+			c = 0
+			if lf < rf {
+				c = -1
+			} else if lf > rf {
+				c = 1
+			}
+		}
+		return (op == ">" && c > 0) || (op == ">=" && c >= 0) || (op == "<" && c < 0) || (op == "<=" && c <= 0), nil
+	case "=|":
+		return strings.HasPrefix(l, r), nil
+	case "~":
+		return matchPattern(l, r, false)
+	case "~/":
+		return matchPattern(l, r, true)
+	case "~~":
+		re, err := regexp.Compile("^(?:" + r + ")$")
+		if err != nil {
+			return false, fmt.Errorf("invalid JavaRegex %s: %w", r, err)
+		}
+		return re.MatchString(l), nil
+	}
+	return false, fmt.Errorf("unsupported operator %s", op)
+}
+
+// matchPattern matches a value with a Matches pattern, where * is any characters and ? one
+// character, or a MatchesPath pattern, where * is a path segment and ** several segments
+func matchPattern(value string, pattern string, isPath bool) (bool, error) {
+	b := strings.Builder{}
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case isPath && strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case c == '*' && isPath:
+			b.WriteString("[^/]*")
+		case c == '*':
+			b.WriteString(".*")
+		case c == '?' && !isPath:
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	re, err := regexp.Compile(b.String())
+	if err != nil {
+		return false, err
+	}
+	return re.MatchString(value), nil
+}
+
+func tokenize(condition string) (tokens []token, err error) {
+	s := strings.TrimSpace(condition)
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "("})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")"})
+			i++
+		case c == '"' || c == '\'':
+			b := strings.Builder{}
+			j := i + 1
+			for ; j < len(s) && s[j] != c; j++ {
+				if s[j] == '\\' && j+1 < len(s) {
+					j++
+				}
+				b.WriteByte(s[j])
+			}
+			if j >= len(s) {
+				return nil, fmt.Errorf("unterminated string in condition %s", condition)
+			}
+			tokens = append(tokens, token{kind: tokString, text: b.String()})
+			i = j + 1
+		default:
+			if op := symbolOp(s[i:]); op != "" {
+				tokens = append(tokens, token{kind: tokOp, text: op})
+				i += len(op)
+				continue
+			}
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\r\n()\"'", rune(s[j])) && symbolOp(s[j:]) == "" {
+				j++
+			}
+			word := s[i:j]
+			if op, ok := wordOps[strings.ToLower(word)]; ok {
+				tokens = append(tokens, token{kind: tokOp, text: op})
+			} else {
+				tokens = append(tokens, token{kind: tokWord, text: word})
+			}
+			i = j
+		}
+	}
+	return tokens, nil
+}
+
+func symbolOp(s string) string {
+	for _, op := range symbolOps {
+		if strings.HasPrefix(s, op) {
+			return op
+		}
+	}
+	return ""
+}
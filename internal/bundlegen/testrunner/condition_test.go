@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testrunner
+
+import "testing"
+
+func TestEvaluate(t *testing.T) {
+	vars := map[string]string{
+		"proxy.pathsuffix":     "/orders/42/items",
+		"request.verb":         "GET",
+		"request.header.x-env": "Prod",
+		"response.status.code": "404",
+	}
+	resolve := func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{``, true},
+		{`request.verb = "GET"`, true},
+		{`request.verb equals "POST"`, false},
+		{`(proxy.pathsuffix MatchesPath "/orders/*/items") and (request.verb = "GET")`, true},
+		{`proxy.pathsuffix MatchesPath "/orders/*"`, false},
+		{`proxy.pathsuffix MatchesPath "/orders/**"`, true},
+		{`proxy.pathsuffix Matches "/orders/*"`, true},
+		{`proxy.pathsuffix JavaRegex "/orders/[0-9]+/items"`, true},
+		{`request.header.x-env EqualsCaseInsensitive "prod"`, true},
+		{`response.status.code >= 400 && response.status.code < 500`, true},
+		{`response.status.code > 1000`, false},
+		{`not (request.verb = "GET") or request.header.x-env = "Prod"`, true},
+		{`request.header.x-missing = null`, true},
+		{`request.header.x-missing != "a"`, true},
+		{`request.header.x-env`, true},
+		{`proxy.pathsuffix StartsWith "/orders"`, true},
+	}
+	for _, test := range tests {
+		got, err := Evaluate(test.condition, resolve)
+		if err != nil {
+			t.Errorf("%s: %v", test.condition, err)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("%s: got %t, want %t", test.condition, got, test.want)
+		}
+	}
+	if _, err := Evaluate(`(request.verb = "GET"`, resolve); err == nil {
+		t.Error("expected an error for a missing parenthesis")
+	}
+}
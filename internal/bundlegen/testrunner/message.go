@@ -0,0 +1,165 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testrunner
+
+import (
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Message is a request or a response in a flow
+type Message struct {
+	Verb    string
+	Path    string
+	Query   url.Values
+	Headers http.Header
+	Status  int
+	Reason  string
+	Content string
+}
+
+// flowContext holds the messages and the flow variables of a test
+type flowContext struct {
+	request  *Message
+	response *Message
+	// inResponse is true in the response and error flows, where message is the response
+	inResponse bool
+	basePath   string
+	vars       map[string]string
+}
+
+var templateVariable = regexp.MustCompile(`\{([A-Za-z_][\w.\-]*)\}`)
+
+func newMessage() *Message {
+	return &Message{Query: url.Values{}, Headers: http.Header{}}
+}
+
+func (m *Message) uri() string {
+	if len(m.Query) == 0 {
+		return m.Path
+	}
+	return m.Path + "?" + m.Query.Encode()
+}
+
+// message returns the message a policy works on when it sets no AssignTo or Source
+func (c *flowContext) message() *Message {
+	if c.inResponse {
+		return c.response
+	}
+	return c.request
+}
+
+// messageNamed returns the request, the response or the current message
+func (c *flowContext) messageNamed(name string) *Message {
+	switch name {
+	case "request":
+		return c.request
+	case "response":
+		return c.response
+	}
+	return c.message()
+}
+
+// get resolves a flow variable, with the message variables read from the messages
+func (c *flowContext) get(name string) (string, bool) {
+	if v, ok := c.vars[name]; ok {
+		return v, true
+	}
+	switch name {
+	case "proxy.basepath":
+		return c.basePath, true
+	case "proxy.pathsuffix":
+		return strings.TrimPrefix(c.request.Path, strings.TrimSuffix(c.basePath, "/")), true
+	}
+	prefix, field, found := strings.Cut(name, ".")
+	if !found || (prefix != "request" && prefix != "response" && prefix != "message") {
+		return "", false
+	}
+	m := c.messageNamed(prefix)
+	switch {
+	case field == "verb" && prefix != "response":
+		return m.Verb, true
+	case field == "path" && prefix != "response":
+		return m.Path, true
+	case field == "uri" && prefix != "response":
+		return m.uri(), true
+	case field == "querystring" && prefix != "response":
+		return m.Query.Encode(), true
+	case field == "status.code" && prefix != "request":
+		return strconv.Itoa(m.Status), m.Status != 0
+	case field == "reason.phrase" && prefix != "request":
+		return m.Reason, m.Reason != ""
+	case field == "content":
+		return m.Content, true
+	case strings.HasPrefix(field, "header."):
+		values := m.Headers.Values(strings.TrimPrefix(field, "header."))
+		if len(values) == 0 {
+			return "", false
+		}
+		return strings.Join(values, ","), true
+	case strings.HasPrefix(field, "queryparam."):
+		values, ok := m.Query[strings.TrimPrefix(field, "queryparam.")]
+		if !ok || len(values) == 0 {
+			return "", false
+		}
+		return values[0], true
+	}
+	return "", false
+}
+
+// set sets a flow variable. Message variables update the message
+func (c *flowContext) set(name string, value string) {
+	prefix, field, found := strings.Cut(name, ".")
+	if found && (prefix == "request" || prefix == "response" || prefix == "message") {
+		m := c.messageNamed(prefix)
+		switch {
+		case field == "verb":
+			m.Verb = value
+			return
+		case field == "content":
+			m.Content = value
+			return
+		case field == "status.code":
+			m.Status, _ = strconv.Atoi(value)
+			return
+		case field == "reason.phrase":
+			m.Reason = value
+			return
+		case strings.HasPrefix(field, "header."):
+			m.Headers.Set(strings.TrimPrefix(field, "header."), value)
+			return
+		case strings.HasPrefix(field, "queryparam."):
+			m.Query.Set(strings.TrimPrefix(field, "queryparam."), value)
+			return
+		}
+	}
+	c.vars[name] = value
+}
+
+// resolveTemplate replaces the {variable} references of a message template.
+// Unresolved variables are replaced with an empty string
+func (c *flowContext) resolveTemplate(template string, prefix string, suffix string) string {
+	re := templateVariable
+	if prefix != "" || suffix != "" {
+		re = regexp.MustCompile(regexp.QuoteMeta(prefix) + `([A-Za-z_][\w.\-]*)` + regexp.QuoteMeta(suffix))
+	}
+	return re.ReplaceAllStringFunc(template, func(ref string) string {
+		v, _ := c.get(re.FindStringSubmatch(ref)[1])
+		return v
+	})
+}
@@ -0,0 +1,414 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testrunner
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	templateParts = regexp.MustCompile(`\{[^}]+\}|[^{]+`)
+	jsonPathParts = regexp.MustCompile(`[^.\[\]]+|\[\d+\]`)
+)
+
+// node is an element of a policy
+type node struct {
+	Name     string
+	Attrs    map[string]string
+	Text     string
+	Children []*node
+	// inner is the raw content of the element, for XML payloads
+	inner string
+	start int64
+}
+
+// policy is a parsed policy of the bundle
+type policy struct {
+	Type    string
+	Name    string
+	Enabled bool
+	root    *node
+}
+
+// faultError is raised by a RaiseFault policy
+type faultError struct {
+	policy string
+}
+
+func (e *faultError) Error() string {
+	return "fault raised by " + e.policy
+}
+
+func parsePolicy(content []byte) (p policy, err error) {
+	root, err := parseNode(content)
+	if err != nil {
+		return p, err
+	}
+	return policy{
+		Type:    root.Name,
+		Name:    root.Attrs["name"],
+		Enabled: root.Attrs["enabled"] != "false",
+		root:    root,
+	}, nil
+}
+
+func parseNode(content []byte) (*node, error) {
+	d := xml.NewDecoder(bytes.NewReader(content))
+	stack := []*node{}
+	var root *node
+	for {
+		tok, err := d.Token()
+		if err != nil {
+			if root != nil && len(stack) == 0 {
+				return root, nil
+			}
+			return nil, err
+		}
+		switch t := tok.(type) {
+		case xml.StartElement:
+			n := &node{Name: t.Name.Local, Attrs: map[string]string{}, start: d.InputOffset()}
+			for _, a := range t.Attr {
+				n.Attrs[a.Name.Local] = a.Value
+			}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.Children = append(parent.Children, n)
+			} else {
+				root = n
+			}
+			stack = append(stack, n)
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			n.Text = strings.TrimSpace(n.Text)
+			if end := d.InputOffset() - int64(len("</"+t.Name.Local+">")); end >= n.start {
+				n.inner = strings.TrimSpace(string(content[n.start:end]))
+			}
+			stack = stack[:len(stack)-1]
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].Text += string(t)
+			}
+		}
+	}
+}
+
+func (n *node) child(name string) *node {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func (n *node) children(name string) (l []*node) {
+	if n == nil {
+		return nil
+	}
+	for _, c := range n.Children {
+		if c.Name == name {
+			l = append(l, c)
+		}
+	}
+	return l
+}
+
+func (n *node) text(name string) string {
+	if c := n.child(name); c != nil {
+		return c.Text
+	}
+	return ""
+}
+
+// execute runs a policy. Policies other than AssignMessage, ExtractVariables and RaiseFault
+// only set the variables of their stub
+func (p policy) execute(c *flowContext, stub map[string]string) error {
+	for name, value := range stub {
+		c.set(name, value)
+	}
+	switch p.Type {
+	case "AssignMessage":
+		return assignMessage(c, p.root)
+	case "ExtractVariables":
+		return extractVariables(c, p.root)
+	case "RaiseFault":
+		c.response = newMessage()
+		c.response.Status = 500
+		c.inResponse = true
+		if err := assignMessage(c, p.root.child("FaultResponse")); err != nil {
+			return err
+		}
+		c.set("fault.name", "RaiseFault")
+		return &faultError{policy: p.Name}
+	}
+	return nil
+}
+
+// assignMessage applies the AssignMessage elements of n, also used for the FaultResponse of RaiseFault
+func assignMessage(c *flowContext, n *node) error {
+	if n == nil {
+		return nil
+	}
+	m := c.message()
+	if assignTo := n.child("AssignTo"); assignTo != nil {
+		target := assignTo.Text
+		if target == "" {
+			target = assignTo.Attrs["type"]
+		}
+		if assignTo.Attrs["createNew"] == "true" && target != "request" && target != "response" {
+			// a new message variable is not visible to the flow messages
+			m = newMessage()
+		} else {
+			m = c.messageNamed(target)
+			if assignTo.Attrs["createNew"] == "true" {
+				*m = *newMessage()
+			}
+		}
+	}
+
+	for _, v := range n.children("AssignVariable") {
+		name := v.text("Name")
+		value := v.text("Value")
+		if ref := v.text("Ref"); ref != "" {
+			if resolved, ok := c.get(ref); ok {
+				value = resolved
+			}
+		}
+		if t := v.child("Template"); t != nil {
+			value = c.resolveTemplate(t.Text, "", "")
+		}
+		c.set(name, value)
+	}
+
+	if copyNode := n.child("Copy"); copyNode != nil {
+		source := c.messageNamed(copyNode.Attrs["source"])
+		for _, h := range copyNode.child("Headers").children("Header") {
+			m.Headers[http.CanonicalHeaderKey(h.Attrs["name"])] = source.Headers.Values(h.Attrs["name"])
+		}
+		for _, q := range copyNode.child("QueryParams").children("QueryParam") {
+			m.Query[q.Attrs["name"]] = source.Query[q.Attrs["name"]]
+		}
+		if copyNode.text("Payload") == "true" {
+			m.Content = source.Content
+		}
+		if copyNode.text("StatusCode") == "true" {
+			m.Status = source.Status
+		}
+		if copyNode.text("Verb") == "true" {
+			m.Verb = source.Verb
+		}
+		if copyNode.text("Path") == "true" {
+			m.Path = source.Path
+		}
+	}
+
+	if remove := n.child("Remove"); remove != nil {
+		if headers := remove.child("Headers"); headers != nil {
+			if len(headers.Children) == 0 {
+				m.Headers = http.Header{}
+			}
+			for _, h := range headers.children("Header") {
+				m.Headers.Del(h.Attrs["name"])
+			}
+		}
+		for _, q := range remove.child("QueryParams").children("QueryParam") {
+			m.Query.Del(q.Attrs["name"])
+		}
+		if remove.child("Payload") != nil {
+			m.Content = ""
+		}
+	}
+
+	if add := n.child("Add"); add != nil {
+		for _, h := range add.child("Headers").children("Header") {
+			m.Headers.Add(h.Attrs["name"], c.resolveTemplate(h.Text, "", ""))
+		}
+		for _, q := range add.child("QueryParams").children("QueryParam") {
+			m.Query.Add(q.Attrs["name"], c.resolveTemplate(q.Text, "", ""))
+		}
+	}
+
+	if set := n.child("Set"); set != nil {
+		for _, h := range set.child("Headers").children("Header") {
+			m.Headers.Set(h.Attrs["name"], c.resolveTemplate(h.Text, "", ""))
+		}
+		for _, q := range set.child("QueryParams").children("QueryParam") {
+			m.Query.Set(q.Attrs["name"], c.resolveTemplate(q.Text, "", ""))
+		}
+		if payload := set.child("Payload"); payload != nil {
+			content := payload.Text
+			if len(payload.Children) > 0 {
+				content = payload.inner
+			}
+			m.Content = c.resolveTemplate(content, payload.Attrs["variablePrefix"], payload.Attrs["variableSuffix"])
+			if contentType := payload.Attrs["contentType"]; contentType != "" {
+				m.Headers.Set("Content-Type", contentType)
+			}
+		}
+		if verb := set.text("Verb"); verb != "" {
+			m.Verb = c.resolveTemplate(verb, "", "")
+		}
+		if p := set.text("Path"); p != "" {
+			m.Path = c.resolveTemplate(p, "", "")
+		}
+		if status := set.text("StatusCode"); status != "" {
+			code, err := strconv.Atoi(c.resolveTemplate(status, "", ""))
+			if err != nil {
+				return fmt.Errorf("invalid StatusCode %s", status)
+			}
+			m.Status = code
+		}
+		if reason := set.text("ReasonPhrase"); reason != "" {
+			m.Reason = c.resolveTemplate(reason, "", "")
+		}
+	}
+	return nil
+}
+
+// extractVariables supports the URIPath, QueryParam, Header and JSONPayload elements
+func extractVariables(c *flowContext, n *node) error {
+	m := c.message()
+	if source := n.text("Source"); source != "" {
+		m = c.messageNamed(source)
+	}
+	prefix := n.text("VariablePrefix")
+	set := func(name string, value string) {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+		c.set(name, value)
+	}
+
+	for _, uriPath := range n.children("URIPath") {
+		suffix, _ := c.get("proxy.pathsuffix")
+		for _, p := range uriPath.children("Pattern") {
+			if matchTemplate(p.Text, suffix, p.Attrs["ignoreCase"] == "true", set) {
+				break
+			}
+		}
+	}
+	for _, q := range n.children("QueryParam") {
+		for _, p := range q.children("Pattern") {
+			if matchTemplate(p.Text, m.Query.Get(q.Attrs["name"]), p.Attrs["ignoreCase"] == "true", set) {
+				break
+			}
+		}
+	}
+	for _, h := range n.children("Header") {
+		for _, p := range h.children("Pattern") {
+			if matchTemplate(p.Text, m.Headers.Get(h.Attrs["name"]), p.Attrs["ignoreCase"] == "true", set) {
+				break
+			}
+		}
+	}
+
+	if jsonPayload := n.child("JSONPayload"); jsonPayload != nil {
+		var doc interface{}
+		if err := json.Unmarshal([]byte(m.Content), &doc); err != nil {
+			if n.text("IgnoreUnresolvedVariables") == "true" {
+				return nil
+			}
+			return fmt.Errorf("JSONPayload is not valid JSON: %w", err)
+		}
+		for _, v := range jsonPayload.children("Variable") {
+			if value, ok := jsonPath(doc, v.text("JSONPath")); ok {
+				set(v.Attrs["name"], value)
+			}
+		}
+	}
+	return nil
+}
+
+// matchTemplate matches a value with a pattern like /orders/{id} and sets the variables of the pattern
+func matchTemplate(pattern string, value string, ignoreCase bool, set func(string, string)) bool {
+	names := []string{}
+	expr := strings.Builder{}
+	if ignoreCase {
+		expr.WriteString("(?i)")
+	}
+	expr.WriteString("^")
+	for _, part := range templateParts.FindAllString(pattern, -1) {
+		if strings.HasPrefix(part, "{") {
+			names = append(names, strings.Trim(part, "{}"))
+			expr.WriteString("(.*?)")
+		} else {
+			expr.WriteString(regexp.QuoteMeta(part))
+		}
+	}
+	expr.WriteString("$")
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return false
+	}
+	match := re.FindStringSubmatch(value)
+	if match == nil {
+		// patterns match the start of a path, for ex: /orders/{id} matches /orders/1/items
+		if re, err = regexp.Compile(strings.TrimSuffix(expr.String(), "$") + "(?:/.*)?$"); err != nil {
+			return false
+		}
+		if match = re.FindStringSubmatch(value); match == nil {
+			return false
+		}
+	}
+	for i, name := range names {
+		set(name, match[i+1])
+	}
+	return true
+}
+
+// jsonPath evaluates a simple JSONPath, for ex: $.items[0].id
+func jsonPath(doc interface{}, p string) (string, bool) {
+	p = strings.TrimPrefix(strings.TrimPrefix(p, "$"), ".")
+	current := doc
+	for _, part := range jsonPathParts.FindAllString(p, -1) {
+		if strings.HasPrefix(part, "[") {
+			i, _ := strconv.Atoi(strings.Trim(part, "[]"))
+			l, ok := current.([]interface{})
+			if !ok || i >= len(l) {
+				return "", false
+			}
+			current = l[i]
+			continue
+		}
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if current, ok = m[part]; !ok {
+			return "", false
+		}
+	}
+	switch v := current.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", false
+	case map[string]interface{}, []interface{}:
+		b, _ := json.Marshal(v)
+		return string(b), true
+	default:
+		return fmt.Sprint(v), true
+	}
+}
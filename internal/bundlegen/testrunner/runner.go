@@ -0,0 +1,558 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testrunner
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	proxytypes "internal/bundlegen/common"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Suite is a test file
+type Suite struct {
+	Tests []TestCase `yaml:"tests"`
+}
+
+// TestCase is a request fixture and the expected execution of the proxy
+type TestCase struct {
+	Name    string         `yaml:"name"`
+	Request RequestFixture `yaml:"request"`
+	// Variables are set before the request flow, for ex: client.ip
+	Variables map[string]string `yaml:"variables,omitempty"`
+	// TargetResponse is the response of the target; the default is a 200 with no content
+	TargetResponse *ResponseFixture `yaml:"targetResponse,omitempty"`
+	// Stubs are the variables set when a policy runs, keyed by policy name. They stand in
+	// for JavaScript and the other policies the runner does not evaluate
+	Stubs  map[string]map[string]string `yaml:"stubs,omitempty"`
+	Expect Expectation                  `yaml:"expect"`
+}
+
+// RequestFixture is the request sent to the proxy
+type RequestFixture struct {
+	Verb    string            `yaml:"verb"`
+	Path    string            `yaml:"path"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty"`
+}
+
+// ResponseFixture is the response of the target
+type ResponseFixture struct {
+	Status  int               `yaml:"status"`
+	Headers map[string]string `yaml:"headers,omitempty"`
+	Body    string            `yaml:"body,omitempty"`
+}
+
+// Expectation is asserted on the result of a test. Fields that are not set are not checked
+type Expectation struct {
+	ProxyEndpoint  string `yaml:"proxyEndpoint,omitempty"`
+	TargetEndpoint string `yaml:"targetEndpoint,omitempty"`
+	// Flows are the conditional flows executed, in order
+	Flows []string `yaml:"flows,omitempty"`
+	// Policies must execute in this order; other policies may execute between them
+	Policies []string `yaml:"policies,omitempty"`
+	// NotPolicies must not execute
+	NotPolicies  []string          `yaml:"notPolicies,omitempty"`
+	Fault        string            `yaml:"fault,omitempty"`
+	Status       int               `yaml:"status,omitempty"`
+	Headers      map[string]string `yaml:"headers,omitempty"`
+	Body         *string           `yaml:"body,omitempty"`
+	BodyContains string            `yaml:"bodyContains,omitempty"`
+	Variables    map[string]string `yaml:"variables,omitempty"`
+}
+
+// Result is the execution of a test and the expectations that failed
+type Result struct {
+	Name           string
+	ProxyEndpoint  string
+	TargetEndpoint string
+	Flows          []string
+	Policies       []string
+	Fault          string
+	Response       *Message
+	Failures       []string
+}
+
+// Proxy is an API proxy bundle loaded for tests
+type Proxy struct {
+	name            string
+	proxyEndpoints  []endpointDef
+	targetEndpoints map[string]endpointDef
+	policies        map[string]policy
+}
+
+type endpointDef struct {
+	Name                string                 `xml:"name,attr"`
+	FaultRules          faultRulesDef          `xml:"FaultRules"`
+	DefaultFaultRule    *faultRuleDef          `xml:"DefaultFaultRule"`
+	PreFlow             proxytypes.PreFlowDef  `xml:"PreFlow"`
+	PostFlow            proxytypes.PostFlowDef `xml:"PostFlow"`
+	Flows               proxytypes.FlowsDef    `xml:"Flows"`
+	PostClientFlow      postClientFlowDef      `xml:"PostClientFlow"`
+	HTTPProxyConnection struct {
+		BasePath string `xml:"BasePath"`
+	} `xml:"HTTPProxyConnection"`
+	RouteRule []routeRuleDef `xml:"RouteRule"`
+}
+
+type faultRulesDef struct {
+	FaultRule []faultRuleDef `xml:"FaultRule"`
+}
+
+type faultRuleDef struct {
+	Name          string                `xml:"name,attr"`
+	Condition     string                `xml:"Condition"`
+	AlwaysEnforce bool                  `xml:"AlwaysEnforce"`
+	Step          []*proxytypes.StepDef `xml:"Step"`
+}
+
+type postClientFlowDef struct {
+	Response proxytypes.ResponseFlowDef `xml:"Response"`
+}
+
+type routeRuleDef struct {
+	Name           string `xml:"name,attr"`
+	Condition      string `xml:"Condition"`
+	TargetEndpoint string `xml:"TargetEndpoint"`
+	URL            string `xml:"URL"`
+}
+
+// execution is the state of a running test
+type execution struct {
+	proxy  *Proxy
+	test   TestCase
+	c      *flowContext
+	result *Result
+}
+
+// ReadSuite reads a YAML test file
+func ReadSuite(fileName string) (s Suite, err error) {
+	content, err := os.ReadFile(fileName)
+	if err != nil {
+		return s, err
+	}
+	if err = yaml.Unmarshal(content, &s); err != nil {
+		return s, fmt.Errorf("error parsing %s: %w", fileName, err)
+	}
+	if len(s.Tests) == 0 {
+		return s, fmt.Errorf("%s has no tests", fileName)
+	}
+	return s, nil
+}
+
+// LoadProxy loads the endpoints and policies of an API proxy bundle, a zip file or a folder
+func LoadProxy(bundlePath string) (p *Proxy, err error) {
+	b, err := proxytypes.ReadBundle(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	if b.Root != "apiproxy" {
+		return nil, fmt.Errorf("%s is not an API proxy bundle", bundlePath)
+	}
+	p = &Proxy{targetEndpoints: map[string]endpointDef{}, policies: map[string]policy{}}
+	if descriptor := b.Descriptor(); descriptor != "" {
+		p.name = strings.TrimSuffix(descriptor[len(b.Root)+1:], ".xml")
+	}
+	for _, name := range b.Names() {
+		switch {
+		case b.In(name, "policies"):
+			pol, err := parsePolicy(b.Files[name])
+			if err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			p.policies[pol.Name] = pol
+		case b.In(name, "proxies"), b.In(name, "targets"):
+			e := endpointDef{}
+			if err = xml.Unmarshal(b.Files[name], &e); err != nil {
+				return nil, fmt.Errorf("error parsing %s: %w", name, err)
+			}
+			if b.In(name, "proxies") {
+				p.proxyEndpoints = append(p.proxyEndpoints, e)
+			} else {
+				p.targetEndpoints[e.Name] = e
+			}
+		}
+	}
+	if len(p.proxyEndpoints) == 0 {
+		return nil, fmt.Errorf("%s has no proxy endpoint", bundlePath)
+	}
+	return p, nil
+}
+
+// Run runs a test: the request flows of the proxy and target endpoints selected by the
+// base path and the route rules, the target response and the response flows. A RaiseFault
+// policy switches to the fault rules
+func (p *Proxy) Run(test TestCase) *Result {
+	r := &Result{Name: test.Name}
+	request := newMessage()
+	request.Verb = strings.ToUpper(test.Request.Verb)
+	if request.Verb == "" {
+		request.Verb = "GET"
+	}
+	u, err := url.Parse(test.Request.Path)
+	if err != nil {
+		r.Failures = append(r.Failures, fmt.Sprintf("invalid request path %s: %v", test.Request.Path, err))
+		return r
+	}
+	request.Path, request.Query = u.Path, u.Query()
+	for name, value := range test.Request.Headers {
+		request.Headers.Set(name, value)
+	}
+	request.Content = test.Request.Body
+
+	e := &execution{
+		proxy:  p,
+		test:   test,
+		c:      &flowContext{request: request, response: newMessage(), vars: map[string]string{}},
+		result: r,
+	}
+	e.c.vars["apiproxy.name"] = p.name
+	for name, value := range test.Variables {
+		e.c.set(name, value)
+	}
+	if err = e.run(); err != nil {
+		r.Failures = append(r.Failures, err.Error())
+	}
+	r.Response = e.c.response
+	r.Failures = append(r.Failures, check(test.Expect, r, e.c)...)
+	return r
+}
+
+func (e *execution) run() error {
+	pe, ok := e.proxy.selectProxyEndpoint(e.c.request.Path)
+	if !ok {
+		return fmt.Errorf("no proxy endpoint has a base path matching %s", e.c.request.Path)
+	}
+	e.result.ProxyEndpoint = pe.Name
+	e.c.basePath = pe.HTTPProxyConnection.BasePath
+	e.c.vars["proxy.name"] = pe.Name
+
+	var te *endpointDef
+	var targetFlow *proxytypes.FlowDef
+	// inTarget is true while the target endpoint runs, its fault rules handle the faults raised there
+	inTarget := false
+
+	proxyFlow, err := e.requestFlow(pe)
+	if err == nil {
+		var route *routeRuleDef
+		if route, err = e.selectRoute(pe); err == nil && route != nil && route.TargetEndpoint != "" {
+			t, ok := e.proxy.targetEndpoints[strings.TrimSpace(route.TargetEndpoint)]
+			if !ok {
+				return fmt.Errorf("route rule %s references target endpoint %s, which does not exist",
+					route.Name, route.TargetEndpoint)
+			}
+			te = &t
+			e.result.TargetEndpoint = te.Name
+			e.c.vars["target.name"] = te.Name
+			inTarget = true
+			targetFlow, err = e.requestFlow(*te)
+		}
+		if err == nil {
+			e.targetResponse(route != nil && (route.TargetEndpoint != "" || route.URL != ""))
+			e.c.inResponse = true
+			if te != nil {
+				err = e.steps(te.PreFlow.Response.Step, responseSteps(targetFlow), te.PostFlow.Response.Step)
+			}
+			if err == nil {
+				inTarget = false
+				err = e.steps(pe.PreFlow.Response.Step, responseSteps(proxyFlow), pe.PostFlow.Response.Step)
+			}
+		}
+	}
+
+	fault := &faultError{}
+	if errors.As(err, &fault) {
+		e.result.Fault = fault.policy
+		e.c.inResponse = true
+		if inTarget {
+			if err = e.faultRules(*te, true); err != nil {
+				return err
+			}
+		}
+		err = e.faultRules(pe, false)
+	}
+	if err != nil {
+		return err
+	}
+	return e.steps(pe.PostClientFlow.Response.Step)
+}
+
+// requestFlow runs the request PreFlow of an endpoint, then selects the conditional flow,
+// whose condition may use the variables set by the PreFlow, and runs the rest of the request flow
+func (e *execution) requestFlow(ep endpointDef) (flow *proxytypes.FlowDef, err error) {
+	if err = e.steps(ep.PreFlow.Request.Step); err != nil {
+		return nil, err
+	}
+	if flow, err = e.selectFlow(ep); err != nil {
+		return nil, err
+	}
+	return flow, e.steps(requestSteps(flow), ep.PostFlow.Request.Step)
+}
+
+// selectProxyEndpoint returns the proxy endpoint with the longest base path matching the path
+func (p *Proxy) selectProxyEndpoint(requestPath string) (endpointDef, bool) {
+	var selected endpointDef
+	found := false
+	for _, pe := range p.proxyEndpoints {
+		basePath := strings.TrimSuffix(pe.HTTPProxyConnection.BasePath, "/")
+		if requestPath != basePath && !strings.HasPrefix(requestPath, basePath+"/") && basePath != "" {
+			continue
+		}
+		if !found || len(basePath) > len(strings.TrimSuffix(selected.HTTPProxyConnection.BasePath, "/")) {
+			selected, found = pe, true
+		}
+	}
+	return selected, found
+}
+
+// selectFlow returns the first conditional flow whose condition is true
+func (e *execution) selectFlow(ep endpointDef) (*proxytypes.FlowDef, error) {
+	for i, f := range ep.Flows.Flow {
+		ok, err := Evaluate(html.UnescapeString(f.Condition.ConditionData), e.c.get)
+		if err != nil {
+			return nil, fmt.Errorf("flow %s of %s: %w", f.Name, ep.Name, err)
+		}
+		if ok {
+			e.result.Flows = append(e.result.Flows, f.Name)
+			e.c.vars["current.flow.name"] = f.Name
+			return &ep.Flows.Flow[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// selectRoute returns the first route rule whose condition is true
+func (e *execution) selectRoute(pe endpointDef) (*routeRuleDef, error) {
+	for i, r := range pe.RouteRule {
+		ok, err := Evaluate(r.Condition, e.c.get)
+		if err != nil {
+			return nil, fmt.Errorf("route rule %s: %w", r.Name, err)
+		}
+		if ok {
+			return &pe.RouteRule[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// targetResponse sets the response of the target, or an empty response without a target
+func (e *execution) targetResponse(hasTarget bool) {
+	e.c.response = newMessage()
+	e.c.response.Status = 200
+	if !hasTarget || e.test.TargetResponse == nil {
+		return
+	}
+	if e.test.TargetResponse.Status != 0 {
+		e.c.response.Status = e.test.TargetResponse.Status
+	}
+	for name, value := range e.test.TargetResponse.Headers {
+		e.c.response.Headers.Set(name, value)
+	}
+	e.c.response.Content = e.test.TargetResponse.Body
+}
+
+// faultRules runs the first fault rule whose condition is true, then the default fault rule.
+// Fault rules are evaluated top to bottom in a target endpoint, bottom to top in a proxy endpoint
+func (e *execution) faultRules(ep endpointDef, topToBottom bool) error {
+	rules := slices.Clone(ep.FaultRules.FaultRule)
+	if !topToBottom {
+		slices.Reverse(rules)
+	}
+	executed := false
+	for _, r := range rules {
+		ok, err := Evaluate(r.Condition, e.c.get)
+		if err != nil {
+			return fmt.Errorf("fault rule %s: %w", r.Name, err)
+		}
+		if !ok {
+			continue
+		}
+		if err = e.steps(r.Step); err != nil && !isFault(err) {
+			return err
+		}
+		executed = true
+		break
+	}
+	if d := ep.DefaultFaultRule; d != nil && (!executed || d.AlwaysEnforce) {
+		if err := e.steps(d.Step); err != nil && !isFault(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// steps runs the steps whose condition is true
+func (e *execution) steps(lists ...[]*proxytypes.StepDef) error {
+	for _, l := range lists {
+		for _, s := range l {
+			name := strings.TrimSpace(s.Name)
+			ok, err := Evaluate(html.UnescapeString(s.Condition), e.c.get)
+			if err != nil {
+				return fmt.Errorf("step %s: %w", name, err)
+			}
+			if !ok {
+				continue
+			}
+			p, found := e.proxy.policies[name]
+			if !found {
+				return fmt.Errorf("step references policy %s, which does not exist", name)
+			}
+			if !p.Enabled {
+				continue
+			}
+			e.result.Policies = append(e.result.Policies, name)
+			if err = p.execute(e.c, e.test.Stubs[name]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// requestSteps returns the request steps of a conditional flow, if one was selected
+func requestSteps(f *proxytypes.FlowDef) []*proxytypes.StepDef {
+	if f == nil {
+		return nil
+	}
+	return f.Request.Step
+}
+
+// responseSteps returns the response steps of a conditional flow, if one was selected
+func responseSteps(f *proxytypes.FlowDef) []*proxytypes.StepDef {
+	if f == nil {
+		return nil
+	}
+	return f.Response.Step
+}
+
+func isFault(err error) bool {
+	fault := &faultError{}
+	return errors.As(err, &fault)
+}
+
+// check returns the expectations of a test that are not met
+func check(expect Expectation, r *Result, c *flowContext) (failures []string) {
+	if expect.ProxyEndpoint != "" && expect.ProxyEndpoint != r.ProxyEndpoint {
+		failures = append(failures, fmt.Sprintf("expected proxy endpoint %s, got %s", expect.ProxyEndpoint, r.ProxyEndpoint))
+	}
+	if expect.TargetEndpoint != "" && expect.TargetEndpoint != r.TargetEndpoint {
+		failures = append(failures, fmt.Sprintf("expected target endpoint %s, got %s", expect.TargetEndpoint, r.TargetEndpoint))
+	}
+	if expect.Flows != nil && !slices.Equal(expect.Flows, r.Flows) {
+		failures = append(failures, fmt.Sprintf("expected flows %v, got %v", expect.Flows, r.Flows))
+	}
+	if !isSubsequence(expect.Policies, r.Policies) {
+		failures = append(failures, fmt.Sprintf("expected policies %v in this order, got %v", expect.Policies, r.Policies))
+	}
+	for _, name := range expect.NotPolicies {
+		if slices.Contains(r.Policies, name) {
+			failures = append(failures, fmt.Sprintf("expected policy %s not to execute", name))
+		}
+	}
+	if expect.Fault != "" && expect.Fault != r.Fault {
+		failures = append(failures, fmt.Sprintf("expected fault raised by %s, got %q", expect.Fault, r.Fault))
+	}
+	if r.Response == nil {
+		return failures
+	}
+	if expect.Status != 0 && expect.Status != r.Response.Status {
+		failures = append(failures, fmt.Sprintf("expected status %d, got %d", expect.Status, r.Response.Status))
+	}
+	for _, name := range sortedKeys(expect.Headers) {
+		if got := r.Response.Headers.Get(name); got != expect.Headers[name] {
+			failures = append(failures, fmt.Sprintf("expected header %s %q, got %q", name, expect.Headers[name], got))
+		}
+	}
+	if expect.Body != nil && strings.TrimSpace(*expect.Body) != strings.TrimSpace(r.Response.Content) {
+		failures = append(failures, fmt.Sprintf("expected body %q, got %q", *expect.Body, r.Response.Content))
+	}
+	if expect.BodyContains != "" && !strings.Contains(r.Response.Content, expect.BodyContains) {
+		failures = append(failures, fmt.Sprintf("expected body to contain %q, got %q", expect.BodyContains, r.Response.Content))
+	}
+	for _, name := range sortedKeys(expect.Variables) {
+		if got, _ := c.get(name); got != expect.Variables[name] {
+			failures = append(failures, fmt.Sprintf("expected variable %s %q, got %q", name, expect.Variables[name], got))
+		}
+	}
+	return failures
+}
+
+// isSubsequence reports whether the elements of want are in got, in the same order
+func isSubsequence(want []string, got []string) bool {
+	i := 0
+	for _, g := range got {
+		if i < len(want) && want[i] == g {
+			i++
+		}
+	}
+	return i == len(want)
+}
+
+func sortedKeys(m map[string]string) (keys []string) {
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Run runs the tests of a suite against an API proxy bundle
+func Run(bundlePath string, suite Suite) ([]*Result, error) {
+	p, err := LoadProxy(bundlePath)
+	if err != nil {
+		return nil, err
+	}
+	results := []*Result{}
+	for _, test := range suite.Tests {
+		results = append(results, p.Run(test))
+	}
+	return results, nil
+}
+
+// Failed returns the number of tests with failures
+func Failed(results []*Result) (n int) {
+	for _, r := range results {
+		if len(r.Failures) > 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// WriteText writes a PASS or FAIL line per test, with the failures and the executed policies of failed tests
+func WriteText(w io.Writer, results []*Result) {
+	for _, r := range results {
+		if len(r.Failures) == 0 {
+			fmt.Fprintf(w, "PASS %s\n", r.Name)
+			continue
+		}
+		fmt.Fprintf(w, "FAIL %s\n", r.Name)
+		for _, f := range r.Failures {
+			fmt.Fprintf(w, "    %s\n", f)
+		}
+		fmt.Fprintf(w, "    flows: %v, policies: %v\n", r.Flows, r.Policies)
+	}
+	fmt.Fprintf(w, "%d tests, %d passed, %d failed\n", len(results), len(results)-Failed(results), Failed(results))
+}
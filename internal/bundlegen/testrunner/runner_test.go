@@ -0,0 +1,231 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testrunner
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+var testBundle = map[string]string{
+	"apiproxy/orders.xml": `<APIProxy name="orders"/>`,
+	"apiproxy/proxies/default.xml": `<ProxyEndpoint name="default">
+  <FaultRules>
+    <FaultRule name="not-found">
+      <Step><Name>NotFoundResponse</Name></Step>
+      <Condition>fault.name = "RaiseFault"</Condition>
+    </FaultRule>
+  </FaultRules>
+  <PreFlow name="PreFlow">
+    <Request>
+      <Step><Name>ExtractId</Name></Step>
+      <Step><Name>CheckKey</Name><Condition>request.header.x-apikey = null</Condition></Step>
+    </Request>
+  </PreFlow>
+  <Flows>
+    <Flow name="get-order">
+      <Condition>(proxy.pathsuffix MatchesPath &quot;/orders/*&quot;) and (request.verb = &quot;GET&quot;)</Condition>
+      <Request><Step><Name>Lookup</Name></Step></Request>
+      <Response><Step><Name>SetHeader</Name></Step></Response>
+    </Flow>
+    <Flow name="ping">
+      <Condition>proxy.pathsuffix MatchesPath "/ping"</Condition>
+    </Flow>
+  </Flows>
+  <HTTPProxyConnection><BasePath>/v1</BasePath></HTTPProxyConnection>
+  <RouteRule name="none"><Condition>proxy.pathsuffix = "/ping"</Condition></RouteRule>
+  <RouteRule name="default"><TargetEndpoint>default</TargetEndpoint></RouteRule>
+</ProxyEndpoint>`,
+	"apiproxy/targets/default.xml": `<TargetEndpoint name="default">
+  <PreFlow name="PreFlow"><Request><Step><Name>Disabled</Name></Step></Request></PreFlow>
+  <HTTPTargetConnection><URL>https://example.com</URL></HTTPTargetConnection>
+</TargetEndpoint>`,
+	"apiproxy/policies/ExtractId.xml": `<ExtractVariables name="ExtractId">
+  <URIPath><Pattern>/orders/{id}</Pattern></URIPath>
+  <VariablePrefix>order</VariablePrefix>
+</ExtractVariables>`,
+	"apiproxy/policies/CheckKey.xml": `<RaiseFault name="CheckKey">
+  <FaultResponse><Set><StatusCode>401</StatusCode><Payload contentType="application/json">{"error":"no key"}</Payload></Set></FaultResponse>
+</RaiseFault>`,
+	"apiproxy/policies/Lookup.xml": `<Javascript name="Lookup"><ResourceURL>jsc://lookup.js</ResourceURL></Javascript>`,
+	"apiproxy/policies/SetHeader.xml": `<AssignMessage name="SetHeader">
+  <Set><Headers><Header name="x-order">{order.id}</Header><Header name="x-owner">{owner}</Header></Headers></Set>
+</AssignMessage>`,
+	"apiproxy/policies/NotFoundResponse.xml": `<AssignMessage name="NotFoundResponse">
+  <Add><Headers><Header name="x-fault">{fault.name}</Header></Headers></Add>
+</AssignMessage>`,
+	"apiproxy/policies/Disabled.xml": `<AssignMessage name="Disabled" enabled="false"/>`,
+}
+
+// faultBundle selects its flow with a variable extracted in the PreFlow and has fault rules in both endpoints
+var faultBundle = map[string]string{
+	"apiproxy/orders.xml": `<APIProxy name="orders"/>`,
+	"apiproxy/proxies/default.xml": `<ProxyEndpoint name="default">
+  <FaultRules>
+    <FaultRule name="proxy-fault">
+      <Step><Name>ProxyFaultResponse</Name></Step>
+      <Condition>fault.name = "RaiseFault"</Condition>
+    </FaultRule>
+  </FaultRules>
+  <PreFlow name="PreFlow"><Request><Step><Name>ExtractId</Name></Step></Request></PreFlow>
+  <Flows>
+    <Flow name="order">
+      <Condition>order.id != null</Condition>
+      <Response><Step><Name>Fail</Name><Condition>request.header.x-fail = "true"</Condition></Step></Response>
+    </Flow>
+  </Flows>
+  <HTTPProxyConnection><BasePath>/v1</BasePath></HTTPProxyConnection>
+  <RouteRule name="default"><TargetEndpoint>default</TargetEndpoint></RouteRule>
+</ProxyEndpoint>`,
+	"apiproxy/targets/default.xml": `<TargetEndpoint name="default">
+  <FaultRules>
+    <FaultRule name="target-fault">
+      <Step><Name>TargetFaultResponse</Name></Step>
+      <Condition>fault.name = "RaiseFault"</Condition>
+    </FaultRule>
+  </FaultRules>
+  <HTTPTargetConnection><URL>https://example.com</URL></HTTPTargetConnection>
+</TargetEndpoint>`,
+	"apiproxy/policies/ExtractId.xml": `<ExtractVariables name="ExtractId">
+  <URIPath><Pattern>/orders/{id}</Pattern></URIPath>
+  <VariablePrefix>order</VariablePrefix>
+</ExtractVariables>`,
+	"apiproxy/policies/Fail.xml": `<RaiseFault name="Fail">
+  <FaultResponse><Set><StatusCode>500</StatusCode></Set></FaultResponse>
+</RaiseFault>`,
+	"apiproxy/policies/ProxyFaultResponse.xml": `<AssignMessage name="ProxyFaultResponse">
+  <Add><Headers><Header name="x-fault-rule">proxy</Header></Headers></Add>
+</AssignMessage>`,
+	"apiproxy/policies/TargetFaultResponse.xml": `<AssignMessage name="TargetFaultResponse">
+  <Add><Headers><Header name="x-fault-rule">target</Header></Headers></Add>
+</AssignMessage>`,
+}
+
+func writeBundle(t *testing.T, bundle map[string]string) string {
+	folder := t.TempDir()
+	for name, content := range bundle {
+		if err := os.MkdirAll(filepath.Join(folder, filepath.Dir(name)), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return folder
+}
+
+func TestRun(t *testing.T) {
+	folder := writeBundle(t, testBundle)
+	body := `{"error":"no key"}`
+	suite := Suite{Tests: []TestCase{
+		{
+			Name:           "get order",
+			Request:        RequestFixture{Path: "/v1/orders/42", Headers: map[string]string{"x-apikey": "abc"}},
+			TargetResponse: &ResponseFixture{Status: 200, Body: `{"id":"42"}`},
+			Stubs:          map[string]map[string]string{"Lookup": {"owner": "alice"}},
+			Expect: Expectation{
+				ProxyEndpoint:  "default",
+				TargetEndpoint: "default",
+				Flows:          []string{"get-order"},
+				Policies:       []string{"ExtractId", "Lookup", "SetHeader"},
+				NotPolicies:    []string{"CheckKey", "Disabled"},
+				Status:         200,
+				Headers:        map[string]string{"x-order": "42", "x-owner": "alice"},
+				Variables:      map[string]string{"order.id": "42"},
+			},
+		},
+		{
+			Name:    "missing key",
+			Request: RequestFixture{Path: "/v1/orders/42"},
+			Expect: Expectation{
+				Flows:       []string{},
+				Policies:    []string{"CheckKey", "NotFoundResponse"},
+				NotPolicies: []string{"Lookup"},
+				Fault:       "CheckKey",
+				Status:      401,
+				Headers:     map[string]string{"x-fault": "RaiseFault"},
+				Body:        &body,
+			},
+		},
+		{
+			Name:    "no target",
+			Request: RequestFixture{Path: "/v1/ping", Headers: map[string]string{"x-apikey": "abc"}},
+			Expect:  Expectation{Flows: []string{"ping"}, TargetEndpoint: "", Status: 200},
+		},
+		{
+			Name:    "wrong expectation",
+			Request: RequestFixture{Path: "/v1/ping", Headers: map[string]string{"x-apikey": "abc"}},
+			Expect:  Expectation{Flows: []string{"get-order"}},
+		},
+	}}
+
+	results, err := Run(filepath.Join(folder, "apiproxy"), suite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results[:3] {
+		if len(r.Failures) > 0 {
+			t.Errorf("%s: %v", r.Name, r.Failures)
+		}
+	}
+	if results[2].TargetEndpoint != "" {
+		t.Errorf("no target: got target endpoint %s", results[2].TargetEndpoint)
+	}
+	if len(results[3].Failures) != 1 {
+		t.Errorf("wrong expectation: got failures %v, want 1", results[3].Failures)
+	}
+	if Failed(results) != 1 {
+		t.Errorf("got %d failed tests, want 1", Failed(results))
+	}
+}
+
+func TestRunFaults(t *testing.T) {
+	folder := writeBundle(t, faultBundle)
+	suite := Suite{Tests: []TestCase{
+		{
+			Name:    "flow selected by a preflow variable",
+			Request: RequestFixture{Path: "/v1/orders/42"},
+			Expect: Expectation{
+				Flows:       []string{"order"},
+				Policies:    []string{"ExtractId"},
+				NotPolicies: []string{"Fail"},
+				Status:      200,
+			},
+		},
+		{
+			Name:    "fault in the proxy response flow",
+			Request: RequestFixture{Path: "/v1/orders/42", Headers: map[string]string{"x-fail": "true"}},
+			Expect: Expectation{
+				Flows:       []string{"order"},
+				Policies:    []string{"ExtractId", "Fail", "ProxyFaultResponse"},
+				NotPolicies: []string{"TargetFaultResponse"},
+				Fault:       "Fail",
+				Status:      500,
+				Headers:     map[string]string{"x-fault-rule": "proxy"},
+			},
+		},
+	}}
+
+	results, err := Run(filepath.Join(folder, "apiproxy"), suite)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range results {
+		if len(r.Failures) > 0 {
+			t.Errorf("%s: %v", r.Name, r.Failures)
+		}
+	}
+}
@@ -21,6 +21,8 @@ var securitySchemesList = securitySchemesListDef{}
 var (
 	quotaPolicyContent       = map[string]string{}
 	spikeArrestPolicyContent = map[string]string{}
+	mockPolicyContent        = map[string]string{}
+	extensionPolicyContent   = map[string]string{}
 )
 
 type pathDetailDef struct {
@@ -33,6 +35,7 @@ type pathDetailDef struct {
 	SecurityScheme securitySchemesDef
 	SpikeArrest    []spikeArrestDef
 	Quota          []quotaDef
+	PolicySteps    []policyStepDef
 }
 
 type spikeArrestDef struct {
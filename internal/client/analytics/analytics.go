@@ -0,0 +1,224 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Query holds the parameters of an analytics stats query
+type Query struct {
+	Environment string
+	Dimensions  []string
+	Select      []string
+	Filter      string
+	TimeRange   string
+	TimeUnit    string
+	SortBy      string
+	Sort        string
+	TopK        int
+	Limit       int
+	Offset      int
+}
+
+// Table is a stats response flattened to a row per dimension value and time slot
+type Table struct {
+	Header []string
+	Rows   [][]string
+}
+
+type statsResponse struct {
+	Environments []struct {
+		Name       string `json:"name,omitempty"`
+		Dimensions []struct {
+			Name    string `json:"name,omitempty"`
+			Metrics []struct {
+				Name   string            `json:"name,omitempty"`
+				Values []json.RawMessage `json:"values,omitempty"`
+			} `json:"metrics,omitempty"`
+		} `json:"dimensions,omitempty"`
+	} `json:"environments,omitempty"`
+}
+
+// timeSlot is a metric value when the query sets a time unit
+type timeSlot struct {
+	Timestamp int64  `json:"timestamp,omitempty"`
+	Value     string `json:"value,omitempty"`
+}
+
+const timeRangeFormat = "01/02/2006 15:04"
+
+// TimeRange returns a time range for the stats API covering the last duration
+func TimeRange(last time.Duration, now time.Time) string {
+	now = now.UTC()
+	return now.Add(-last).Format(timeRangeFormat) + "~" + now.Format(timeRangeFormat)
+}
+
+// Stats runs a stats query against an environment
+func Stats(q Query) (respBody []byte, err error) {
+	if len(q.Dimensions) == 0 {
+		return nil, fmt.Errorf("at least one dimension must be set")
+	}
+	if len(q.Select) == 0 {
+		return nil, fmt.Errorf("at least one metric must be selected")
+	}
+
+	// throttle API Calls
+	getDefaultRate := apiclient.GetRate()
+	apiclient.SetRate(apiclient.ApigeeAnalyticsAPI)
+	defer apiclient.SetRate(getDefaultRate)
+
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", q.Environment,
+		"stats", strings.Join(q.Dimensions, ","))
+
+	v := u.Query()
+	v.Set("select", strings.Join(q.Select, ","))
+	v.Set("timeRange", q.TimeRange)
+	if q.Filter != "" {
+		v.Set("filter", q.Filter)
+	}
+	if q.TimeUnit != "" {
+		v.Set("timeUnit", q.TimeUnit)
+		v.Set("tsAscending", "true")
+	}
+	if q.SortBy != "" {
+		v.Set("sortby", q.SortBy)
+	}
+	if q.Sort != "" {
+		v.Set("sort", q.Sort)
+	}
+	if q.TopK > 0 {
+		v.Set("topk", strconv.Itoa(q.TopK))
+	}
+	if q.Limit > 0 {
+		v.Set("limit", strconv.Itoa(q.Limit))
+	}
+	if q.Offset > 0 {
+		v.Set("offset", strconv.Itoa(q.Offset))
+	}
+	u.RawQuery = v.Encode()
+
+	respBody, err = apiclient.HttpClient(u.String())
+	return respBody, err
+}
+
+// ToTable flattens a stats response. There is a column per dimension and per metric,
+// and a TIMESTAMP column when the values are split by time unit
+func ToTable(respBody []byte, dimensions []string) (t Table, err error) {
+	r := statsResponse{}
+	if err = json.Unmarshal(respBody, &r); err != nil {
+		return t, err
+	}
+
+	var metrics []string
+	timeSeries := false
+	for _, e := range r.Environments {
+		for _, d := range e.Dimensions {
+			for _, m := range d.Metrics {
+				if !contains(metrics, m.Name) {
+					metrics = append(metrics, m.Name)
+				}
+				if len(m.Values) > 0 && strings.HasPrefix(string(m.Values[0]), "{") {
+					timeSeries = true
+				}
+			}
+		}
+	}
+
+	t.Header = append(t.Header, dimensions...)
+	if timeSeries {
+		t.Header = append(t.Header, "TIMESTAMP")
+	}
+	t.Header = append(t.Header, metrics...)
+
+	for _, e := range r.Environments {
+		for _, d := range e.Dimensions {
+			values := map[string][]timeSlot{}
+			slots := 0
+			for _, m := range d.Metrics {
+				for _, raw := range m.Values {
+					s, err := toTimeSlot(raw)
+					if err != nil {
+						return t, err
+					}
+					values[m.Name] = append(values[m.Name], s)
+				}
+				slots = max(slots, len(values[m.Name]))
+			}
+			for i := 0; i < slots; i++ {
+				row := dimensionValues(d.Name, len(dimensions))
+				if timeSeries {
+					row = append(row, timestamp(values, metrics, i))
+				}
+				for _, m := range metrics {
+					value := ""
+					if i < len(values[m]) {
+						value = values[m][i].Value
+					}
+					row = append(row, value)
+				}
+				t.Rows = append(t.Rows, row)
+			}
+		}
+	}
+	return t, nil
+}
+
+func toTimeSlot(raw json.RawMessage) (s timeSlot, err error) {
+	if strings.HasPrefix(string(raw), "{") {
+		err = json.Unmarshal(raw, &s)
+		return s, err
+	}
+	err = json.Unmarshal(raw, &s.Value)
+	return s, err
+}
+
+// dimensionValues splits a dimension name holding the values of several dimensions
+func dimensionValues(name string, count int) []string {
+	if count <= 1 {
+		return []string{name}
+	}
+	values := strings.SplitN(name, ",", count)
+	for len(values) < count {
+		values = append(values, "")
+	}
+	return values
+}
+
+func timestamp(values map[string][]timeSlot, metrics []string, i int) string {
+	for _, m := range metrics {
+		if i < len(values[m]) && values[m][i].Timestamp > 0 {
+			return time.UnixMilli(values[m][i].Timestamp).UTC().Format(time.RFC3339)
+		}
+	}
+	return ""
+}
+
+func contains(l []string, s string) bool {
+	for _, v := range l {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
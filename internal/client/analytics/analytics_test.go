@@ -0,0 +1,69 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestToTable(t *testing.T) {
+	respBody := []byte(`{"environments":[{"name":"test","dimensions":[
+		{"name":"proxy1,target1","metrics":[
+			{"name":"sum(message_count)","values":["12"]},
+			{"name":"p99(total_response_time)","values":["250.5"]}]},
+		{"name":"proxy2,target2","metrics":[
+			{"name":"sum(message_count)","values":["3"]},
+			{"name":"p99(total_response_time)","values":["80.0"]}]}]}]}`)
+	got, err := ToTable(respBody, []string{"apiproxy", "target_url"})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	want := Table{
+		Header: []string{"apiproxy", "target_url", "sum(message_count)", "p99(total_response_time)"},
+		Rows: [][]string{
+			{"proxy1", "target1", "12", "250.5"},
+			{"proxy2", "target2", "3", "80.0"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+
+	respBody = []byte(`{"environments":[{"name":"test","dimensions":[{"name":"proxy1","metrics":[
+		{"name":"sum(message_count)","values":[{"timestamp":1767225600000,"value":"5"},
+		{"timestamp":1767229200000,"value":"7"}]}]}]}]}`)
+	if got, err = ToTable(respBody, []string{"apiproxy"}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	want = Table{
+		Header: []string{"apiproxy", "TIMESTAMP", "sum(message_count)"},
+		Rows: [][]string{
+			{"proxy1", "2026-01-01T00:00:00Z", "5"},
+			{"proxy1", "2026-01-01T01:00:00Z", "7"},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+}
+
+func TestTimeRange(t *testing.T) {
+	now := time.Date(2026, 3, 1, 10, 30, 0, 0, time.UTC)
+	if got := TimeRange(2*time.Hour, now); got != "03/01/2026 08:30~03/01/2026 10:30" {
+		t.Fatalf("got %s", got)
+	}
+}
@@ -0,0 +1,81 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/datastores"
+	"net/url"
+	"path"
+	"time"
+)
+
+// Export is the definition of an analytics data export to a datastore
+type Export struct {
+	Name          string    `json:"name,omitempty"`
+	Description   string    `json:"description,omitempty"`
+	DateRange     DateRange `json:"dateRange,omitempty"`
+	OutputFormat  string    `json:"outputFormat,omitempty"`
+	CsvDelimiter  string    `json:"csvDelimiter,omitempty"`
+	DatastoreName string    `json:"datastoreName,omitempty"`
+}
+
+// DateRange is the range of days to export, as YYYY-MM-DD; the end day is excluded
+type DateRange struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+// CreateExport starts an export of analytics data to a datastore, set by the display
+// name of a datastore in the org
+func CreateExport(environment string, e Export, datastore string) (respBody []byte, err error) {
+	if _, err = datastores.GetName(datastore); err != nil {
+		return nil, fmt.Errorf("datastore %s: %w", datastore, err)
+	}
+	e.DatastoreName = datastore
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "analytics", "exports")
+	respBody, err = apiclient.HttpClient(u.String(), string(payload))
+	return respBody, err
+}
+
+// GetExport returns the status of an export
+func GetExport(environment string, id string) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "analytics", "exports", id)
+	respBody, err = apiclient.HttpClient(u.String())
+	return respBody, err
+}
+
+// ListExports lists the exports of an environment
+func ListExports(environment string) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "analytics", "exports")
+	respBody, err = apiclient.HttpClient(u.String())
+	return respBody, err
+}
+
+// WaitForExport polls an export until it completes
+func WaitForExport(environment string, id string, interval time.Duration, timeout time.Duration) (respBody []byte, err error) {
+	return waitForJob("Export "+id, func() ([]byte, error) {
+		return GetExport(environment, id)
+	}, interval, timeout)
+}
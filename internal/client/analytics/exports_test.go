@@ -0,0 +1,64 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestCreateExport(t *testing.T) {
+	var exported map[string]interface{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/organizations/test/analytics/datastores":
+			fmt.Fprint(w, `{"datastores":[{"self":"/organizations/test/analytics/datastores/3f2a",
+				"displayName":"daily-gcs","org":"test","targetType":"gcs"}]}`)
+		case "/v1/organizations/test/environments/prod/analytics/exports":
+			body, _ := io.ReadAll(r.Body)
+			if err := json.Unmarshal(body, &exported); err != nil {
+				t.Errorf("invalid export %s", body)
+			}
+			fmt.Fprint(w, `{"self":"/organizations/test/environments/prod/analytics/exports/e1","state":"enqueued"}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer ts.Close()
+
+	apiclient.NewApigeeClient(apiclient.ApigeeClientOptions{NoOutput: true})
+	apiclient.SetApigeeToken("test")
+	apiclient.SetApigeeBaseURL(ts.URL + "/v1/organizations/")
+	defer apiclient.SetApigeeBaseURL("")
+	_ = apiclient.SetApigeeOrg("test")
+
+	e := Export{Name: "daily", DateRange: DateRange{Start: "2026-01-01", End: "2026-01-02"}, OutputFormat: "csv"}
+	if _, err := CreateExport("prod", e, "daily-gcs"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if exported["datastoreName"] != "daily-gcs" {
+		t.Fatalf("expected the display name of the datastore, got %v", exported["datastoreName"])
+	}
+
+	exported = nil
+	if _, err := CreateExport("prod", e, "missing"); err == nil || exported != nil {
+		t.Fatalf("expected an error for a missing datastore, got %v", err)
+	}
+}
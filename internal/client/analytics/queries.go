@@ -0,0 +1,280 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/clilog"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AsyncQuery is the definition of an asynchronous analytics query
+type AsyncQuery struct {
+	Name            string        `json:"name,omitempty"`
+	Metrics         []QueryMetric `json:"metrics,omitempty"`
+	Dimensions      []string      `json:"dimensions,omitempty"`
+	TimeRange       interface{}   `json:"timeRange,omitempty"`
+	Filter          string        `json:"filter,omitempty"`
+	GroupByTimeUnit string        `json:"groupByTimeUnit,omitempty"`
+	Limit           int           `json:"limit,omitempty"`
+	OutputFormat    string        `json:"outputFormat,omitempty"`
+	CsvDelimiter    string        `json:"csvDelimiter,omitempty"`
+}
+
+// QueryMetric is a metric and the aggregate function applied to it
+type QueryMetric struct {
+	Name     string `json:"name,omitempty"`
+	Function string `json:"function,omitempty"`
+}
+
+// TimeInterval is a time range with a start and an end
+type TimeInterval struct {
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
+
+type asyncJob struct {
+	Self        string `json:"self,omitempty"`
+	State       string `json:"state,omitempty"`
+	Error       string `json:"error,omitempty"`
+	QueryParams struct {
+		OutputFormat string `json:"outputFormat,omitempty"`
+	} `json:"queryParams,omitempty"`
+}
+
+// ParseMetric parses a metric set as function(name), for ex: sum(message_count)
+func ParseMetric(metric string) (m QueryMetric, err error) {
+	open := strings.Index(metric, "(")
+	if open < 0 {
+		return QueryMetric{Name: metric}, nil
+	}
+	if !strings.HasSuffix(metric, ")") || open == 0 {
+		return m, fmt.Errorf("invalid metric %s, must be function(name)", metric)
+	}
+	return QueryMetric{Function: metric[:open], Name: metric[open+1 : len(metric)-1]}, nil
+}
+
+// AsyncTimeRange returns a time range for an asynchronous query. A range set as start~end
+// is split into a start and an end; others, like last24hours, are sent as-is
+func AsyncTimeRange(timeRange string, last time.Duration, now time.Time) interface{} {
+	if last > 0 {
+		now = now.UTC()
+		return TimeInterval{Start: now.Add(-last).Format(time.RFC3339), End: now.Format(time.RFC3339)}
+	}
+	if start, end, found := strings.Cut(timeRange, "~"); found {
+		return TimeInterval{Start: start, End: end}
+	}
+	return timeRange
+}
+
+// CreateQuery submits an asynchronous query
+func CreateQuery(environment string, q AsyncQuery) (respBody []byte, err error) {
+	payload, err := json.Marshal(q)
+	if err != nil {
+		return nil, err
+	}
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "queries")
+	respBody, err = apiclient.HttpClient(u.String(), string(payload))
+	return respBody, err
+}
+
+// GetQuery returns the status of an asynchronous query
+func GetQuery(environment string, id string) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "queries", id)
+	respBody, err = apiclient.HttpClient(u.String())
+	return respBody, err
+}
+
+// ListQueries lists the asynchronous queries of an environment
+func ListQueries(environment string, submittedBy string, from string, to string) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "queries")
+	q := u.Query()
+	if submittedBy != "" {
+		q.Set("submittedBy", submittedBy)
+	}
+	if from != "" {
+		q.Set("from", from)
+	}
+	if to != "" {
+		q.Set("to", to)
+	}
+	u.RawQuery = q.Encode()
+	respBody, err = apiclient.HttpClient(u.String())
+	return respBody, err
+}
+
+// JobID returns the id of a query or an export from its create or get response
+func JobID(respBody []byte) (id string, err error) {
+	j := asyncJob{}
+	if err = json.Unmarshal(respBody, &j); err != nil {
+		return "", err
+	}
+	if j.Self == "" {
+		return "", fmt.Errorf("id not found in response")
+	}
+	return path.Base(j.Self), nil
+}
+
+// WaitForQuery polls an asynchronous query until it completes
+func WaitForQuery(environment string, id string, interval time.Duration, timeout time.Duration) (respBody []byte, err error) {
+	return waitForJob("Query "+id, func() ([]byte, error) {
+		return GetQuery(environment, id)
+	}, interval, timeout)
+}
+
+// DownloadQueryResult downloads the result of a completed query into folder and
+// decompresses it. The names of the files written are returned
+func DownloadQueryResult(environment string, id string, folder string) (files []string, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	respBody, err := GetQuery(environment, id)
+	if err != nil {
+		return nil, err
+	}
+	j := asyncJob{}
+	if err = json.Unmarshal(respBody, &j); err != nil {
+		return nil, err
+	}
+	if !strings.EqualFold(j.State, "completed") {
+		return nil, fmt.Errorf("query %s is %s, the result is available once it is completed", id, j.State)
+	}
+	format := strings.ToLower(j.QueryParams.OutputFormat)
+	if format == "" {
+		format = "json"
+	}
+
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", environment, "queries", id, "result")
+	resp, err := apiclient.DownloadFile(u.String(), true)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, nil
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return writeResult(content, folder, id+"."+format)
+}
+
+// writeResult writes a query result, decompressing gzip and zip content
+func writeResult(content []byte, folder string, fileName string) (files []string, err error) {
+	if err = os.MkdirAll(folder, 0o755); err != nil {
+		return nil, err
+	}
+	switch {
+	case bytes.HasPrefix(content, []byte{0x1f, 0x8b}):
+		r, err := gzip.NewReader(bytes.NewReader(content))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		if content, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	case bytes.HasPrefix(content, []byte("PK\x03\x04")):
+		r, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range r.File {
+			if f.FileInfo().IsDir() {
+				continue
+			}
+			rc, err := f.Open()
+			if err != nil {
+				return files, err
+			}
+			data, err := io.ReadAll(rc)
+			rc.Close()
+			if err != nil {
+				return files, err
+			}
+			name := filepath.Join(folder, filepath.Base(f.Name))
+			if err = os.WriteFile(name, data, 0o644); err != nil {
+				return files, err
+			}
+			files = append(files, name)
+		}
+		return files, nil
+	}
+	name := filepath.Join(folder, fileName)
+	if err = os.WriteFile(name, content, 0o644); err != nil {
+		return nil, err
+	}
+	return []string{name}, nil
+}
+
+// waitForJob polls an asynchronous query or export until it is completed or failed
+func waitForJob(kind string, get func() ([]byte, error), interval time.Duration,
+	timeout time.Duration,
+) (respBody []byte, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	var expired <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		expired = timer.C
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if respBody, err = get(); err != nil {
+			return nil, err
+		}
+		j := asyncJob{}
+		if err = json.Unmarshal(respBody, &j); err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(j.State) {
+		case "completed":
+			clilog.Info.Printf("%s completed\n", kind)
+			return respBody, nil
+		case "failed", "expired":
+			return respBody, fmt.Errorf("%s is %s: %s", kind, j.State, j.Error)
+		}
+		clilog.Info.Printf("%s status is: %s. Waiting %s.\n", kind, j.State, interval)
+
+		select {
+		case <-apiclient.GetContext().Done():
+			return respBody, fmt.Errorf("wait for %s interrupted: %w", kind, apiclient.GetContext().Err())
+		case <-expired:
+			return respBody, fmt.Errorf("%s did not complete within %s", kind, timeout)
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseMetric(t *testing.T) {
+	m, err := ParseMetric("sum(message_count)")
+	if err != nil || m != (QueryMetric{Name: "message_count", Function: "sum"}) {
+		t.Fatalf("got %+v, %v", m, err)
+	}
+	if m, err = ParseMetric("message_count"); err != nil || m != (QueryMetric{Name: "message_count"}) {
+		t.Fatalf("got %+v, %v", m, err)
+	}
+	if _, err = ParseMetric("sum(message_count"); err == nil {
+		t.Fatal("expected an invalid metric")
+	}
+}
+
+func TestWriteResult(t *testing.T) {
+	folder := t.TempDir()
+	var gz bytes.Buffer
+	w := gzip.NewWriter(&gz)
+	_, _ = w.Write([]byte("apiproxy,sum(message_count)\nproxy1,12\n"))
+	_ = w.Close()
+	files, err := writeResult(gz.Bytes(), folder, "q1.csv")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !reflect.DeepEqual(files, []string{filepath.Join(folder, "q1.csv")}) {
+		t.Fatalf("unexpected files %v", files)
+	}
+	if data, _ := os.ReadFile(files[0]); string(data) != "apiproxy,sum(message_count)\nproxy1,12\n" {
+		t.Fatalf("unexpected content %s", data)
+	}
+
+	var z bytes.Buffer
+	zw := zip.NewWriter(&z)
+	f, _ := zw.Create("result/part-0.json")
+	_, _ = f.Write([]byte(`{"rows":[]}`))
+	_ = zw.Close()
+	if files, err = writeResult(z.Bytes(), folder, "q2.json"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !reflect.DeepEqual(files, []string{filepath.Join(folder, "part-0.json")}) {
+		t.Fatalf("unexpected files %v", files)
+	}
+}
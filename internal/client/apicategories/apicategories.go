@@ -157,26 +157,63 @@ func Export(folder string) (err error) {
 
 // Import
 func Import(siteid string, apicateogyFile string) (err error) {
+	_, err = ImportAndMapIDs(siteid, apicateogyFile)
+	return err
+}
+
+// ImportAndMapIDs creates the categories in the file that do not exist in the site.
+// Category IDs are assigned by the site, the returned map holds the ID in the site
+// for each category ID in the file
+func ImportAndMapIDs(siteid string, apicateogyFile string) (ids map[string]string, err error) {
 	errs := []string{}
 	l, err := readAPICategoriesFile(apicateogyFile)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if len(l.Data) < 1 {
 		clilog.Warning.Println("No categories found for the siteid")
-		return nil
+		return nil, nil
 	}
 
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	listRespBytes, err := List(siteid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch apicategories: %w", err)
+	}
+	known := listapicategories{}
+	if err = json.Unmarshal(listRespBytes, &known); err != nil {
+		return nil, fmt.Errorf("failed to unmarshall: %w", err)
+	}
+	knownIDs := map[string]string{}
+	for _, category := range known.Data {
+		knownIDs[category.Name] = category.ID
+	}
+
+	ids = map[string]string{}
 	for _, category := range l.Data {
-		_, err = Create(siteid, category.Name)
+		if id, ok := knownIDs[category.Name]; ok {
+			ids[category.ID] = id
+			continue
+		}
+		respBody, err := Create(siteid, category.Name)
 		if err != nil {
 			errs = append(errs, err.Error())
+			continue
+		}
+		created := struct {
+			Data data `json:"data,omitempty"`
+		}{}
+		if err = json.Unmarshal(respBody, &created); err != nil {
+			return nil, err
 		}
+		ids[category.ID] = created.Data.ID
 	}
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return ids, errors.New(strings.Join(errs, "\n"))
 	}
-	return nil
+	return ids, nil
 }
 
 func readAPICategoriesFile(fileName string) (l listapicategories, err error) {
@@ -34,7 +34,7 @@ var (
 func TestCreate(t *testing.T) {
 	var respBody []byte
 	var respJSONMap map[string]interface{}
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -58,7 +58,7 @@ func TestCreate(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -69,7 +69,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestGetByName(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -81,7 +81,7 @@ func TestGetByName(t *testing.T) {
 
 func TestGetIDByName(t *testing.T) {
 	var id string
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -95,7 +95,7 @@ func TestGetIDByName(t *testing.T) {
 }
 
 func TestList(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -106,7 +106,7 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
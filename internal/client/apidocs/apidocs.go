@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"internal/apiclient"
 	"internal/client/sites"
+	"internal/clilog"
 	"internal/cmd/utils"
 	"io"
 	"net/url"
@@ -350,8 +351,9 @@ Now import the data to org2 as
 apigeecli apidocs import -o org2 -s site1 --source-f . --use-new-siteid=true -t $token
 */
 
-// Import
-func Import(siteid string, useSrcSiteID string, folder string) (err error) {
+// Import creates the apidocs of a site. When categoryIDs is set, the category IDs
+// of each apidoc are replaced with the matching IDs in the site
+func Import(siteid string, useSrcSiteID string, folder string, categoryIDs map[string]string) (err error) {
 	var errs []string
 	var respBody []byte
 	var docsList []data
@@ -365,6 +367,9 @@ func Import(siteid string, useSrcSiteID string, folder string) (err error) {
 		return err
 	}
 	for _, doc := range docsList {
+		if categoryIDs != nil {
+			doc.CategoryIDs = mapCategoryIDs(doc.Title, doc.CategoryIDs, categoryIDs)
+		}
 		// 1. create the apidoc object
 		respBody, err = Create(siteid, doc.Title, doc.Description, strconv.FormatBool(doc.Published),
 			strconv.FormatBool(doc.AnonAllowed), doc.ApiProductName,
@@ -417,6 +422,17 @@ func Import(siteid string, useSrcSiteID string, folder string) (err error) {
 	return nil
 }
 
+func mapCategoryIDs(title string, srcIDs []string, categoryIDs map[string]string) (ids []string) {
+	for _, srcID := range srcIDs {
+		if id, ok := categoryIDs[srcID]; ok {
+			ids = append(ids, id)
+		} else {
+			clilog.Warning.Printf("Category %s of apidoc %s was not imported, removing it\n", srcID, title)
+		}
+	}
+	return ids
+}
+
 func getArrayStr(str []string) string {
 	tmp := strings.Join(str, ",")
 	tmp = strings.ReplaceAll(tmp, ",", "\",\"")
@@ -42,7 +42,7 @@ func TestCreate(t *testing.T) {
 	categoryIds := []string{}
 	apiProductName := "test"
 
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -66,7 +66,7 @@ func TestCreate(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -77,7 +77,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestGetByTitle(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -88,7 +88,7 @@ func TestGetByTitle(t *testing.T) {
 }
 
 func TestList(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -101,7 +101,7 @@ func TestList(t *testing.T) {
 func TestUpdateDocumentation(t *testing.T) {
 	var openAPIDoc []byte
 	displayName := "test"
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
@@ -115,7 +115,7 @@ func TestUpdateDocumentation(t *testing.T) {
 }
 
 func TestGetDocumentation(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -126,7 +126,7 @@ func TestGetDocumentation(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Errorf("setup failed: %v", err)
@@ -79,7 +79,8 @@ type conflictingdeployment struct {
 	Revision    string `json:"revision,omitempty"`
 }
 
-const interval = 10
+// interval is the time between two checks of a deployment
+var interval = 10 * time.Second
 
 // CreateProxy
 func CreateProxy(name string, proxy string, space string) (respBody []byte, err error) {
@@ -436,8 +437,16 @@ func CleanProxy(name string, reportOnly bool, keepList []string) (err error) {
 	return nil
 }
 
-// ExportProxies
-func ExportProxies(conn int, folder string, allRevisions bool, space string) (err error) {
+// ExportProxies exports proxy bundles to a folder. When incremental is set, only new
+// or changed revisions are downloaded and bundles of deleted proxies are removed
+func ExportProxies(conn int, folder string, allRevisions bool, space string, incremental bool) (err error) {
+	var manifest *apiclient.Manifest
+	if incremental {
+		if manifest, err = apiclient.ReadManifest(folder); err != nil {
+			return err
+		}
+	}
+
 	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
 	q := u.Query()
 	q.Set("includeRevisions", "true")
@@ -485,15 +494,18 @@ func ExportProxies(conn int, folder string, allRevisions bool, space string) (er
 
 	for i := 0; i < conn; i++ {
 		fanOutWg.Add(1)
-		go exportAPIProxies(&fanOutWg, jobChan, folder, allRevisions, errChan)
+		go exportAPIProxies(&fanOutWg, jobChan, folder, allRevisions, manifest, errChan)
 	}
 
 	for _, proxy := range prxs.Proxies {
 		if allRevisions {
 			for _, rev := range proxy.Revision {
+				if apiclient.Canceled() {
+					break
+				}
 				jobChan <- revision{name: proxy.Name, rev: rev}
 			}
-		} else {
+		} else if !apiclient.Canceled() {
 			lastRevision := maxRevision(proxy.Revision)
 			jobChan <- revision{name: proxy.Name, rev: lastRevision}
 		}
@@ -503,20 +515,38 @@ func ExportProxies(conn int, folder string, allRevisions bool, space string) (er
 	close(errChan)
 	fanInWg.Wait()
 
+	if err = apiclient.GetContext().Err(); err != nil {
+		if manifest != nil {
+			_ = manifest.Write()
+		}
+		return fmt.Errorf("proxy export interrupted: %w", err)
+	}
+
+	if manifest != nil {
+		if err = manifest.Prune(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if err = manifest.Write(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, "\n"))
 	}
 	return nil
 }
 
-func exportAPIProxies(wg *sync.WaitGroup, jobs <-chan revision, folder string, allRevisions bool, errs chan<- error) {
+func exportAPIProxies(wg *sync.WaitGroup, jobs <-chan revision, folder string, allRevisions bool,
+	manifest *apiclient.Manifest, errs chan<- error,
+) {
 	defer wg.Done()
 	for {
 		job, ok := <-jobs
 		if !ok {
 			return
 		}
-		err := apiclient.FetchBundle("apis", folder, job.name, job.rev, allRevisions)
+		err := apiclient.FetchChangedBundle("apis", folder, job.name, job.rev, allRevisions, manifest)
 		if err != nil {
 			errs <- err
 		}
@@ -557,7 +587,7 @@ func ImportProxies(conn int, folder string, space string) error {
 	fanOutWg := sync.WaitGroup{}
 	fanInWg := sync.WaitGroup{}
 
-	errs := []string{}
+	errs := []error{}
 	fanInWg.Add(1)
 	go func() {
 		defer fanInWg.Done()
@@ -566,7 +596,7 @@ func ImportProxies(conn int, folder string, space string) error {
 			if !ok {
 				return
 			}
-			errs = append(errs, newErr.Error())
+			errs = append(errs, newErr)
 		}
 	}()
 
@@ -576,6 +606,9 @@ func ImportProxies(conn int, folder string, space string) error {
 	}
 
 	for _, bundle := range bundles {
+		if apiclient.Canceled() {
+			break
+		}
 		jobChan <- bundle
 	}
 	close(jobChan)
@@ -583,8 +616,12 @@ func ImportProxies(conn int, folder string, space string) error {
 	close(errChan)
 	fanInWg.Wait()
 
+	if err = apiclient.GetContext().Err(); err != nil {
+		return fmt.Errorf("proxy import interrupted: %w", err)
+	}
+
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errors.Join(errs...)
 	}
 	return nil
 }
@@ -629,7 +666,7 @@ func importAPIProxies(wg *sync.WaitGroup, jobs <-chan string, space string, errs
 			errs <- err
 			continue
 		}
-		req, err := http.NewRequest(http.MethodPost, u.String(), reqBody)
+		req, err := http.NewRequestWithContext(apiclient.GetContext(), http.MethodPost, u.String(), reqBody)
 		if err != nil {
 			errs <- err
 			continue
@@ -647,11 +684,13 @@ func importAPIProxies(wg *sync.WaitGroup, jobs <-chan string, space string, errs
 			continue
 		}
 		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			b = []byte(err.Error())
+			errs <- fmt.Errorf("bundle %s not imported: %w", n, err)
+			continue
 		}
-		if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			errs <- fmt.Errorf("bundle %s not imported: (HTTP %v) %s", n, resp.StatusCode, b)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			errs <- fmt.Errorf("bundle %s not imported: %w", n, apiclient.NewAPIError(resp, b))
 			continue
 		}
 
@@ -707,41 +746,16 @@ func maxRevision(revisionList []string) string {
 	return strconv.Itoa(max)
 }
 
-// Wait
-func Wait(name string, revision int) error {
-	var err error
-
-	clilog.Info.Printf("Checking deployment status in %d seconds\n", interval)
-
-	apiclient.DisableCmdPrintHttpResponse()
-
-	stop := apiclient.Every(interval*time.Second, func(time.Time) bool {
-		var respBody []byte
-		respMap := make(map[string]interface{})
-		if respBody, err = ListProxyRevisionDeployments(name, revision); err != nil {
-			clilog.Error.Printf("Error fetching proxy revision status: %v", err)
-			return false
-		}
-
-		if err = json.Unmarshal(respBody, &respMap); err != nil {
-			return true
-		}
-
-		switch respMap["state"] {
-		case "PROGRESSING":
-			clilog.Info.Printf("Proxy deployment status is: %s. Waiting %d seconds.\n", respMap["state"], interval)
-			return true
-		case "READY":
-			clilog.Info.Println("Proxy deployment completed with status: ", respMap["state"])
-		default:
-			clilog.Info.Println("Proxy deployment failed with status: ", respMap["state"])
-			err = fmt.Errorf("proxy deployment failed with status: %s", respMap["state"])
-		}
-
-		return false
-	})
-
-	<-stop
+// Wait polls the deployment of the proxy revision in the environment until it
+// completes. A timeout of 0 waits until the deployment completes
+func Wait(name string, revision int, timeout time.Duration) error {
+	clilog.Info.Printf("Checking deployment status in %s\n", interval)
 
+	_, err := apiclient.WaitForDeployments([]apiclient.DeploymentTarget{{
+		EntityType:  "apis",
+		Name:        name,
+		Revision:    revision,
+		Environment: apiclient.GetApigeeEnv(),
+	}}, interval, timeout)
 	return err
 }
@@ -31,21 +31,22 @@ const (
 var cliPath = os.Getenv("APIGEECLI_PATH")
 
 func TestCreateProxy(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
 
-	if _, err := CreateProxy(proxyName, path.Join(cliPath, testFolder, "test_proxy.zip")); err != nil {
+	if _, err := CreateProxy(proxyName, path.Join(cliPath, testFolder, "test_proxy.zip"), ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestFetchProxy(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
+	clienttest.ChdirTemp(t)
 	if err := FetchProxy(proxyName, 1); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -53,7 +54,7 @@ func TestFetchProxy(t *testing.T) {
 }
 
 func TestGetProxy(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -66,7 +67,7 @@ func TestGetProxy(t *testing.T) {
 }
 
 func TestGetHighestProxyRevision(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -76,7 +77,7 @@ func TestGetHighestProxyRevision(t *testing.T) {
 }
 
 func TestGenerateDeployChangeReport(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -86,28 +87,30 @@ func TestGenerateDeployChangeReport(t *testing.T) {
 }
 
 func TestDeployProxy(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
 	if _, err := DeployProxy(proxyName, 1, false, false, false, ""); err != nil {
 		t.Fatalf("%v", err)
 	}
-	Wait(proxyName, 1)
+	if err := Wait(proxyName, 1, 0); err != nil {
+		t.Fatalf("%v", err)
+	}
 }
 
 func TestListProxies(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := ListProxies(true); err != nil {
+	if _, err := ListProxies(true, ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestListProxyDeployments(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -117,7 +120,7 @@ func TestListProxyDeployments(t *testing.T) {
 }
 
 func TestListProxyRevisionDeployments(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -127,7 +130,7 @@ func TestListProxyRevisionDeployments(t *testing.T) {
 }
 
 func TestGenerateUndeployChangeReport(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -136,7 +139,7 @@ func TestGenerateUndeployChangeReport(t *testing.T) {
 }
 
 func TestUndeployProxy(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -150,7 +153,7 @@ func TestUpdate(t *testing.T) {
 		"label1": "value1",
 		"label2": "value2",
 	}
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -162,11 +165,11 @@ func TestUpdate(t *testing.T) {
 // TODO: CleanProxy, ExportProxies
 
 func TestDeleteProxyRevision(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := CreateProxy(proxyName, ""); err != nil {
+	if _, err := CreateProxy(proxyName, "", ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 	if _, err := DeleteProxyRevision(proxyName, 2); err != nil {
@@ -175,7 +178,7 @@ func TestDeleteProxyRevision(t *testing.T) {
 }
 
 func TestDeleteProxy(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
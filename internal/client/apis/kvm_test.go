@@ -24,11 +24,11 @@ const (
 )
 
 func TestCreateProxyKVM(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := CreateProxy(proxyName, ""); err != nil {
+	if _, err := CreateProxy(proxyName, "", ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 	if _, err := CreateProxyKVM(proxyName, kvmName, true); err != nil {
@@ -37,7 +37,7 @@ func TestCreateProxyKVM(t *testing.T) {
 }
 
 func TestListProxyKVM(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -47,7 +47,7 @@ func TestListProxyKVM(t *testing.T) {
 }
 
 func TestDeleteProxyKVM(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
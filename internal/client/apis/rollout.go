@@ -0,0 +1,411 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/analytics"
+	"internal/clilog"
+	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"time"
+
+	proxytypes "internal/bundlegen/common"
+)
+
+// RolloutOptions are the parameters of a canary rollout
+type RolloutOptions struct {
+	Name        string
+	Revision    int
+	Environment string
+	// CanaryEnvironment is an environment, attached to its own environment group, where the
+	// revision is deployed before the promotion
+	CanaryEnvironment string
+	// CanaryBasePath is prepended to the base paths of a copy of the revision, deployed
+	// as the proxy <name>-canary in the environment before the promotion
+	CanaryBasePath string
+	// Window is how long the canary is watched, Interval the time between two checks
+	Window   time.Duration
+	Interval time.Duration
+	// MaxErrorRate is the highest percentage of errors of the canary
+	MaxErrorRate float64
+	// MaxLatency is the highest average total response time of the canary
+	MaxLatency time.Duration
+	// MinRequests is the lowest number of requests to the canary to promote it
+	MinRequests int
+	// WaitTimeout is the maximum time to wait for a deployment, defaultWaitTimeout when not set
+	WaitTimeout time.Duration
+	// ServiceAccountName is the service account of the deployments
+	ServiceAccountName string
+	// Space is the space of the canary proxy
+	Space   string
+	LogFile string
+}
+
+// CanaryStats are the analytics of the canary since its deployment
+type CanaryStats struct {
+	Requests  int     `json:"requests"`
+	Errors    int     `json:"errors"`
+	ErrorRate float64 `json:"errorRate"`
+	// Latency is the average total response time in milliseconds
+	Latency float64 `json:"latencyMs"`
+}
+
+// RolloutEntry is a line of the rollout log
+type RolloutEntry struct {
+	Time     string       `json:"time"`
+	Step     string       `json:"step"`
+	Decision string       `json:"decision,omitempty"`
+	Message  string       `json:"message,omitempty"`
+	Stats    *CanaryStats `json:"stats,omitempty"`
+}
+
+// rollout is a running rollout
+type rollout struct {
+	opts     RolloutOptions
+	log      *os.File
+	previous int
+	// canaryName and canaryEnv are the proxy and the environment of the canary
+	canaryName     string
+	canaryEnv      string
+	canaryRevision int
+	started        time.Time
+}
+
+const (
+	decisionContinue = "continue"
+	decisionPromote  = "promote"
+	decisionRollback = "rollback"
+	canarySuffix     = "-canary"
+	canaryStatsQuery = "sum(message_count),sum(is_error),avg(total_response_time)"
+	// defaultWaitTimeout bounds the deployments of a rollout, which must not wait forever
+	defaultWaitTimeout = 10 * time.Minute
+)
+
+var basePathElement = regexp.MustCompile(`(<BasePath>)\s*([^<]*?)\s*(</BasePath>)`)
+
+// Rollout deploys a revision as a canary, watches its error rate and latency in analytics for a
+// window, then promotes the revision to the environment or removes the canary. If the promotion
+// fails, the previous revision is deployed again. Every decision is appended to the log file
+func Rollout(opts RolloutOptions) (err error) {
+	if (opts.CanaryEnvironment == "") == (opts.CanaryBasePath == "") {
+		return fmt.Errorf("either a canary environment or a canary base path must be set, not both")
+	}
+	if opts.WaitTimeout <= 0 {
+		opts.WaitTimeout = defaultWaitTimeout
+	}
+	r := &rollout{opts: opts, canaryName: opts.Name, canaryEnv: opts.CanaryEnvironment}
+	if r.log, err = os.OpenFile(opts.LogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644); err != nil {
+		return err
+	}
+	defer r.log.Close()
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	defer apiclient.SetApigeeEnv(apiclient.GetApigeeEnv())
+
+	apiclient.SetApigeeEnv(opts.Environment)
+	if r.previous, err = deployedRevision(opts.Name); err != nil {
+		return r.fail("start", err)
+	}
+	r.record(RolloutEntry{Step: "start", Message: fmt.Sprintf("rollout of %s revision %d to %s, previous revision %d",
+		opts.Name, opts.Revision, opts.Environment, r.previous)})
+
+	if err = r.deployCanary(); err != nil {
+		r.removeCanary()
+		return r.fail("canary", err)
+	}
+
+	decision, err := r.watch()
+	if err != nil {
+		r.removeCanary()
+		return r.fail("watch", err)
+	}
+	if decision == decisionRollback {
+		r.removeCanary()
+		r.record(RolloutEntry{Step: "rollback", Decision: decisionRollback,
+			Message: fmt.Sprintf("canary removed, revision %d continues to serve %s", r.previous, opts.Environment)})
+		return fmt.Errorf("rollout of %s revision %d was rolled back", opts.Name, opts.Revision)
+	}
+
+	if err = r.promote(); err != nil {
+		r.removeCanary()
+		return r.fail("promote", err)
+	}
+	r.removeCanary()
+	r.record(RolloutEntry{Step: "done", Decision: decisionPromote,
+		Message: fmt.Sprintf("revision %d serves %s", opts.Revision, opts.Environment)})
+	return nil
+}
+
+// deployCanary deploys the revision to the canary environment, or a copy of the revision under the
+// canary base path, and waits for the deployment
+func (r *rollout) deployCanary() (err error) {
+	r.canaryRevision = r.opts.Revision
+	if r.opts.CanaryBasePath != "" {
+		r.canaryName = r.opts.Name + canarySuffix
+		r.canaryEnv = r.opts.Environment
+		if r.canaryRevision, err = r.importCanary(); err != nil {
+			return err
+		}
+	}
+	apiclient.SetApigeeEnv(r.canaryEnv)
+	if _, err = DeployProxy(r.canaryName, r.canaryRevision, true, false, false, r.opts.ServiceAccountName); err != nil {
+		return err
+	}
+	if err = Wait(r.canaryName, r.canaryRevision, r.opts.WaitTimeout); err != nil {
+		return err
+	}
+	r.started = time.Now()
+	r.record(RolloutEntry{Step: "canary", Message: fmt.Sprintf("%s revision %d deployed to %s",
+		r.canaryName, r.canaryRevision, r.canaryEnv)})
+	return nil
+}
+
+// importCanary imports a copy of the revision with the canary base path as the canary proxy
+func (r *rollout) importCanary() (int, error) {
+	tmpDir, err := os.MkdirTemp("", "proxy")
+	if err != nil {
+		return -1, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bundlePath, err := apiclient.DownloadBundle("apis", tmpDir, r.opts.Name, strconv.Itoa(r.opts.Revision))
+	if err != nil {
+		return -1, err
+	}
+	b, err := proxytypes.ReadBundle(bundlePath)
+	if err != nil {
+		return -1, err
+	}
+	canaryPath := path.Join(tmpDir, r.canaryName+".zip")
+	if err = writeCanaryBundle(b, r.opts.CanaryBasePath, canaryPath); err != nil {
+		return -1, err
+	}
+	respBody, err := apiclient.ImportBundle("apis", r.canaryName, canaryPath, r.opts.Space)
+	if err != nil {
+		return -1, err
+	}
+	imported := struct {
+		Revision string `json:"revision,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &imported); err != nil {
+		return -1, err
+	}
+	return strconv.Atoi(imported.Revision)
+}
+
+// writeCanaryBundle writes a bundle with the canary base path prepended to the base paths of the proxy endpoints
+func writeCanaryBundle(b proxytypes.Bundle, canaryBasePath string, fileName string) error {
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for _, name := range b.Names() {
+		content := b.Files[name]
+		if b.In(name, "proxies") {
+			content = basePathElement.ReplaceAllFunc(content, func(m []byte) []byte {
+				parts := basePathElement.FindSubmatch(m)
+				return []byte(string(parts[1]) + path.Join("/", canaryBasePath, string(parts[2])) + string(parts[3]))
+			})
+		}
+		fw, err := w.Create(name)
+		if err != nil {
+			return err
+		}
+		if _, err = fw.Write(content); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// watch checks the analytics of the canary until the end of the window or until a threshold is crossed
+func (r *rollout) watch() (string, error) {
+	timer := time.NewTimer(r.opts.Interval)
+	defer timer.Stop()
+	for {
+		select {
+		case <-apiclient.GetContext().Done():
+			return "", fmt.Errorf("rollout canceled: %w", apiclient.GetContext().Err())
+		case <-timer.C:
+		}
+		stats, err := canaryStats(r.canaryEnv, r.canaryName, r.started)
+		if err != nil {
+			return "", err
+		}
+		done := time.Since(r.started) >= r.opts.Window
+		decision, reason := Decide(stats, r.opts, done)
+		r.record(RolloutEntry{Step: "watch", Decision: decision, Message: reason, Stats: &stats})
+		if decision != decisionContinue {
+			return decision, nil
+		}
+		timer.Reset(r.opts.Interval)
+	}
+}
+
+// Decide returns whether to continue watching the canary, to promote it or to roll it back, and why
+func Decide(stats CanaryStats, opts RolloutOptions, windowDone bool) (decision string, reason string) {
+	if stats.Requests >= opts.MinRequests && stats.Requests > 0 {
+		if stats.ErrorRate > opts.MaxErrorRate {
+			return decisionRollback, fmt.Sprintf("error rate %.2f%% is above %.2f%%", stats.ErrorRate, opts.MaxErrorRate)
+		}
+		if opts.MaxLatency > 0 && stats.Latency > float64(opts.MaxLatency.Milliseconds()) {
+			return decisionRollback, fmt.Sprintf("average latency %.0fms is above %dms",
+				stats.Latency, opts.MaxLatency.Milliseconds())
+		}
+	}
+	if !windowDone {
+		return decisionContinue, "window not elapsed"
+	}
+	if stats.Requests < opts.MinRequests {
+		return decisionRollback, fmt.Sprintf("%d requests, fewer than the minimum of %d", stats.Requests, opts.MinRequests)
+	}
+	return decisionPromote, "error rate and latency within thresholds"
+}
+
+// canaryStats returns the analytics of the canary proxy since a time
+func canaryStats(environment string, name string, since time.Time) (stats CanaryStats, err error) {
+	respBody, err := analytics.Stats(analytics.Query{
+		Environment: environment,
+		Dimensions:  []string{"apiproxy"},
+		Select:      []string{canaryStatsQuery},
+		Filter:      fmt.Sprintf("(apiproxy eq '%s')", name),
+		// the stats API has a granularity of one minute
+		TimeRange: analytics.TimeRange(time.Since(since)+time.Minute, time.Now()),
+	})
+	if err != nil {
+		return stats, err
+	}
+	return parseCanaryStats(respBody, name)
+}
+
+func parseCanaryStats(respBody []byte, name string) (stats CanaryStats, err error) {
+	t, err := analytics.ToTable(respBody, []string{"apiproxy"})
+	if err != nil {
+		return stats, err
+	}
+	for _, row := range t.Rows {
+		if row[0] != name {
+			continue
+		}
+		for i, metric := range t.Header {
+			value, _ := strconv.ParseFloat(row[i], 64)
+			switch metric {
+			case "sum(message_count)":
+				stats.Requests += int(value)
+			case "sum(is_error)":
+				stats.Errors += int(value)
+			case "avg(total_response_time)":
+				stats.Latency = value
+			}
+		}
+	}
+	if stats.Requests > 0 {
+		stats.ErrorRate = float64(stats.Errors) * 100 / float64(stats.Requests)
+	}
+	return stats, nil
+}
+
+// promote deploys the revision to the environment, and the previous revision again if the deployment fails
+func (r *rollout) promote() error {
+	apiclient.SetApigeeEnv(r.opts.Environment)
+	_, err := DeployProxy(r.opts.Name, r.opts.Revision, true, true, false, r.opts.ServiceAccountName)
+	if err == nil {
+		err = Wait(r.opts.Name, r.opts.Revision, r.opts.WaitTimeout)
+	}
+	if err == nil {
+		r.record(RolloutEntry{Step: "promote", Decision: decisionPromote,
+			Message: fmt.Sprintf("revision %d deployed to %s", r.opts.Revision, r.opts.Environment)})
+		return nil
+	}
+	r.record(RolloutEntry{Step: "promote", Decision: decisionRollback, Message: err.Error()})
+	if r.previous == -1 {
+		return err
+	}
+	if _, rerr := DeployProxy(r.opts.Name, r.previous, true, true, false, r.opts.ServiceAccountName); rerr != nil {
+		return fmt.Errorf("%w; redeploying revision %d failed: %w", err, r.previous, rerr)
+	}
+	r.record(RolloutEntry{Step: "rollback", Decision: decisionRollback,
+		Message: fmt.Sprintf("revision %d deployed again to %s", r.previous, r.opts.Environment)})
+	return err
+}
+
+// removeCanary undeploys the canary, and deletes the canary proxy of a canary base path
+func (r *rollout) removeCanary() {
+	if r.canaryRevision <= 0 {
+		return
+	}
+	apiclient.SetApigeeEnv(r.canaryEnv)
+	if _, err := UndeployProxy(r.canaryName, r.canaryRevision, false); err != nil && !apiclient.IsNotFound(err) {
+		r.record(RolloutEntry{Step: "cleanup", Message: "error undeploying the canary: " + err.Error()})
+	}
+	if r.canaryName != r.opts.Name {
+		if _, err := DeleteProxy(r.canaryName); err != nil {
+			r.record(RolloutEntry{Step: "cleanup", Message: "error deleting the canary proxy: " + err.Error()})
+		}
+	}
+	r.canaryRevision = 0
+	r.record(RolloutEntry{Step: "cleanup", Message: fmt.Sprintf("canary %s removed from %s", r.canaryName, r.canaryEnv)})
+}
+
+func (r *rollout) fail(step string, err error) error {
+	r.record(RolloutEntry{Step: step, Decision: decisionRollback, Message: err.Error()})
+	return err
+}
+
+// record appends an entry to the rollout log and logs it
+func (r *rollout) record(e RolloutEntry) {
+	e.Time = time.Now().UTC().Format(time.RFC3339)
+	line, _ := json.Marshal(e)
+	if _, err := r.log.Write(append(line, '\n')); err != nil {
+		clilog.Warning.Printf("error writing the rollout log: %v\n", err)
+	}
+	if e.Decision != "" {
+		clilog.Info.Printf("%s %s: %s: %s\n", e.Time, e.Step, e.Decision, e.Message)
+	} else {
+		clilog.Info.Printf("%s %s: %s\n", e.Time, e.Step, e.Message)
+	}
+}
+
+// deployedRevision returns the revision of the proxy deployed to the environment, -1 if none
+func deployedRevision(name string) (int, error) {
+	respBody, err := ListProxyDeploymentsForEnv(name)
+	if err != nil {
+		if apiclient.IsNotFound(err) {
+			return -1, nil
+		}
+		return -1, err
+	}
+	deployments := struct {
+		Deployments []struct {
+			Revision string `json:"revision,omitempty"`
+		} `json:"deployments,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &deployments); err != nil {
+		return -1, err
+	}
+	if len(deployments.Deployments) == 0 {
+		return -1, nil
+	}
+	return strconv.Atoi(deployments.Deployments[0].Revision)
+}
@@ -0,0 +1,153 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/clienttest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	proxytypes "internal/bundlegen/common"
+)
+
+func TestDecide(t *testing.T) {
+	opts := RolloutOptions{MaxErrorRate: 5, MaxLatency: 500 * time.Millisecond, MinRequests: 10}
+	tests := []struct {
+		stats      CanaryStats
+		windowDone bool
+		want       string
+	}{
+		{CanaryStats{Requests: 100, ErrorRate: 10}, false, decisionRollback},
+		{CanaryStats{Requests: 100, Latency: 800}, false, decisionRollback},
+		{CanaryStats{Requests: 5, ErrorRate: 100}, false, decisionContinue},
+		{CanaryStats{Requests: 100, ErrorRate: 1, Latency: 100}, false, decisionContinue},
+		{CanaryStats{Requests: 100, ErrorRate: 1, Latency: 100}, true, decisionPromote},
+		{CanaryStats{Requests: 5}, true, decisionRollback},
+	}
+	for i, test := range tests {
+		if got, reason := Decide(test.stats, opts, test.windowDone); got != test.want {
+			t.Errorf("%d: got %s (%s), want %s", i, got, reason, test.want)
+		}
+	}
+}
+
+func TestParseCanaryStats(t *testing.T) {
+	respBody := []byte(`{"environments":[{"name":"test","dimensions":[{"name":"orders-canary","metrics":[
+		{"name":"sum(message_count)","values":["200.0"]},
+		{"name":"sum(is_error)","values":["4.0"]},
+		{"name":"avg(total_response_time)","values":["123.5"]}]}]}]}`)
+	stats, err := parseCanaryStats(respBody, "orders-canary")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := CanaryStats{Requests: 200, Errors: 4, ErrorRate: 2, Latency: 123.5}
+	if stats != want {
+		t.Errorf("got %+v, want %+v", stats, want)
+	}
+}
+
+func TestWriteCanaryBundle(t *testing.T) {
+	b := proxytypes.Bundle{Root: "apiproxy", Files: map[string][]byte{
+		"apiproxy/orders.xml": []byte(`<APIProxy name="orders"/>`),
+		"apiproxy/proxies/default.xml": []byte(`<ProxyEndpoint name="default">
+  <HTTPProxyConnection><BasePath> /orders </BasePath></HTTPProxyConnection>
+</ProxyEndpoint>`),
+	}}
+	fileName := filepath.Join(t.TempDir(), "orders-canary.zip")
+	if err := writeCanaryBundle(b, "/canary", fileName); err != nil {
+		t.Fatal(err)
+	}
+	canary, err := proxytypes.ReadBundle(fileName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<ProxyEndpoint name="default">
+  <HTTPProxyConnection><BasePath>/canary/orders</BasePath></HTTPProxyConnection>
+</ProxyEndpoint>`
+	if got := string(canary.Files["apiproxy/proxies/default.xml"]); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestRollout(t *testing.T) {
+	if clienttest.UseRealOrg() {
+		t.Skip("deploys and deletes proxies in the org")
+	}
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer func(d time.Duration) { interval = d }(interval)
+	interval = time.Millisecond
+
+	const name = "rollout-orders"
+	stats := func(requests int, errors int) []byte {
+		return []byte(fmt.Sprintf(`{"environments":[{"name":"%s","dimensions":[{"name":"%s","metrics":[
+			{"name":"sum(message_count)","values":["%d.0"]},
+			{"name":"sum(is_error)","values":["%d.0"]},
+			{"name":"avg(total_response_time)","values":["100.0"]}]}]}]}`,
+			apiclient.GetApigeeEnv(), name+canarySuffix, requests, errors))
+	}
+	if _, err := CreateProxy(name, path.Join(cliPath, testFolder, "test_proxy.zip"), ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	logFile := filepath.Join(t.TempDir(), "rollout.log")
+	opts := RolloutOptions{
+		Name:           name,
+		Revision:       1,
+		Environment:    apiclient.GetApigeeEnv(),
+		CanaryBasePath: "/canary",
+		Window:         time.Millisecond,
+		Interval:       time.Millisecond,
+		MaxErrorRate:   5,
+		MinRequests:    10,
+		LogFile:        logFile,
+	}
+
+	clienttest.GetFakeApigee().SetStats(opts.Environment, stats(200, 50))
+	if err := Rollout(opts); err == nil || !strings.Contains(err.Error(), "rolled back") {
+		t.Fatalf("expected a rollback, got %v", err)
+	}
+	if revision, err := deployedRevision(name); err != nil || revision != -1 {
+		t.Fatalf("expected no deployed revision after the rollback, got %d %v", revision, err)
+	}
+
+	clienttest.GetFakeApigee().SetStats(opts.Environment, stats(200, 2))
+	if err := Rollout(opts); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if revision, err := deployedRevision(name); err != nil || revision != 1 {
+		t.Fatalf("expected revision 1 to be deployed, got %d %v", revision, err)
+	}
+	if _, err := GetProxy(name+canarySuffix, -1); !apiclient.IsNotFound(err) {
+		t.Fatalf("expected the canary proxy to be deleted, got %v", err)
+	}
+
+	content, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	for _, step := range []string{`"step":"canary"`, `"step":"rollback"`, `"step":"promote"`, `"step":"cleanup"`, `"step":"done"`} {
+		if !strings.Contains(string(content), step) {
+			t.Errorf("expected %s in the rollout log %s", step, content)
+		}
+	}
+}
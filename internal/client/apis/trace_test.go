@@ -20,11 +20,11 @@ import (
 )
 
 func TestListTracceSession(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := CreateProxy(proxyName, ""); err != nil {
+	if _, err := CreateProxy(proxyName, "", ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 	if _, err := DeployProxy(proxyName, 1, false, false, false, ""); err != nil {
@@ -36,7 +36,7 @@ func TestListTracceSession(t *testing.T) {
 }
 
 func TestCreateTraceSession(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -46,7 +46,7 @@ func TestCreateTraceSession(t *testing.T) {
 }
 
 func TestCleanupTrace(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -390,10 +390,10 @@ func ImportAllApps(conn int, filePath string) (err error) {
 	clilog.Info.Printf("Found %d apps in the file\n", len(appgrpapps))
 	clilog.Info.Printf("Create apps with %d connections\n", conn)
 
-	// flatten the structure
+	// flatten the structure, the file holds the list of apps of each appgroup
 	a := []appgroupapp{}
 	for _, aga := range appgrpapps {
-		a = append(a, aga[0])
+		a = append(a, aga...)
 	}
 
 	jobChan := make(chan appgroupapp)
@@ -447,6 +447,10 @@ func importAllAppGroupApps(wg *sync.WaitGroup, jobs <-chan appgroupapp, errs cha
 		}
 		// 1. Create the app
 		err = createAppNoKey(job.AppGroup, job.Name, "-1", "", nil, nil, nil)
+		if apiclient.IsConflict(err) {
+			clilog.Warning.Printf("App %s in AppGroup %s already exists. Updates to app is not currently supported", job.Name, job.AppGroup)
+			continue
+		}
 		if err != nil {
 			errs <- err
 			continue
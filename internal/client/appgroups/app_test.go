@@ -18,6 +18,7 @@ import (
 	"internal/client/clienttest"
 	"internal/client/products"
 	"os"
+	"path"
 	"testing"
 )
 
@@ -33,7 +34,7 @@ func TestCreateApp(t *testing.T) {
 	attrs := map[string]string{
 		"test": "test",
 	}
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -48,7 +49,7 @@ func TestCreateApp(t *testing.T) {
 }
 
 func TestGetApp(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -58,7 +59,7 @@ func TestGetApp(t *testing.T) {
 }
 
 func TestListApps(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -68,7 +69,7 @@ func TestListApps(t *testing.T) {
 }
 
 func TestManageApp(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -85,7 +86,7 @@ func TestUpdateApp(t *testing.T) {
 	attrs := map[string]string{
 		"test": "update",
 	}
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -96,7 +97,7 @@ func TestUpdateApp(t *testing.T) {
 }
 
 func TestExportApps(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -105,8 +106,32 @@ func TestExportApps(t *testing.T) {
 	}
 }
 
+func TestImportAllApps(t *testing.T) {
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
+		t.Fatalf("%v", err)
+	}
+	// the existing app is skipped and every app of the appgroup is imported
+	appsFile := path.Join(t.TempDir(), "appgroupsapp.json")
+	contents := `[[{"name":"` + appName + `","appGroup":"` + name + `"},
+		{"name":"test-import","appGroup":"` + name + `","credentials":[{"consumerKey":"import-key",
+		"consumerSecret":"import-secret","apiProducts":[{"apiproduct":"test"}]}]}]]`
+	if err := os.WriteFile(appsFile, []byte(contents), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err := ImportAllApps(1, appsFile); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := GetApp(name, "test-import"); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := DeleteApp(name, "test-import"); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
 func TestDeleteApp(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
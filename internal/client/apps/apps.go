@@ -312,7 +312,7 @@ func Export(conn int) (payload [][]byte, err error) {
 
 	start := 0
 
-	for i, end := 0, 0; i < numOfLoops; i++ {
+	for i, end := 0, 0; i < numOfLoops && !apiclient.Canceled(); i++ {
 		pwg.Add(1)
 		end = (i * conn) + conn
 		clilog.Debug.Printf("Exporting batch %d of apps\n", (i + 1))
@@ -321,7 +321,7 @@ func Export(conn int) (payload [][]byte, err error) {
 		pwg.Wait()
 	}
 
-	if remaining > 0 {
+	if remaining > 0 && !apiclient.Canceled() {
 		pwg.Add(1)
 		clilog.Debug.Printf("Exporting remaining %d apps\n", remaining)
 		go batchExport(entities.Apps[start:numEntities], entityType, &pwg, &mu)
@@ -330,6 +330,9 @@ func Export(conn int) (payload [][]byte, err error) {
 	payload = make([][]byte, len(apiclient.GetEntityPayloadList()))
 	copy(payload, apiclient.GetEntityPayloadList())
 	apiclient.ClearEntityPayloadList()
+	if err = apiclient.GetContext().Err(); err != nil {
+		return payload, fmt.Errorf("app export interrupted: %w", err)
+	}
 	return payload, nil
 }
 
@@ -356,7 +359,7 @@ func Import(conn int, filePath string, developersFilePath string) error {
 
 	start := 0
 
-	for i, end := 0, 0; i < numOfLoops; i++ {
+	for i, end := 0, 0; i < numOfLoops && !apiclient.Canceled(); i++ {
 		pwg.Add(1)
 		end = (i * conn) + conn
 		clilog.Debug.Printf("Creating batch %d of apps\n", (i + 1))
@@ -365,13 +368,16 @@ func Import(conn int, filePath string, developersFilePath string) error {
 		pwg.Wait()
 	}
 
-	if remaining > 0 {
+	if remaining > 0 && !apiclient.Canceled() {
 		pwg.Add(1)
 		clilog.Debug.Printf("Creating remaining %d apps\n", remaining)
 		go batchImport(entities[start:numEntities], developerEntities, &pwg)
 		pwg.Wait()
 	}
 
+	if err = apiclient.GetContext().Err(); err != nil {
+		return fmt.Errorf("app import interrupted: %w", err)
+	}
 	return nil
 }
 
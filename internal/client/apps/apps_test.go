@@ -35,7 +35,7 @@ func TestCreate(t *testing.T) {
 	var respBody []byte
 	var respJSONMap map[string]interface{}
 	var err error
-	if err = clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err = clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -51,7 +51,7 @@ func TestCreate(t *testing.T) {
 	if err = json.Unmarshal(respBody, &respJSONMap); err != nil {
 		t.Fatalf("%v", err)
 	}
-	devID = respJSONMap["id"].(string)
+	devID = respJSONMap["developerId"].(string)
 
 	createProduct(t)
 
@@ -66,14 +66,14 @@ func TestCreate(t *testing.T) {
 	if err = json.Unmarshal(respBody, &respJSONMap); err != nil {
 		t.Fatalf("%v", err)
 	}
-	appID = respJSONMap["id"].(string)
+	appID = respJSONMap["appId"].(string)
 	if appID == "" {
 		t.Fatalf("%v", fmt.Errorf("unable to find appId"))
 	}
 }
 
 func TestGet(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -83,7 +83,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestSearch(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -93,7 +93,7 @@ func TestSearch(t *testing.T) {
 }
 
 func TestManage(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -103,7 +103,7 @@ func TestManage(t *testing.T) {
 }
 
 func TestList(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -113,7 +113,7 @@ func TestList(t *testing.T) {
 }
 
 func TestListApps(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -123,7 +123,7 @@ func TestListApps(t *testing.T) {
 }
 
 func TestGenerateKey(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -137,7 +137,7 @@ func TestGenerateKey(t *testing.T) {
 }
 
 func TestExport(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -147,7 +147,7 @@ func TestExport(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -25,44 +25,44 @@ func TestCreateKey(t *testing.T) {
 	attrs := map[string]string{
 		"test": "test",
 	}
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
 
 	TestCreate(t)
 
-	if _, err := CreateKey(name, appID, "key1", "key1-secret", apiProducts, scopes, "-1", attrs); err != nil {
+	if _, err := CreateKey(email, appID, "key1", "key1-secret", apiProducts, scopes, "-1", attrs); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestGetKey(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := GetKey(name, appID, "key1"); err != nil {
+	if _, err := GetKey(email, appID, "key1"); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestManageKey(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := ManageKey(name, appID, "key1", "approve"); err != nil {
+	if _, err := ManageKey(email, appID, "key1", "approve", ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestDeleteKey(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := DeleteKey(name, appID, "key1"); err != nil {
+	if _, err := DeleteKey(email, appID, "key1"); err != nil {
 		t.Fatalf("%v", err)
 	}
 	TestDelete(t)
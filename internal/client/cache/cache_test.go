@@ -20,7 +20,7 @@ import (
 )
 
 func TestList(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -18,6 +18,10 @@ import (
 	"fmt"
 	"internal/apiclient"
 	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
 )
 
 type TestRequirements uint8
@@ -29,9 +33,99 @@ const (
 	SITEID_NOT_REQD
 	CLIPATH_REQD
 	CLIPATH_NOT_REQD
+	REGION_REQD
 )
 
-func TestSetup(envReqd TestRequirements, siteIdReqd TestRequirements, cliPathReqd TestRequirements) (err error) {
+const (
+	fakeOrg   = "fake-org"
+	fakeEnv   = "fake-env"
+	fakeToken = "fake-token"
+)
+
+var (
+	fakes   = make(map[string]*FakeApigee)
+	fakesMu sync.Mutex
+)
+
+// UseRealOrg returns true when the tests must run against a real Apigee org
+func UseRealOrg() bool {
+	return os.Getenv("APIGEECLI_TEST_REAL_ORG") == "true"
+}
+
+// GetFakeApigee returns the fake control plane of the calling test file. The tests of a
+// file run in sequence and share its state; the tests of another file start from an empty org
+func GetFakeApigee() *FakeApigee {
+	suite := testFile()
+	fakesMu.Lock()
+	defer fakesMu.Unlock()
+	f, ok := fakes[suite]
+	if !ok {
+		f = NewFakeApigee(fakeOrg, fakeEnv)
+		fakes[suite] = f
+	}
+	return f
+}
+
+// testFile returns the file of the top level test, ex: keys_test.go when TestCreateKey
+// calls TestCreateApp of app_test.go
+func testFile() string {
+	pc := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pc[:runtime.Callers(2, pc)])
+	file := ""
+	for {
+		frame, more := frames.Next()
+		if strings.HasSuffix(frame.File, "_test.go") {
+			file = frame.File
+		}
+		if !more {
+			return file
+		}
+	}
+}
+
+// ChdirTemp moves the rest of the test to a temporary folder, for the clients
+// that download files to the current folder
+func ChdirTemp(t testing.TB) {
+	t.Helper()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = os.Chdir(t.TempDir()); err != nil {
+		t.Fatalf("%v", err)
+	}
+	t.Cleanup(func() {
+		_ = os.Chdir(wd)
+	})
+}
+
+// TestSetup points the client at the fake control plane or at the org of APIGEE_ORG when
+// APIGEECLI_TEST_REAL_ORG is set. The portal tests and the API hub tests, with REGION_REQD,
+// are skipped when running against the fake or without APIGEE_SITEID and APIGEE_REGION
+func TestSetup(t testing.TB, envReqd TestRequirements, siteIdReqd TestRequirements,
+	cliPathReqd TestRequirements, otherReqd ...TestRequirements,
+) (err error) {
+	t.Helper()
+	if siteIdReqd == SITEID_REQD {
+		if !UseRealOrg() {
+			t.Skip("the portal is not served by the fake control plane")
+		}
+		if os.Getenv("APIGEE_SITEID") == "" {
+			t.Skip("APIGEE_SITEID not set")
+		}
+	}
+	for _, reqd := range otherReqd {
+		if reqd != REGION_REQD {
+			continue
+		}
+		if !UseRealOrg() {
+			t.Skip("API hub is not served by the fake control plane")
+		}
+		if os.Getenv("APIGEE_REGION") == "" {
+			t.Skip("APIGEE_REGION not set")
+		}
+	}
+
 	apiclient.NewApigeeClient(apiclient.ApigeeClientOptions{
 		TokenCheck:  true,
 		PrintOutput: true,
@@ -40,6 +134,10 @@ func TestSetup(envReqd TestRequirements, siteIdReqd TestRequirements, cliPathReq
 		SkipCache:   false,
 	})
 
+	if !UseRealOrg() {
+		return fakeSetup(cliPathReqd)
+	}
+
 	org := os.Getenv("APIGEE_ORG")
 	if err = apiclient.SetApigeeOrg(org); err != nil {
 		return fmt.Errorf("APIGEE_ORG not set")
@@ -54,13 +152,6 @@ func TestSetup(envReqd TestRequirements, siteIdReqd TestRequirements, cliPathReq
 		apiclient.SetApigeeEnv(env)
 	}
 
-	if siteIdReqd == SITEID_REQD {
-		siteId := os.Getenv("APIGEE_SITEID")
-		if siteId == "" {
-			return fmt.Errorf("APIGEE_SITEID not set")
-		}
-	}
-
 	region := os.Getenv("APIGEE_REGION")
 	if region != "" {
 		apiclient.SetRegion(region)
@@ -87,3 +178,21 @@ func TestSetup(envReqd TestRequirements, siteIdReqd TestRequirements, cliPathReq
 
 	return nil
 }
+
+// fakeSetup points the client at the in-process fake control plane
+func fakeSetup(cliPathReqd TestRequirements) (err error) {
+	f := GetFakeApigee()
+	apiclient.SetApigeeBaseURL(f.URL())
+	if err = apiclient.SetApigeeOrg(f.Org); err != nil {
+		return err
+	}
+	apiclient.SetProjectID(f.Org)
+	apiclient.SetApigeeEnv(f.Env)
+	apiclient.SetApigeeToken(fakeToken)
+
+	if cliPathReqd == CLIPATH_REQD && os.Getenv("APIGEECLI_PATH") == "" {
+		return fmt.Errorf("APIGEECLI_PATH not set")
+	}
+
+	return nil
+}
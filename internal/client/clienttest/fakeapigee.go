@@ -0,0 +1,1281 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clienttest
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// FakeApigee is an in-process fake of the Apigee v1 management API. It keeps
+// orgs, environments, proxies, sharedflows, products, developers, apps, kvms,
+// target servers, flowhooks, resource files and deployments in memory. Other
+// collections are stored generically, keyed by their name.
+type FakeApigee struct {
+	Org string
+	Env string
+
+	org         map[string]interface{}
+	server      *httptest.Server
+	mu          sync.Mutex
+	collections map[string]*collection
+	bundles     map[string]*bundle
+	deployments []*deployment
+	apps        map[string]string                 // appId to the developer app path
+	flowhooks   map[string]map[string]interface{} // env/flowHookPoint to the attachment
+	resources   map[string][]byte                 // env/type/name to the resource file
+	stats       map[string][]byte                 // env to the response of the stats API
+	counter     int
+}
+
+type collection struct {
+	names []string
+	items map[string]map[string]interface{}
+}
+
+type bundle struct {
+	name      string
+	revisions []*bundleRevision
+	labels    map[string]interface{}
+	createdAt int64
+}
+
+type bundleRevision struct {
+	revision       int
+	archive        []byte
+	createdAt      int64
+	lastModifiedAt int64
+}
+
+type deployment struct {
+	env            string
+	kind           string // apis or sharedflows
+	name           string
+	revision       int
+	startTime      int64
+	serviceAccount string
+}
+
+// listKeys are the names of the array returned when listing a collection
+var listKeys = map[string]string{
+	"apiproducts":    "apiProduct",
+	"developers":     "developer",
+	"apps":           "app",
+	"entries":        "keyValueEntries",
+	"envgroups":      "environmentGroups",
+	"datacollectors": "dataCollectors",
+	"attachments":    "environmentGroupAttachments",
+	"appgroups":      "appGroups",
+	"apis":           "proxies",
+	"sharedflows":    "sharedFlows",
+	"reports":        "qualifier",
+}
+
+// namesOnly are collections that list as an array of names
+var namesOnly = map[string]bool{
+	"environments":  true,
+	"targetservers": true,
+	"keyvaluemaps":  true,
+	"keystores":     true,
+	"aliases":       true,
+	"references":    true,
+}
+
+// flowhookPoints are the flowhooks of every environment
+var flowhookPoints = []string{"PreProxyFlowHook", "PostProxyFlowHook", "PreTargetFlowHook", "PostTargetFlowHook"}
+
+// singletons are sub-resources with a single instance, for ex: an environment debugmask
+var singletons = map[string]bool{
+	"debugmask":             true,
+	"traceConfig":           true,
+	"securityActionsConfig": true,
+	"addonsConfig":          true,
+}
+
+// generatedNames are collections where the server assigns the name on create
+var generatedNames = map[string]bool{
+	"debugsessions": true,
+	"attachments":   true,
+	"reports":       true,
+}
+
+// lroCollections are collections that return a long running operation
+var lroCollections = map[string]bool{
+	"environments": true,
+	"envgroups":    true,
+	"attachments":  true,
+	"instances":    true,
+}
+
+// NewFakeApigee starts a fake control plane with one org and one environment
+func NewFakeApigee(org string, env string) *FakeApigee {
+	f := &FakeApigee{
+		Org:         org,
+		Env:         env,
+		collections: make(map[string]*collection),
+		bundles:     make(map[string]*bundle),
+		apps:        make(map[string]string),
+		flowhooks:   make(map[string]map[string]interface{}),
+		resources:   make(map[string][]byte),
+		stats:       make(map[string][]byte),
+	}
+	f.org = map[string]interface{}{
+		"name":             org,
+		"projectId":        org,
+		"runtimeType":      "CLOUD",
+		"state":            "ACTIVE",
+		"subscriptionType": "PAID",
+		"createdAt":        strconv.FormatInt(now(), 10),
+		"properties":       map[string]interface{}{},
+	}
+	f.put("environments", env, map[string]interface{}{
+		"name":            env,
+		"state":           "ACTIVE",
+		"deploymentType":  "PROXY",
+		"apiProxyType":    "PROGRAMMABLE",
+		"type":            "COMPREHENSIVE",
+		"createdAt":       strconv.FormatInt(now(), 10),
+		"lastModifiedAt":  strconv.FormatInt(now(), 10),
+		"properties":      map[string]interface{}{},
+		"forwardProxyUri": "",
+	})
+	f.server = httptest.NewServer(f)
+	return f
+}
+
+// URL returns the base url to use with apiclient.SetApigeeBaseURL
+func (f *FakeApigee) URL() string {
+	return f.server.URL + "/v1/organizations/"
+}
+
+// Close stops the fake control plane
+func (f *FakeApigee) Close() {
+	f.server.Close()
+}
+
+// SetStats sets the response of the stats API of an environment, whatever the query
+func (f *FakeApigee) SetStats(env string, respBody []byte) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stats[env] = respBody
+}
+
+// ServeHTTP routes a management API request
+func (f *FakeApigee) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "request is missing a valid access token")
+		return
+	}
+
+	p := strings.Trim(strings.TrimPrefix(r.URL.EscapedPath(), "/v1/organizations"), "/")
+	if p == "" {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"organizations": []interface{}{
+				map[string]interface{}{"organization": f.Org, "projectId": f.Org},
+			},
+		})
+		return
+	}
+
+	segs := strings.Split(p, "/")
+	for i, seg := range segs {
+		// some clients escape names twice, for ex: developer emails
+		for strings.Contains(seg, "%") {
+			unescaped, err := url.PathUnescape(seg)
+			if err != nil || unescaped == seg {
+				break
+			}
+			seg = unescaped
+		}
+		segs[i] = seg
+	}
+	if segs[0] != f.Org {
+		writeError(w, http.StatusForbidden, fmt.Sprintf("permission denied on organization %s", segs[0]))
+		return
+	}
+	rest := f.resolveIDs(segs[1:])
+
+	switch {
+	case len(rest) == 0:
+		f.handleOrg(w, r)
+	case strings.Contains(rest[len(rest)-1], ":"):
+		f.handleCustomMethod(w, r, rest)
+	case isDeploymentPath(rest):
+		f.handleDeployments(w, r, rest)
+	case isBundlePath(rest):
+		f.handleBundles(w, r, rest)
+	case rest[0] == "apps" && len(rest) <= 2:
+		f.handleApps(w, r, rest)
+	case len(rest) >= 5 && (rest[0] == "developers" || rest[0] == "appgroups") && rest[2] == "apps" && rest[4] == "keys":
+		f.handleAppKeys(w, r, rest)
+	case len(rest) >= 3 && rest[0] == "environments" && rest[2] == "flowhooks":
+		f.handleFlowhooks(w, r, rest)
+	case len(rest) >= 3 && rest[0] == "environments" && rest[2] == "resourcefiles":
+		f.handleResourceFiles(w, r, rest)
+	case len(rest) == 4 && rest[0] == "environments" && rest[2] == "stats" && r.Method == http.MethodGet:
+		respBody, ok := f.stats[rest[1]]
+		if !ok {
+			writeJSON(w, http.StatusOK, map[string]interface{}{
+				"environments": []interface{}{map[string]interface{}{"name": rest[1]}},
+			})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(respBody)
+	case len(rest) == 3 && rest[0] == "environments" && rest[2] == "deployedConfig":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"name":       fmt.Sprintf("organizations/%s/environments/%s/deployedConfig", f.Org, rest[1]),
+			"revisionId": "1",
+			"proxies":    f.deployedConfig(rest[1]),
+		})
+	case singletons[rest[len(rest)-1]]:
+		f.handleSingleton(w, r, strings.Join(rest, "/"))
+	default:
+		f.handleGeneric(w, r, rest)
+	}
+}
+
+// resolveIDs replaces developer and app ids in a path with their email and name
+func (f *FakeApigee) resolveIDs(rest []string) []string {
+	if len(rest) < 2 || rest[0] != "developers" {
+		return rest
+	}
+	if c, ok := f.collections["developers"]; ok && c.items[rest[1]] == nil {
+		for email, developer := range c.items {
+			if developer["developerId"] == rest[1] {
+				rest[1] = email
+			}
+		}
+	}
+	if len(rest) < 4 || rest[2] != "apps" {
+		return rest
+	}
+	if c, ok := f.collections[strings.Join(rest[:3], "/")]; ok && c.items[rest[3]] == nil {
+		for name, app := range c.items {
+			if app["appId"] == rest[3] {
+				rest[3] = name
+			}
+		}
+	}
+	return rest
+}
+
+func (f *FakeApigee) handleSingleton(w http.ResponseWriter, r *http.Request, p string) {
+	i := strings.LastIndex(p, "/")
+	c := f.collections[p[:i]]
+	item := map[string]interface{}{}
+	if c != nil && c.items[p[i+1:]] != nil {
+		item = c.items[p[i+1:]]
+	}
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPatch:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if r.Method == http.MethodPut {
+			item = map[string]interface{}{}
+		}
+		for k, v := range body {
+			item[k] = v
+		}
+		item["name"] = fmt.Sprintf("organizations/%s/%s", f.Org, p)
+		f.put(p[:i], p[i+1:], item)
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		return
+	}
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (f *FakeApigee) handleOrg(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for k, v := range body {
+			f.org[k] = v
+		}
+		f.org["name"] = f.Org
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		return
+	}
+	f.org["environments"] = f.names("environments")
+	writeJSON(w, http.StatusOK, f.org)
+}
+
+func (f *FakeApigee) handleCustomMethod(w http.ResponseWriter, r *http.Request, rest []string) {
+	last := rest[len(rest)-1]
+	verb := last[strings.Index(last, ":")+1:]
+	switch verb {
+	case "generateDeployChangeReport", "generateUndeployChangeReport":
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	case "move":
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+	default:
+		writeError(w, http.StatusNotImplemented, fmt.Sprintf("%s is not supported by the fake control plane", verb))
+	}
+}
+
+// isBundlePath matches apis and sharedflows and their revisions
+func isBundlePath(rest []string) bool {
+	if rest[0] != "apis" && rest[0] != "sharedflows" {
+		return false
+	}
+	return len(rest) <= 2 || rest[2] == "revisions"
+}
+
+func (f *FakeApigee) handleBundles(w http.ResponseWriter, r *http.Request, rest []string) {
+	kind := rest[0]
+	switch len(rest) {
+	case 1:
+		switch r.Method {
+		case http.MethodGet:
+			list := []interface{}{}
+			for _, b := range f.sortedBundles(kind) {
+				list = append(list, map[string]interface{}{"name": b.name, "revision": b.revisionNames()})
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{listKeys[kind]: list})
+		case http.MethodPost:
+			f.importBundle(w, r, kind)
+		default:
+			writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		}
+		return
+	case 2:
+		b, ok := f.bundles[kind+"/"+rest[1]]
+		if !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s does not exist", kind, rest[1]))
+			return
+		}
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, b.toJSON())
+		case http.MethodPatch:
+			body, _ := readBody(r)
+			if labels, ok := body["labels"].(map[string]interface{}); ok {
+				b.labels = labels
+			}
+			writeJSON(w, http.StatusOK, b.toJSON())
+		case http.MethodDelete:
+			if f.isDeployed(kind, b.name, -1) {
+				writeError(w, http.StatusBadRequest, fmt.Sprintf("%s %s is deployed", kind, b.name))
+				return
+			}
+			delete(f.bundles, kind+"/"+rest[1])
+			writeJSON(w, http.StatusOK, b.toJSON())
+		default:
+			writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		}
+		return
+	}
+
+	b, ok := f.bundles[kind+"/"+rest[1]]
+	if !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s does not exist", kind, rest[1]))
+		return
+	}
+	if len(rest) == 3 {
+		writeJSON(w, http.StatusOK, b.revisionNames())
+		return
+	}
+	rev, err := strconv.Atoi(rest[3])
+	i := b.revisionIndex(rev)
+	if err != nil || i < 0 || len(rest) > 4 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("revision %s of %s does not exist", rest[3], b.name))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if r.URL.Query().Get("format") == "bundle" {
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write(b.revisions[i].archive)
+			return
+		}
+		writeJSON(w, http.StatusOK, b.revisionJSON(b.revisions[i]))
+	case http.MethodPost:
+		archive, err := readArchive(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		b.revisions[i].archive = archive
+		b.revisions[i].lastModifiedAt = now()
+		writeJSON(w, http.StatusOK, b.revisionJSON(b.revisions[i]))
+	case http.MethodDelete:
+		if f.isDeployed(kind, b.name, rev) {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("revision %d of %s is deployed", rev, b.name))
+			return
+		}
+		deleted := b.revisions[i]
+		b.revisions = append(b.revisions[:i], b.revisions[i+1:]...)
+		writeJSON(w, http.StatusOK, b.revisionJSON(deleted))
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+func (f *FakeApigee) importBundle(w http.ResponseWriter, r *http.Request, kind string) {
+	var name string
+	var archive []byte
+	var err error
+
+	if r.URL.Query().Get("action") == "import" {
+		name = r.URL.Query().Get("name")
+		if archive, err = readArchive(r); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+	} else {
+		body, _ := readBody(r)
+		name, _ = body["name"].(string)
+	}
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "name is required")
+		return
+	}
+
+	b, ok := f.bundles[kind+"/"+name]
+	if !ok {
+		b = &bundle{name: name, createdAt: now()}
+		f.bundles[kind+"/"+name] = b
+	}
+	rev := &bundleRevision{revision: b.latest() + 1, archive: archive, createdAt: now()}
+	rev.lastModifiedAt = rev.createdAt
+	b.revisions = append(b.revisions, rev)
+	writeJSON(w, http.StatusOK, b.revisionJSON(rev))
+}
+
+// readArchive reads a zip from a multipart form or the raw body
+func readArchive(r *http.Request) ([]byte, error) {
+	archive, err := readFile(r)
+	if err != nil {
+		return nil, err
+	}
+	if _, err = zip.NewReader(bytes.NewReader(archive), int64(len(archive))); err != nil {
+		return nil, fmt.Errorf("bundle is not a valid zip file: %v", err)
+	}
+	return archive, nil
+}
+
+// readFile reads the first file of a multipart form or the raw body
+func readFile(r *http.Request) (content []byte, err error) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/") {
+		return io.ReadAll(r.Body)
+	}
+	if err = r.ParseMultipartForm(32 << 20); err != nil {
+		return nil, err
+	}
+	for _, files := range r.MultipartForm.File {
+		file, err := files[0].Open()
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return io.ReadAll(file)
+	}
+	return nil, fmt.Errorf("the multipart form has no file")
+}
+
+func (f *FakeApigee) sortedBundles(kind string) []*bundle {
+	list := []*bundle{}
+	for key, b := range f.bundles {
+		if strings.HasPrefix(key, kind+"/") {
+			list = append(list, b)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].name < list[j].name })
+	return list
+}
+
+func (b *bundle) latest() int {
+	latest := 0
+	for _, rev := range b.revisions {
+		if rev.revision > latest {
+			latest = rev.revision
+		}
+	}
+	return latest
+}
+
+func (b *bundle) revisionIndex(revision int) int {
+	for i, rev := range b.revisions {
+		if rev.revision == revision {
+			return i
+		}
+	}
+	return -1
+}
+
+func (b *bundle) revisionNames() []string {
+	names := []string{}
+	for _, rev := range b.revisions {
+		names = append(names, strconv.Itoa(rev.revision))
+	}
+	return names
+}
+
+func (b *bundle) toJSON() map[string]interface{} {
+	lastModified := b.createdAt
+	if len(b.revisions) > 0 {
+		lastModified = b.revisions[len(b.revisions)-1].lastModifiedAt
+	}
+	m := map[string]interface{}{
+		"name":             b.name,
+		"revision":         b.revisionNames(),
+		"latestRevisionId": strconv.Itoa(b.latest()),
+		"metaData": map[string]interface{}{
+			"createdAt":      strconv.FormatInt(b.createdAt, 10),
+			"lastModifiedAt": strconv.FormatInt(lastModified, 10),
+			"subType":        "Proxy",
+		},
+	}
+	if b.labels != nil {
+		m["labels"] = b.labels
+	}
+	return m
+}
+
+func (b *bundle) revisionJSON(rev *bundleRevision) map[string]interface{} {
+	return map[string]interface{}{
+		"name":           b.name,
+		"revision":       strconv.Itoa(rev.revision),
+		"createdAt":      strconv.FormatInt(rev.createdAt, 10),
+		"lastModifiedAt": strconv.FormatInt(rev.lastModifiedAt, 10),
+		"contextInfo":    "Revision " + strconv.Itoa(rev.revision) + " of application " + b.name,
+		"type":           "Application",
+	}
+}
+
+// isDeploymentPath matches the deployment collections of an org, env, proxy or sharedflow
+func isDeploymentPath(rest []string) bool {
+	if rest[len(rest)-1] != "deployments" {
+		return false
+	}
+	switch len(rest) {
+	case 1:
+		return true
+	case 3:
+		return rest[0] == "environments" || rest[0] == "apis" || rest[0] == "sharedflows"
+	case 5, 7:
+		return rest[0] == "environments" || rest[2] == "revisions"
+	}
+	return false
+}
+
+func (f *FakeApigee) handleDeployments(w http.ResponseWriter, r *http.Request, rest []string) {
+	var env, kind, name string
+	revision := -1
+
+	switch len(rest) {
+	case 1:
+	case 3:
+		if rest[0] == "environments" {
+			env = rest[1]
+			kind = "apis"
+			if r.URL.Query().Get("sharedFlows") == "true" {
+				kind = "sharedflows"
+			}
+		} else {
+			kind, name = rest[0], rest[1]
+		}
+	case 5:
+		if rest[0] == "environments" {
+			env, kind, name = rest[1], rest[2], rest[3]
+		} else {
+			kind, name = rest[0], rest[1]
+			revision, _ = strconv.Atoi(rest[3])
+		}
+	case 7:
+		env, kind, name = rest[1], rest[2], rest[3]
+		revision, _ = strconv.Atoi(rest[5])
+	}
+
+	if len(rest) == 7 {
+		f.handleRevisionDeployment(w, r, env, kind, name, revision)
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		return
+	}
+	list := []interface{}{}
+	for _, d := range f.deployments {
+		if (env == "" || d.env == env) && (kind == "" || d.kind == kind) &&
+			(name == "" || d.name == name) && (revision == -1 || d.revision == revision) {
+			list = append(list, d.toJSON())
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"deployments": list})
+}
+
+func (f *FakeApigee) handleRevisionDeployment(w http.ResponseWriter, r *http.Request,
+	env string, kind string, name string, revision int,
+) {
+	if _, ok := f.collections["environments"].items[env]; !ok {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("environment %s does not exist", env))
+		return
+	}
+	b, ok := f.bundles[kind+"/"+name]
+	if !ok || b.revisionIndex(revision) < 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("revision %d of %s does not exist", revision, name))
+		return
+	}
+
+	i := -1
+	for j, d := range f.deployments {
+		if d.env == env && d.kind == kind && d.name == name {
+			i = j
+		}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if i < 0 || f.deployments[i].revision != revision {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("revision %d of %s is not deployed to %s", revision, name, env))
+			return
+		}
+		writeJSON(w, http.StatusOK, f.deployments[i].toJSON())
+	case http.MethodPost:
+		if i >= 0 && r.URL.Query().Get("override") != "true" && f.deployments[i].revision != revision {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("revision %d of %s is already deployed to %s",
+				f.deployments[i].revision, name, env))
+			return
+		}
+		d := &deployment{
+			env: env, kind: kind, name: name, revision: revision, startTime: now(),
+			serviceAccount: r.URL.Query().Get("serviceAccount"),
+		}
+		if i >= 0 {
+			f.deployments[i] = d
+		} else {
+			f.deployments = append(f.deployments, d)
+		}
+		writeJSON(w, http.StatusOK, d.toJSON())
+	case http.MethodDelete:
+		if i < 0 || f.deployments[i].revision != revision {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("revision %d of %s is not deployed to %s", revision, name, env))
+			return
+		}
+		d := f.deployments[i]
+		f.deployments = append(f.deployments[:i], f.deployments[i+1:]...)
+		writeJSON(w, http.StatusOK, d.toJSON())
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+func (f *FakeApigee) isDeployed(kind string, name string, revision int) bool {
+	for _, d := range f.deployments {
+		if d.kind == kind && d.name == name && (revision == -1 || d.revision == revision) {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *FakeApigee) deployedConfig(env string) []interface{} {
+	proxies := []interface{}{}
+	for _, d := range f.deployments {
+		if d.env == env && d.kind == "apis" {
+			proxies = append(proxies, map[string]interface{}{
+				"name": fmt.Sprintf("organizations/%s/apis/%s/revisions/%d", f.Org, d.name, d.revision),
+			})
+		}
+	}
+	return proxies
+}
+
+func (d *deployment) toJSON() map[string]interface{} {
+	m := map[string]interface{}{
+		"environment":     d.env,
+		"apiProxy":        d.name,
+		"revision":        strconv.Itoa(d.revision),
+		"deployStartTime": strconv.FormatInt(d.startTime, 10),
+		"state":           "READY",
+		"instances": []interface{}{
+			map[string]interface{}{
+				"instance": "fake-instance",
+				"deployedRevisions": []interface{}{
+					map[string]interface{}{"revision": strconv.Itoa(d.revision), "percentage": 100},
+				},
+			},
+		},
+	}
+	if d.serviceAccount != "" {
+		m["serviceAccount"] = d.serviceAccount
+	}
+	return m
+}
+
+// handleFlowhooks serves the flowhooks of an environment, which always has the four flowhook points
+func (f *FakeApigee) handleFlowhooks(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 3 {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+			return
+		}
+		writeJSON(w, http.StatusOK, flowhookPoints)
+		return
+	}
+
+	point := rest[3]
+	known := false
+	for _, p := range flowhookPoints {
+		known = known || p == point
+	}
+	if !known || len(rest) > 4 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("flowhook %s does not exist", point))
+		return
+	}
+	key := rest[1] + "/" + point
+	flowhook, attached := f.flowhooks[key]
+	if !attached {
+		flowhook = map[string]interface{}{"flowHookPoint": point, "continueOnError": false}
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, flowhook)
+	case http.MethodPut:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sharedFlow, _ := body["sharedFlow"].(string)
+		if _, ok := f.bundles["sharedflows/"+sharedFlow]; !ok {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("sharedflows %s does not exist", sharedFlow))
+			return
+		}
+		body["flowHookPoint"] = point
+		f.flowhooks[key] = body
+		writeJSON(w, http.StatusOK, body)
+	case http.MethodDelete:
+		if !attached {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("no sharedflow is attached to flowhook %s", point))
+			return
+		}
+		delete(f.flowhooks, key)
+		writeJSON(w, http.StatusOK, flowhook)
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+// handleResourceFiles serves the resource files of an environment, uploaded as multipart forms
+func (f *FakeApigee) handleResourceFiles(w http.ResponseWriter, r *http.Request, rest []string) {
+	env := rest[1]
+	if len(rest) <= 4 {
+		resourceType := r.URL.Query().Get("type")
+		if len(rest) == 4 {
+			resourceType = rest[3]
+		}
+		switch {
+		case r.Method == http.MethodGet:
+			prefix := env + "/"
+			if resourceType != "" {
+				prefix += resourceType + "/"
+			}
+			keys := []string{}
+			for key := range f.resources {
+				if strings.HasPrefix(key, prefix) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			list := []interface{}{}
+			for _, key := range keys {
+				parts := strings.Split(key, "/")
+				list = append(list, map[string]interface{}{"name": parts[2], "type": parts[1]})
+			}
+			writeJSON(w, http.StatusOK, map[string]interface{}{"resourceFile": list})
+		case r.Method == http.MethodPost && len(rest) == 3:
+			name := r.URL.Query().Get("name")
+			if name == "" || resourceType == "" {
+				writeError(w, http.StatusBadRequest, "name and type are required")
+				return
+			}
+			key := env + "/" + resourceType + "/" + name
+			if _, ok := f.resources[key]; ok {
+				writeError(w, http.StatusConflict, fmt.Sprintf("resource file %s already exists", name))
+				return
+			}
+			content, err := readFile(r)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			f.resources[key] = content
+			writeJSON(w, http.StatusOK, map[string]interface{}{"name": name, "type": resourceType})
+		default:
+			writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		}
+		return
+	}
+
+	resourceType, name := rest[3], rest[4]
+	key := env + "/" + resourceType + "/" + name
+	content, ok := f.resources[key]
+	if !ok || len(rest) > 5 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("resource file %s of type %s does not exist", name, resourceType))
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(content)
+	case http.MethodPut:
+		content, err := readFile(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		f.resources[key] = content
+		writeJSON(w, http.StatusOK, map[string]interface{}{"name": name, "type": resourceType})
+	case http.MethodDelete:
+		delete(f.resources, key)
+		writeJSON(w, http.StatusOK, map[string]interface{}{"name": name, "type": resourceType})
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+// handleApps serves the org level view of developer apps
+func (f *FakeApigee) handleApps(w http.ResponseWriter, r *http.Request, rest []string) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		return
+	}
+	if len(rest) == 2 {
+		app := f.appByID(rest[1])
+		if app == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("app %s does not exist", rest[1]))
+			return
+		}
+		writeJSON(w, http.StatusOK, app)
+		return
+	}
+	expand := r.URL.Query().Get("expand") == "true"
+	ids := make([]string, 0, len(f.apps))
+	for id := range f.apps {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	list := []interface{}{}
+	for _, id := range ids {
+		if expand {
+			list = append(list, f.appByID(id))
+		} else {
+			list = append(list, map[string]interface{}{"appId": id})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"app": list})
+}
+
+func (f *FakeApigee) appByID(id string) map[string]interface{} {
+	appPath, ok := f.apps[id]
+	if !ok {
+		return nil
+	}
+	i := strings.LastIndex(appPath, "/")
+	c, ok := f.collections[appPath[:i]]
+	if !ok {
+		return nil
+	}
+	return c.items[appPath[i+1:]]
+}
+
+// handleAppKeys serves the credentials of a developer or appgroup app
+func (f *FakeApigee) handleAppKeys(w http.ResponseWriter, r *http.Request, rest []string) {
+	c, ok := f.collections[strings.Join(rest[:3], "/")]
+	if !ok || c.items[rest[3]] == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("app %s does not exist", rest[3]))
+		return
+	}
+	app := c.items[rest[3]]
+	creds, _ := app["credentials"].([]interface{})
+
+	if len(rest) == 5 {
+		switch r.Method {
+		case http.MethodPost:
+			body, _ := readBody(r)
+			cred := newCredential(body["consumerKey"], body["consumerSecret"], nil)
+			app["credentials"] = append(creds, cred)
+			writeJSON(w, http.StatusCreated, cred)
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, creds)
+		default:
+			writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+		}
+		return
+	}
+
+	i := -1
+	for j, cred := range creds {
+		if cred.(map[string]interface{})["consumerKey"] == rest[5] {
+			i = j
+		}
+	}
+	if i < 0 {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("key %s does not exist", rest[5]))
+		return
+	}
+	cred := creds[i].(map[string]interface{})
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, cred)
+	case http.MethodDelete:
+		if len(rest) == 8 {
+			cred["apiProducts"] = removeProduct(cred["apiProducts"], rest[7])
+		} else {
+			app["credentials"] = append(creds[:i], creds[i+1:]...)
+		}
+		writeJSON(w, http.StatusOK, cred)
+	case http.MethodPost, http.MethodPut:
+		body, _ := readBody(r)
+		if action := r.URL.Query().Get("action"); action != "" {
+			if action == "revoke" {
+				cred["status"] = "revoked"
+			} else {
+				cred["status"] = "approved"
+			}
+		}
+		if products, ok := body["apiProducts"].([]interface{}); ok {
+			existing, _ := cred["apiProducts"].([]interface{})
+			for _, product := range products {
+				existing = append(existing, map[string]interface{}{"apiproduct": product, "status": "approved"})
+			}
+			cred["apiProducts"] = existing
+		}
+		writeJSON(w, http.StatusOK, cred)
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+func removeProduct(products interface{}, name string) []interface{} {
+	list, _ := products.([]interface{})
+	kept := []interface{}{}
+	for _, p := range list {
+		if p.(map[string]interface{})["apiproduct"] != name {
+			kept = append(kept, p)
+		}
+	}
+	return kept
+}
+
+func newCredential(key interface{}, secret interface{}, products interface{}) map[string]interface{} {
+	if key == nil || key == "" {
+		key = fmt.Sprintf("fake-key-%d", now())
+	}
+	if secret == nil || secret == "" {
+		secret = fmt.Sprintf("fake-secret-%d", now())
+	}
+	apiProducts := []interface{}{}
+	if list, ok := products.([]interface{}); ok {
+		for _, product := range list {
+			apiProducts = append(apiProducts, map[string]interface{}{"apiproduct": product, "status": "approved"})
+		}
+	}
+	return map[string]interface{}{
+		"consumerKey":    key,
+		"consumerSecret": secret,
+		"apiProducts":    apiProducts,
+		"status":         "approved",
+		"issuedAt":       strconv.FormatInt(now(), 10),
+		"expiresAt":      "-1",
+	}
+}
+
+// handleGeneric serves any collection; odd paths are collections and even paths are items
+func (f *FakeApigee) handleGeneric(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest)%2 == 1 {
+		f.handleCollection(w, r, strings.Join(rest, "/"), rest[len(rest)-1])
+		return
+	}
+
+	collPath := strings.Join(rest[:len(rest)-1], "/")
+	leaf := rest[len(rest)-2]
+	name := rest[len(rest)-1]
+	c := f.collections[collPath]
+
+	var item map[string]interface{}
+	if c != nil {
+		item = c.items[name]
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		if item == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s does not exist", leaf, name))
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	case http.MethodPut, http.MethodPatch, http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		if item == nil {
+			// debugmasks and the like are upserted
+			if r.Method != http.MethodPut {
+				writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s does not exist", leaf, name))
+				return
+			}
+			item = map[string]interface{}{}
+		}
+		if r.Method == http.MethodPut {
+			updated := map[string]interface{}{}
+			for _, k := range []string{"createdAt", "appId", "developerId", "credentials"} {
+				if v, ok := item[k]; ok {
+					updated[k] = v
+				}
+			}
+			item = updated
+		}
+		if action := r.URL.Query().Get("action"); action != "" {
+			item["status"] = map[string]string{"approve": "approved", "revoke": "revoked"}[action]
+			if item["status"] == "" {
+				item["status"] = action
+			}
+		}
+		for k, v := range body {
+			item[k] = v
+		}
+		item[idField(leaf)] = name
+		item["lastModifiedAt"] = strconv.FormatInt(now(), 10)
+		f.put(collPath, name, item)
+		writeJSON(w, http.StatusOK, item)
+	case http.MethodDelete:
+		if item == nil {
+			writeError(w, http.StatusNotFound, fmt.Sprintf("%s %s does not exist", leaf, name))
+			return
+		}
+		f.remove(collPath, name)
+		if appID, ok := item["appId"].(string); ok {
+			delete(f.apps, appID)
+		}
+		if lroCollections[leaf] {
+			writeJSON(w, http.StatusOK, f.operation(item))
+			return
+		}
+		writeJSON(w, http.StatusOK, item)
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+func (f *FakeApigee) handleCollection(w http.ResponseWriter, r *http.Request, collPath string, leaf string) {
+	switch r.Method {
+	case http.MethodGet:
+		items := []interface{}{}
+		if c, ok := f.collections[collPath]; ok {
+			for _, n := range c.names {
+				if namesOnly[leaf] {
+					items = append(items, n)
+				} else {
+					items = append(items, c.items[n])
+				}
+			}
+		}
+		if namesOnly[leaf] {
+			writeJSON(w, http.StatusOK, items)
+			return
+		}
+		key, ok := listKeys[leaf]
+		if !ok {
+			key = leaf
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{key: items, "nextPageToken": ""})
+	case http.MethodPost:
+		body, err := readBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		id := idField(leaf)
+		name, _ := body[id].(string)
+		if name == "" {
+			name = r.URL.Query().Get("name")
+		}
+		if name == "" && generatedNames[leaf] {
+			name = f.newID()
+		}
+		if name == "" {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("%s is required", id))
+			return
+		}
+		if c, ok := f.collections[collPath]; ok && c.items[name] != nil {
+			writeError(w, http.StatusConflict, fmt.Sprintf("%s %s already exists", leaf, name))
+			return
+		}
+		body[id] = name
+		body["createdAt"] = strconv.FormatInt(now(), 10)
+		body["lastModifiedAt"] = body["createdAt"]
+		switch {
+		case leaf == "developers":
+			body["developerId"] = f.newID()
+			body["status"] = "active"
+		case leaf == "apps" && strings.HasPrefix(collPath, "developers/"):
+			appID := f.newID()
+			body["appId"] = appID
+			body["developerId"] = strings.Split(collPath, "/")[1]
+			if developers, ok := f.collections["developers"]; ok && developers.items[body["developerId"].(string)] != nil {
+				body["developerId"] = developers.items[body["developerId"].(string)]["developerId"]
+			}
+			body["status"] = "approved"
+			body["credentials"] = []interface{}{newCredential(nil, nil, body["apiProducts"])}
+			delete(body, "apiProducts")
+			f.apps[appID] = collPath + "/" + name
+		case leaf == "apps" && strings.HasPrefix(collPath, "appgroups/"):
+			body["appId"] = f.newID()
+			body["appGroup"] = strings.Split(collPath, "/")[1]
+			body["status"] = "approved"
+			body["credentials"] = []interface{}{newCredential(nil, nil, body["apiProducts"])}
+			delete(body, "apiProducts")
+		}
+		f.put(collPath, name, body)
+		if lroCollections[leaf] {
+			writeJSON(w, http.StatusOK, f.operation(body))
+			return
+		}
+		writeJSON(w, http.StatusCreated, body)
+	default:
+		writeError(w, http.StatusNotImplemented, "method not supported by the fake control plane")
+	}
+}
+
+func (f *FakeApigee) operation(response map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"name":     fmt.Sprintf("organizations/%s/operations/%s", f.Org, f.newID()),
+		"metadata": map[string]interface{}{"state": "FINISHED"},
+		"done":     true,
+		"response": response,
+	}
+}
+
+func (f *FakeApigee) put(collPath string, name string, item map[string]interface{}) {
+	c, ok := f.collections[collPath]
+	if !ok {
+		c = &collection{items: make(map[string]map[string]interface{})}
+		f.collections[collPath] = c
+	}
+	if _, ok := c.items[name]; !ok {
+		c.names = append(c.names, name)
+	}
+	c.items[name] = item
+}
+
+func (f *FakeApigee) remove(collPath string, name string) {
+	c, ok := f.collections[collPath]
+	if !ok {
+		return
+	}
+	delete(c.items, name)
+	for i, n := range c.names {
+		if n == name {
+			c.names = append(c.names[:i], c.names[i+1:]...)
+			break
+		}
+	}
+}
+
+func (f *FakeApigee) names(collPath string) []string {
+	if c, ok := f.collections[collPath]; ok {
+		return append([]string{}, c.names...)
+	}
+	return []string{}
+}
+
+func (f *FakeApigee) newID() string {
+	f.counter++
+	return fmt.Sprintf("00000000-0000-4000-8000-%012d", f.counter)
+}
+
+func idField(leaf string) string {
+	if leaf == "developers" {
+		return "email"
+	}
+	return "name"
+}
+
+func readBody(r *http.Request) (map[string]interface{}, error) {
+	body := map[string]interface{}{}
+	b, err := io.ReadAll(r.Body)
+	if err != nil || len(bytes.TrimSpace(b)) == 0 {
+		return body, err
+	}
+	if err = json.Unmarshal(b, &body); err != nil {
+		return nil, fmt.Errorf("invalid JSON payload: %v", err)
+	}
+	return body, nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with a google.rpc.Status payload
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	status := map[int]string{
+		http.StatusBadRequest:     "INVALID_ARGUMENT",
+		http.StatusUnauthorized:   "UNAUTHENTICATED",
+		http.StatusForbidden:      "PERMISSION_DENIED",
+		http.StatusNotFound:       "NOT_FOUND",
+		http.StatusConflict:       "ALREADY_EXISTS",
+		http.StatusNotImplemented: "UNIMPLEMENTED",
+	}[statusCode]
+	writeJSON(w, statusCode, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    statusCode,
+			"message": message,
+			"status":  status,
+		},
+	})
+}
+
+func now() int64 {
+	return time.Now().UnixMilli()
+}
@@ -0,0 +1,390 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugsessions
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/clilog"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// An archive is a zip file with:
+//
+//	manifest.json       the session the transactions were captured in
+//	traces/<id>.json    the raw JSON of each transaction
+//	session.har         the requests and responses as HAR 1.2
+//	timings.json        the execution time of each policy
+const (
+	manifestFile = "manifest.json"
+	tracesFolder = "traces"
+	harFile      = "session.har"
+	timingsFile  = "timings.json"
+)
+
+// Manifest describes the debug session of an archive
+type Manifest struct {
+	Organization string    `json:"organization,omitempty"`
+	Environment  string    `json:"environment,omitempty"`
+	Proxy        string    `json:"proxy,omitempty"`
+	Revision     int       `json:"revision,omitempty"`
+	Session      string    `json:"session,omitempty"`
+	ExportTime   time.Time `json:"exportTime,omitempty"`
+	Transactions []string  `json:"transactions,omitempty"`
+}
+
+// PolicyTiming is the execution time of a policy across the transactions of a session
+type PolicyTiming struct {
+	Policy     string        `json:"policy,omitempty"`
+	Type       string        `json:"type,omitempty"`
+	Executions int           `json:"executions,omitempty"`
+	Total      time.Duration `json:"total,omitempty"`
+	Average    time.Duration `json:"average,omitempty"`
+	Max        time.Duration `json:"max,omitempty"`
+}
+
+type har struct {
+	Log harLog `json:"log"`
+}
+
+type harLog struct {
+	Version string     `json:"version"`
+	Creator harCreator `json:"creator"`
+	Entries []harEntry `json:"entries"`
+}
+
+type harCreator struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+type harEntry struct {
+	StartedDateTime string      `json:"startedDateTime"`
+	Time            float64     `json:"time"`
+	Request         harRequest  `json:"request"`
+	Response        harResponse `json:"response"`
+	Cache           struct{}    `json:"cache"`
+	Timings         harTimings  `json:"timings"`
+	Comment         string      `json:"comment,omitempty"`
+}
+
+type harRequest struct {
+	Method      string         `json:"method"`
+	URL         string         `json:"url"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	QueryString []harNameValue `json:"queryString"`
+	PostData    *harPostData   `json:"postData,omitempty"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harResponse struct {
+	Status      int            `json:"status"`
+	StatusText  string         `json:"statusText"`
+	HTTPVersion string         `json:"httpVersion"`
+	Cookies     []harNameValue `json:"cookies"`
+	Headers     []harNameValue `json:"headers"`
+	Content     harContent     `json:"content"`
+	RedirectURL string         `json:"redirectURL"`
+	HeadersSize int            `json:"headersSize"`
+	BodySize    int            `json:"bodySize"`
+}
+
+type harNameValue struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type harPostData struct {
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text"`
+}
+
+type harContent struct {
+	Size     int    `json:"size"`
+	MimeType string `json:"mimeType"`
+	Text     string `json:"text,omitempty"`
+}
+
+type harTimings struct {
+	Send    float64 `json:"send"`
+	Wait    float64 `json:"wait"`
+	Receive float64 `json:"receive"`
+}
+
+// NewSession returns an existing debug session of a proxy revision
+func NewSession(proxy string, revision int, id string) *Session {
+	return &Session{Proxy: proxy, Revision: revision, ID: id, seen: map[string]bool{}}
+}
+
+// Export writes every transaction of a debug session to an archive
+func Export(s *Session, fileName string) (count int, err error) {
+	ids, err := ListTransactions(s)
+	if err != nil {
+		return 0, err
+	}
+	raws := map[string][]byte{}
+	for _, id := range ids {
+		if raws[id], err = GetTransaction(s, id); err != nil {
+			return 0, fmt.Errorf("error fetching transaction %s: %w", id, err)
+		}
+	}
+	m := Manifest{
+		Organization: apiclient.GetApigeeOrg(),
+		Environment:  apiclient.GetApigeeEnv(),
+		Proxy:        s.Proxy,
+		Revision:     s.Revision,
+		Session:      s.ID,
+		ExportTime:   time.Now().UTC(),
+		Transactions: ids,
+	}
+	if err = WriteArchive(fileName, m, raws); err != nil {
+		return 0, err
+	}
+	clilog.Info.Printf("Exported %d transactions of debug session %s to %s\n", len(ids), s.ID, fileName)
+	return len(ids), nil
+}
+
+// WriteArchive writes the raw transactions with a HAR file and a timing summary
+func WriteArchive(fileName string, m Manifest, raws map[string][]byte) (err error) {
+	transactions := []Transaction{}
+	for _, id := range m.Transactions {
+		t, err := Decode(id, raws[id])
+		if err != nil {
+			return fmt.Errorf("error decoding transaction %s: %w", id, err)
+		}
+		t.Proxy, t.Revision = m.Proxy, m.Revision
+		transactions = append(transactions, t)
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+
+	files := map[string]interface{}{
+		manifestFile: m,
+		harFile:      toHAR(transactions),
+		timingsFile:  Timings(transactions),
+	}
+	for _, name := range []string{manifestFile, harFile, timingsFile} {
+		data, err := json.MarshalIndent(files[name], "", "  ")
+		if err != nil {
+			return err
+		}
+		if err = writeZipFile(w, name, data); err != nil {
+			return err
+		}
+	}
+	for _, id := range m.Transactions {
+		if err = writeZipFile(w, path.Join(tracesFolder, id+".json"), raws[id]); err != nil {
+			return err
+		}
+	}
+	return w.Close()
+}
+
+// ReadArchive reads an archive and decodes its transactions, ordered by start time
+func ReadArchive(fileName string) (m Manifest, transactions []Transaction, err error) {
+	r, err := zip.OpenReader(fileName)
+	if err != nil {
+		return m, nil, err
+	}
+	defer r.Close()
+
+	raws := map[string][]byte{}
+	for _, f := range r.File {
+		data, err := readZipFile(f)
+		if err != nil {
+			return m, nil, err
+		}
+		if f.Name == manifestFile {
+			if err = json.Unmarshal(data, &m); err != nil {
+				return m, nil, fmt.Errorf("invalid %s: %w", manifestFile, err)
+			}
+		} else if path.Dir(f.Name) == tracesFolder {
+			raws[strings.TrimSuffix(path.Base(f.Name), ".json")] = data
+		}
+	}
+	if m.Session == "" {
+		return m, nil, fmt.Errorf("%s is not a debug session archive", fileName)
+	}
+
+	for _, id := range m.Transactions {
+		raw, ok := raws[id]
+		if !ok {
+			return m, nil, fmt.Errorf("transaction %s is missing from %s", id, fileName)
+		}
+		t, err := Decode(id, raw)
+		if err != nil {
+			return m, nil, fmt.Errorf("error decoding transaction %s: %w", id, err)
+		}
+		t.Proxy, t.Revision = m.Proxy, m.Revision
+		transactions = append(transactions, t)
+	}
+	sort.SliceStable(transactions, func(i, j int) bool {
+		return transactions[i].StartTime.Before(transactions[j].StartTime)
+	})
+	return m, transactions, nil
+}
+
+// Timings summarizes the execution time of each policy, slowest first
+func Timings(transactions []Transaction) []PolicyTiming {
+	timings := map[string]*PolicyTiming{}
+	for _, t := range transactions {
+		for _, s := range t.Steps {
+			p, ok := timings[s.Policy]
+			if !ok {
+				p = &PolicyTiming{Policy: s.Policy, Type: s.Type}
+				timings[s.Policy] = p
+			}
+			p.Executions++
+			p.Total += s.ExecutionTime
+			p.Max = max(p.Max, s.ExecutionTime)
+		}
+	}
+	l := []PolicyTiming{}
+	for _, p := range timings {
+		p.Average = p.Total / time.Duration(p.Executions)
+		l = append(l, *p)
+	}
+	sort.Slice(l, func(i, j int) bool {
+		if l[i].Total != l[j].Total {
+			return l[i].Total > l[j].Total
+		}
+		return l[i].Policy < l[j].Policy
+	})
+	return l
+}
+
+// RenderTimings writes the timing summary as a table
+func RenderTimings(w io.Writer, timings []PolicyTiming) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "POLICY\tTYPE\tEXECUTIONS\tTOTAL\tAVERAGE\tMAX")
+	for _, p := range timings {
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%s\t%s\t%s\n", p.Policy, p.Type, p.Executions, p.Total, p.Average, p.Max)
+	}
+	tw.Flush()
+}
+
+func toHAR(transactions []Transaction) har {
+	h := har{Log: harLog{
+		Version: "1.2",
+		Creator: harCreator{Name: "apigeecli"},
+		Entries: []harEntry{},
+	}}
+	for _, t := range transactions {
+		host := header(t.Request.Headers, "Host")
+		u, _ := url.Parse(t.URI)
+		if u == nil {
+			u = &url.URL{Path: t.URI}
+		}
+		u.Scheme, u.Host = "https", host
+
+		e := harEntry{
+			StartedDateTime: t.StartTime.Format(time.RFC3339Nano),
+			Time:            float64(t.Duration.Milliseconds()),
+			Request: harRequest{
+				Method:      t.Verb,
+				URL:         u.String(),
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     harHeaders(t.Request.Headers),
+				QueryString: []harNameValue{},
+				HeadersSize: -1,
+				BodySize:    len(t.Request.Body),
+			},
+			Response: harResponse{
+				Status:      t.StatusCode,
+				HTTPVersion: "HTTP/1.1",
+				Cookies:     []harNameValue{},
+				Headers:     harHeaders(t.Response.Headers),
+				Content: harContent{
+					Size:     len(t.Response.Body),
+					MimeType: header(t.Response.Headers, "Content-Type"),
+					Text:     t.Response.Body,
+				},
+				HeadersSize: -1,
+				BodySize:    len(t.Response.Body),
+			},
+			Timings: harTimings{Wait: float64(t.Duration.Milliseconds())},
+			Comment: "transaction " + t.ID,
+		}
+		for name, values := range u.Query() {
+			for _, v := range values {
+				e.Request.QueryString = append(e.Request.QueryString, harNameValue{Name: name, Value: v})
+			}
+		}
+		sort.Slice(e.Request.QueryString, func(i, j int) bool {
+			return e.Request.QueryString[i].Name < e.Request.QueryString[j].Name
+		})
+		if t.Request.Body != "" {
+			e.Request.PostData = &harPostData{
+				MimeType: header(t.Request.Headers, "Content-Type"),
+				Text:     t.Request.Body,
+			}
+		}
+		h.Log.Entries = append(h.Log.Entries, e)
+	}
+	return h
+}
+
+func harHeaders(headers []Header) []harNameValue {
+	l := []harNameValue{}
+	for _, h := range headers {
+		l = append(l, harNameValue{Name: h.Name, Value: h.Value})
+	}
+	return l
+}
+
+func header(headers []Header, name string) string {
+	for _, h := range headers {
+		if strings.EqualFold(h.Name, name) {
+			return h.Value
+		}
+	}
+	return ""
+}
+
+func writeZipFile(w *zip.Writer, name string, data []byte) error {
+	f, err := w.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,75 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugsessions
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"path"
+	"testing"
+	"time"
+)
+
+func TestWriteAndReadArchive(t *testing.T) {
+	fileName := path.Join(t.TempDir(), "session.zip")
+	m := Manifest{Proxy: "orders", Revision: 3, Session: "s1", Transactions: []string{"tx1", "tx2"}}
+	raws := map[string][]byte{"tx1": []byte(sampleTransaction), "tx2": []byte(sampleTransaction)}
+	if err := WriteArchive(fileName, m, raws); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	got, transactions, err := ReadArchive(fileName)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if got.Proxy != "orders" || len(transactions) != 2 || transactions[0].Proxy != "orders" {
+		t.Fatalf("unexpected archive %+v %+v", got, transactions)
+	}
+
+	r, err := zip.OpenReader(fileName)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer r.Close()
+	h := har{}
+	timings := []PolicyTiming{}
+	for _, f := range r.File {
+		data, err := readZipFile(f)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		switch f.Name {
+		case harFile:
+			err = json.Unmarshal(data, &h)
+		case timingsFile:
+			err = json.Unmarshal(data, &timings)
+		}
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+	if h.Log.Version != "1.2" || len(h.Log.Entries) != 2 {
+		t.Fatalf("unexpected har %+v", h)
+	}
+	e := h.Log.Entries[0]
+	if e.Request.URL != "https://api.example.com/v1/orders" || e.Request.PostData.Text != `{"id":1}` ||
+		e.Response.Status != 401 || e.Response.Content.MimeType != "application/json" {
+		t.Fatalf("unexpected entry %+v", e)
+	}
+	if len(timings) != 2 || timings[0].Policy != "FC-Auth" || timings[0].Executions != 2 ||
+		timings[0].Average != 38*time.Millisecond {
+		t.Fatalf("unexpected timings %+v", timings)
+	}
+}
@@ -0,0 +1,200 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugsessions
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/clilog"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Session is a debug session of a proxy revision deployed to the environment
+type Session struct {
+	Proxy    string
+	Revision int
+	ID       string
+	seen     map[string]bool
+}
+
+// Filter selects the transactions to show
+type Filter struct {
+	// StatusCodes are codes like 500 or classes like 5xx
+	StatusCodes []string
+	// Path is a prefix of the request path
+	Path string
+	// SharedFlow keeps transactions that reference the sharedflow
+	SharedFlow string
+}
+
+// CreateSession starts a debug session that captures transactions for the timeout
+func CreateSession(proxy string, revision int, timeout time.Duration) (s *Session, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", apiclient.GetApigeeEnv(),
+		"apis", proxy, "revisions", strconv.Itoa(revision), "debugsessions")
+	q := u.Query()
+	q.Set("timeout", strconv.Itoa(int(timeout.Seconds())))
+	u.RawQuery = q.Encode()
+
+	respBody, err := apiclient.HttpClient(u.String(), "{}")
+	if err != nil {
+		return nil, err
+	}
+	session := struct {
+		Name string `json:"name,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &session); err != nil {
+		return nil, err
+	}
+	clilog.Info.Printf("Created debug session %s for %s revision %d\n", session.Name, proxy, revision)
+	return &Session{Proxy: proxy, Revision: revision, ID: session.Name, seen: map[string]bool{}}, nil
+}
+
+// CreateSessions creates a debug session for each proxy revision. With a revision of -1,
+// a session is created for each revision deployed to the environment
+func CreateSessions(proxies []string, revision int, timeout time.Duration) (sessions []*Session, err error) {
+	for _, proxy := range proxies {
+		revisions := []int{revision}
+		if revision == -1 {
+			if revisions, err = apiclient.DeployedRevisions("apis", apiclient.GetApigeeEnv(), proxy); err != nil {
+				return nil, err
+			}
+			if len(revisions) == 0 {
+				return nil, fmt.Errorf("%s is not deployed to %s", proxy, apiclient.GetApigeeEnv())
+			}
+		}
+		for _, rev := range revisions {
+			s, err := CreateSession(proxy, rev, timeout)
+			if err != nil {
+				return nil, err
+			}
+			sessions = append(sessions, s)
+		}
+	}
+	return sessions, nil
+}
+
+// ListTransactions returns the ids of the transactions captured by a session
+func ListTransactions(s *Session) (ids []string, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	respBody, err := apiclient.HttpClient(s.dataURL(""))
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(respBody)) == 0 {
+		return nil, nil
+	}
+	err = json.Unmarshal(respBody, &ids)
+	return ids, err
+}
+
+// GetTransaction returns the raw JSON of a transaction
+func GetTransaction(s *Session, id string) (respBody []byte, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+	return apiclient.HttpClient(s.dataURL(id))
+}
+
+// Tail polls the sessions every interval for the duration and calls handle for each
+// new completed transaction that matches the filter. Tail returns when the duration
+// expires or the command is interrupted
+func Tail(sessions []*Session, f Filter, interval time.Duration, duration time.Duration,
+	handle func(t Transaction, raw []byte) error,
+) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-apiclient.GetContext().Done():
+			return nil
+		case <-timer.C:
+			return nil
+		case <-ticker.C:
+		}
+		for _, s := range sessions {
+			ids, err := ListTransactions(s)
+			if err != nil {
+				return fmt.Errorf("error listing transactions of %s: %w", s.Proxy, err)
+			}
+			for _, id := range ids {
+				if s.seen[id] {
+					continue
+				}
+				raw, err := GetTransaction(s, id)
+				if err != nil {
+					return fmt.Errorf("error fetching transaction %s: %w", id, err)
+				}
+				t, err := Decode(id, raw)
+				if err != nil {
+					return fmt.Errorf("error decoding transaction %s: %w", id, err)
+				}
+				if !t.Completed {
+					// fetch it again once the transaction completes
+					continue
+				}
+				s.seen[id] = true
+				t.Proxy, t.Revision = s.Proxy, s.Revision
+				if !f.Match(t, raw) {
+					continue
+				}
+				if err = handle(t, raw); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// Match reports whether a transaction passes the filter
+func (f Filter) Match(t Transaction, raw []byte) bool {
+	if f.Path != "" && !strings.HasPrefix(t.URI, f.Path) {
+		return false
+	}
+	if f.SharedFlow != "" && !bytes.Contains(raw, []byte(strconv.Quote(f.SharedFlow))) {
+		return false
+	}
+	if len(f.StatusCodes) == 0 {
+		return true
+	}
+	code := strconv.Itoa(t.StatusCode)
+	for _, s := range f.StatusCodes {
+		s = strings.ToLower(s)
+		if s == code || (len(s) == 3 && strings.HasSuffix(s, "xx") && s[0] == code[0]) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Session) dataURL(id string) string {
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", apiclient.GetApigeeEnv(),
+		"apis", s.Proxy, "revisions", strconv.Itoa(s.Revision), "debugsessions", s.ID, "data", id)
+	return u.String()
+}
@@ -0,0 +1,249 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugsessions
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Transaction is a debug transaction decoded from the /data response
+type Transaction struct {
+	ID         string        `json:"id,omitempty"`
+	Proxy      string        `json:"proxy,omitempty"`
+	Revision   int           `json:"revision,omitempty"`
+	Completed  bool          `json:"completed,omitempty"`
+	Verb       string        `json:"verb,omitempty"`
+	URI        string        `json:"uri,omitempty"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	StartTime  time.Time     `json:"startTime,omitempty"`
+	Duration   time.Duration `json:"duration,omitempty"`
+	Request    Message       `json:"request,omitempty"`
+	Response   Message       `json:"response,omitempty"`
+	Steps      []Step        `json:"steps,omitempty"`
+	Errors     []string      `json:"errors,omitempty"`
+}
+
+// Message is a decoded request or response
+type Message struct {
+	Headers []Header `json:"headers,omitempty"`
+	Body    string   `json:"body,omitempty"`
+}
+
+// Header is an http header
+type Header struct {
+	Name  string `json:"name,omitempty"`
+	Value string `json:"value,omitempty"`
+}
+
+// Step is a policy execution
+type Step struct {
+	Policy        string        `json:"policy,omitempty"`
+	Type          string        `json:"type,omitempty"`
+	Enforcement   string        `json:"enforcement,omitempty"`
+	ExecutionTime time.Duration `json:"executionTime,omitempty"`
+	Variables     []Header      `json:"variables,omitempty"`
+	Error         string        `json:"error,omitempty"`
+}
+
+type rawTransaction struct {
+	Completed bool       `json:"completed,omitempty"`
+	Point     []rawPoint `json:"point,omitempty"`
+}
+
+type rawPoint struct {
+	ID      string      `json:"id,omitempty"`
+	Results []rawResult `json:"results,omitempty"`
+}
+
+type rawResult struct {
+	ActionResult string `json:"ActionResult,omitempty"`
+	Timestamp    string `json:"timestamp,omitempty"`
+	Properties   struct {
+		Property []Header `json:"property,omitempty"`
+	} `json:"properties,omitempty"`
+	AccessList []map[string]struct {
+		Name    string `json:"name,omitempty"`
+		Value   string `json:"value,omitempty"`
+		Success bool   `json:"success,omitempty"`
+	} `json:"accessList,omitempty"`
+	Headers         []Header `json:"headers,omitempty"`
+	Content         string   `json:"content,omitempty"`
+	ContentEncoding string   `json:"contentEncoding,omitempty"`
+	Verb            string   `json:"verb,omitempty"`
+	URI             string   `json:"uRI,omitempty"`
+	StatusCode      string   `json:"statusCode,omitempty"`
+}
+
+// Decode decodes the raw JSON of a debug transaction into a timeline of policy executions.
+// The execution time of a policy is the time since the previous point of the transaction
+func Decode(id string, raw []byte) (t Transaction, err error) {
+	r := rawTransaction{}
+	if err = json.Unmarshal(raw, &r); err != nil {
+		return t, err
+	}
+	t.ID, t.Completed = id, r.Completed
+
+	var last time.Time
+	requestSeen := false
+	for _, p := range r.Point {
+		step := Step{}
+		var stepTime time.Time
+		for _, res := range p.Results {
+			ts, ok := parseTimestamp(res.Timestamp)
+			if ok && t.StartTime.IsZero() {
+				t.StartTime = ts
+			}
+			switch res.ActionResult {
+			case "DebugInfo":
+				if ok {
+					stepTime = ts
+				}
+				for _, prop := range res.Properties.Property {
+					switch {
+					case prop.Name == "stepDefinition-name":
+						step.Policy = prop.Value
+					case prop.Name == "type":
+						step.Type = prop.Value
+					case prop.Name == "enforcement":
+						step.Enforcement = prop.Value
+					case isError(prop):
+						step.Error = prop.Value
+					}
+				}
+			case "VariableAccess":
+				for _, access := range res.AccessList {
+					if set, ok := access["Set"]; ok && set.Success {
+						step.Variables = append(step.Variables, Header{Name: set.Name, Value: set.Value})
+					}
+				}
+			case "RequestMessage":
+				if !requestSeen {
+					requestSeen = true
+					t.Verb, t.URI = res.Verb, res.URI
+					t.Request = Message{Headers: res.Headers, Body: decodeContent(res.Content, res.ContentEncoding)}
+				}
+			case "ResponseMessage":
+				t.Response = Message{Headers: res.Headers, Body: decodeContent(res.Content, res.ContentEncoding)}
+				if code, err := strconv.Atoi(res.StatusCode); err == nil {
+					t.StatusCode = code
+				}
+			case "ErrorMessage":
+				t.Errors = append(t.Errors, strings.TrimSpace(res.StatusCode+" "+decodeContent(res.Content, res.ContentEncoding)))
+				if code, err := strconv.Atoi(res.StatusCode); err == nil {
+					t.StatusCode = code
+				}
+			}
+		}
+
+		if p.ID == "Execution" && step.Policy != "" {
+			if !stepTime.IsZero() && !last.IsZero() {
+				step.ExecutionTime = stepTime.Sub(last)
+			}
+			if step.Error != "" {
+				t.Errors = append(t.Errors, step.Policy+": "+step.Error)
+			}
+			t.Steps = append(t.Steps, step)
+		}
+		if !stepTime.IsZero() {
+			last = stepTime
+		}
+	}
+	if !last.IsZero() {
+		t.Duration = last.Sub(t.StartTime)
+	}
+	return t, nil
+}
+
+// Render writes a transaction as a readable timeline
+func Render(w io.Writer, t Transaction) {
+	fmt.Fprintf(w, "%s %s %s -> %d", t.StartTime.Format(time.RFC3339Nano), t.Verb, t.URI, t.StatusCode)
+	if t.Proxy != "" {
+		fmt.Fprintf(w, " (%s revision %d)", t.Proxy, t.Revision)
+	}
+	fmt.Fprintf(w, " in %s, transaction %s\n", t.Duration, t.ID)
+
+	renderMessage(w, "Request", t.Request)
+	for _, s := range t.Steps {
+		fmt.Fprintf(w, "  %8s  %-8s  %s (%s)\n", "+"+s.ExecutionTime.String(), s.Enforcement, s.Policy, s.Type)
+		for _, v := range s.Variables {
+			fmt.Fprintf(w, "              set %s = %s\n", v.Name, v.Value)
+		}
+		if s.Error != "" {
+			fmt.Fprintf(w, "              error: %s\n", s.Error)
+		}
+	}
+	renderMessage(w, "Response", t.Response)
+	if len(t.Errors) > 0 {
+		fmt.Fprintln(w, "  Errors:")
+		for _, e := range t.Errors {
+			fmt.Fprintf(w, "    %s\n", e)
+		}
+	}
+	fmt.Fprintln(w)
+}
+
+func renderMessage(w io.Writer, title string, m Message) {
+	if len(m.Headers) == 0 && m.Body == "" {
+		return
+	}
+	fmt.Fprintf(w, "  %s headers:\n", title)
+	for _, h := range m.Headers {
+		fmt.Fprintf(w, "    %s: %s\n", h.Name, h.Value)
+	}
+	if m.Body != "" {
+		fmt.Fprintf(w, "  %s body:\n    %s\n", title, strings.ReplaceAll(m.Body, "\n", "\n    "))
+	}
+}
+
+// parseTimestamp parses a debug timestamp, for ex: 18-10-26 10:31:02:123
+func parseTimestamp(timestamp string) (time.Time, bool) {
+	i := strings.LastIndex(timestamp, ":")
+	if i < 0 {
+		return time.Time{}, false
+	}
+	t, err := time.Parse("02-01-06 15:04:05", timestamp[:i])
+	if err != nil {
+		return time.Time{}, false
+	}
+	millis, err := strconv.Atoi(timestamp[i+1:])
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.Add(time.Duration(millis) * time.Millisecond), true
+}
+
+// decodeContent decodes the payloads marked as base64, leaving other content as-is.
+// A plain-text payload, ex: Zm9v, can also be valid base64
+func decodeContent(content string, encoding string) string {
+	if !strings.EqualFold(encoding, "base64") {
+		return content
+	}
+	if decoded, err := base64.StdEncoding.DecodeString(content); err == nil && utf8.Valid(decoded) {
+		return string(decoded)
+	}
+	return content
+}
+
+func isError(prop Header) bool {
+	name := strings.ToLower(prop.Name)
+	return (name == "error" || strings.HasPrefix(name, "error.")) && prop.Value != "" && prop.Value != "false"
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package debugsessions
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+// sampleTransaction is a transaction with one policy that sets a variable and fails
+const sampleTransaction = `{"completed":true,"point":[
+{"id":"StateChange","results":[
+  {"ActionResult":"DebugInfo","timestamp":"18-10-26 10:31:02:100","properties":{"property":[{"name":"To","value":"REQ_HEADERS_PARSED"}]}},
+  {"ActionResult":"RequestMessage","verb":"POST","uRI":"/v1/orders","headers":[{"name":"Host","value":"api.example.com"}],"content":"eyJpZCI6MX0=","contentEncoding":"base64"}]},
+{"id":"Execution","results":[
+  {"ActionResult":"DebugInfo","timestamp":"18-10-26 10:31:02:112","properties":{"property":[
+    {"name":"stepDefinition-name","value":"AM-SetTarget"},{"name":"type","value":"AssignMessage"},{"name":"enforcement","value":"request"}]}},
+  {"ActionResult":"VariableAccess","accessList":[{"Get":{"name":"request.verb","value":"POST","success":true}},
+    {"Set":{"name":"target.url","value":"https://backend","success":true}}]}]},
+{"id":"Execution","results":[
+  {"ActionResult":"DebugInfo","timestamp":"18-10-26 10:31:02:150","properties":{"property":[
+    {"name":"stepDefinition-name","value":"FC-Auth"},{"name":"type","value":"FlowCallout"},{"name":"enforcement","value":"request"},
+    {"name":"sharedflow","value":"auth-sf"},{"name":"error","value":"invalid token"}]}}]},
+{"id":"StateChange","results":[
+  {"ActionResult":"DebugInfo","timestamp":"18-10-26 10:31:02:160","properties":{"property":[{"name":"To","value":"RESP_SENT"}]}},
+  {"ActionResult":"ResponseMessage","statusCode":"401","headers":[{"name":"Content-Type","value":"application/json"}],"content":"{\"error\":\"unauthorized\"}"}]}]}`
+
+func TestDecode(t *testing.T) {
+	tr, err := Decode("tx1", []byte(sampleTransaction))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if tr.Verb != "POST" || tr.URI != "/v1/orders" || tr.StatusCode != 401 || !tr.Completed {
+		t.Fatalf("unexpected transaction %+v", tr)
+	}
+	if tr.Request.Body != `{"id":1}` || tr.Response.Body != `{"error":"unauthorized"}` {
+		t.Fatalf("unexpected bodies %q %q", tr.Request.Body, tr.Response.Body)
+	}
+	// a plain-text body that is valid base64 is not decoded
+	plain, err := Decode("tx2", []byte(`{"point":[{"id":"StateChange","results":[
+  {"ActionResult":"ResponseMessage","statusCode":"200","content":"Zm9v"}]}]}`))
+	if err != nil || plain.Response.Body != "Zm9v" {
+		t.Fatalf("unexpected body %q, %v", plain.Response.Body, err)
+	}
+	if len(tr.Steps) != 2 || tr.Steps[0].ExecutionTime != 12*time.Millisecond ||
+		tr.Steps[1].ExecutionTime != 38*time.Millisecond || tr.Duration != 60*time.Millisecond {
+		t.Fatalf("unexpected steps %+v", tr.Steps)
+	}
+	if len(tr.Steps[0].Variables) != 1 || tr.Steps[0].Variables[0].Name != "target.url" {
+		t.Fatalf("unexpected variables %+v", tr.Steps[0].Variables)
+	}
+	if len(tr.Errors) != 1 || tr.Errors[0] != "FC-Auth: invalid token" {
+		t.Fatalf("unexpected errors %v", tr.Errors)
+	}
+
+	var b bytes.Buffer
+	Render(&b, tr)
+	for _, want := range []string{"POST /v1/orders -> 401", "AM-SetTarget (AssignMessage)",
+		"set target.url = https://backend", "error: invalid token"} {
+		if !strings.Contains(b.String(), want) {
+			t.Fatalf("%q not found in\n%s", want, b.String())
+		}
+	}
+}
+
+func TestFilterMatch(t *testing.T) {
+	tr := Transaction{URI: "/v1/orders/1", StatusCode: 401}
+	raw := []byte(sampleTransaction)
+	for _, c := range []struct {
+		f    Filter
+		want bool
+	}{
+		{Filter{}, true},
+		{Filter{StatusCodes: []string{"4xx"}}, true},
+		{Filter{StatusCodes: []string{"500", "5XX"}}, false},
+		{Filter{Path: "/v1/orders"}, true},
+		{Filter{Path: "/v2"}, false},
+		{Filter{SharedFlow: "auth-sf"}, true},
+		{Filter{SharedFlow: "auth"}, false},
+	} {
+		if got := c.f.Match(tr, raw); got != c.want {
+			t.Fatalf("%+v: got %v", c.f, got)
+		}
+	}
+}
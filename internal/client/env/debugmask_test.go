@@ -26,7 +26,7 @@ import (
 var cliPath = os.Getenv("APIGEECLI_PATH")
 
 func TestSetDebug(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -49,7 +49,7 @@ func TestSetDebug(t *testing.T) {
 }
 
 func TestGetDebug(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -0,0 +1,235 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/apis"
+	"internal/client/flowhooks"
+	"internal/client/sharedflows"
+	"internal/clilog"
+	"os"
+	"strconv"
+)
+
+// EnvDeployments holds the deployments and flowhook attachments of an environment
+type EnvDeployments struct {
+	Proxies     []Deployment `json:"proxies,omitempty"`
+	SharedFlows []Deployment `json:"sharedFlows,omitempty"`
+	FlowHooks   []FlowHook   `json:"flowHooks,omitempty"`
+}
+
+// Deployment is a deployed proxy or sharedflow revision
+type Deployment struct {
+	Name           string `json:"name,omitempty"`
+	Revision       string `json:"revision,omitempty"`
+	ServiceAccount string `json:"serviceAccount,omitempty"`
+}
+
+// FlowHook is a sharedflow attached to a flowhook
+type FlowHook struct {
+	FlowHookPoint   string `json:"flowHookPoint,omitempty"`
+	SharedFlow      string `json:"sharedFlow,omitempty"`
+	ContinueOnError *bool  `json:"continueOnError,omitempty"`
+	Description     string `json:"description,omitempty"`
+}
+
+type deploymentList struct {
+	Deployments []struct {
+		APIProxy       string `json:"apiProxy,omitempty"`
+		Revision       string `json:"revision,omitempty"`
+		ServiceAccount string `json:"serviceAccount,omitempty"`
+	} `json:"deployments,omitempty"`
+}
+
+// ExportDeployments returns the proxy and sharedflow deployments, with their service
+// accounts, and the flowhook attachments of the environment
+func ExportDeployments() (respBody []byte, err error) {
+	d := EnvDeployments{}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	if d.Proxies, err = listDeployments(apis.ListEnvDeployments, apis.ListProxyRevisionDeployments); err != nil {
+		return nil, err
+	}
+	if d.SharedFlows, err = listDeployments(sharedflows.ListEnvDeployments, sharedflows.ListRevisionDeployments); err != nil {
+		return nil, err
+	}
+
+	if respBody, err = flowhooks.List(); err != nil {
+		return nil, err
+	}
+	flowhookPoints := []string{}
+	if err = json.Unmarshal(respBody, &flowhookPoints); err != nil {
+		return nil, err
+	}
+	for _, flowhookPoint := range flowhookPoints {
+		f := FlowHook{}
+		if respBody, err = flowhooks.Get(flowhookPoint); err != nil {
+			return nil, err
+		}
+		if err = json.Unmarshal(respBody, &f); err != nil {
+			return nil, err
+		}
+		if f.SharedFlow != "" {
+			f.FlowHookPoint = flowhookPoint
+			d.FlowHooks = append(d.FlowHooks, f)
+		}
+	}
+
+	if respBody, err = json.Marshal(d); err != nil {
+		return nil, err
+	}
+	return apiclient.PrettifyJSON(respBody)
+}
+
+// listDeployments lists the deployments of the environment. The list response
+// may not include the service account, so each revision deployment is read
+func listDeployments(list func() ([]byte, error),
+	getRevision func(name string, revision int) ([]byte, error),
+) (deployments []Deployment, err error) {
+	respBody, err := list()
+	if err != nil {
+		return nil, err
+	}
+	l := deploymentList{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	for _, item := range l.Deployments {
+		d := Deployment{Name: item.APIProxy, Revision: item.Revision, ServiceAccount: item.ServiceAccount}
+		if d.ServiceAccount == "" {
+			revision, _ := strconv.Atoi(item.Revision)
+			if respBody, err = getRevision(item.APIProxy, revision); err != nil {
+				return nil, err
+			}
+			if err = json.Unmarshal(respBody, &d); err != nil {
+				return nil, err
+			}
+			d.Name = item.APIProxy
+		}
+		deployments = append(deployments, d)
+	}
+	return deployments, nil
+}
+
+// ImportDeployments deploys the sharedflows, attaches the flowhooks and then deploys
+// the proxies listed in a file written by ExportDeployments. Revisions already
+// deployed are skipped. When a revision does not exist in the org, the latest
+// revision is deployed instead
+func ImportDeployments(filePath string) error {
+	d := EnvDeployments{}
+
+	byteValue, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	if err = json.Unmarshal(byteValue, &d); err != nil {
+		return err
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	errs := []error{}
+
+	deployed, err := deployedRevisions(sharedflows.ListEnvDeployments)
+	if err != nil {
+		return err
+	}
+	for _, sf := range d.SharedFlows {
+		revision, err := resolveRevision(sharedflows.Get, sf)
+		if err == nil && deployed[sf.Name] != strconv.Itoa(revision) {
+			clilog.Info.Printf("\tDeploying sharedflow %s revision %d\n", sf.Name, revision)
+			_, err = sharedflows.Deploy(sf.Name, revision, true, sf.ServiceAccount)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("sharedflow %s not deployed: %w", sf.Name, err))
+		}
+	}
+
+	for _, f := range d.FlowHooks {
+		clilog.Info.Printf("\tAttaching sharedflow %s to %s\n", f.SharedFlow, f.FlowHookPoint)
+		if _, err = flowhooks.Attach(f.FlowHookPoint, f.Description, f.SharedFlow, f.ContinueOnError); err != nil {
+			errs = append(errs, fmt.Errorf("flowhook %s not attached: %w", f.FlowHookPoint, err))
+		}
+	}
+
+	if deployed, err = deployedRevisions(apis.ListEnvDeployments); err != nil {
+		return err
+	}
+	for _, proxy := range d.Proxies {
+		revision, err := resolveRevision(apis.GetProxy, proxy)
+		if err == nil && deployed[proxy.Name] != strconv.Itoa(revision) {
+			clilog.Info.Printf("\tDeploying proxy %s revision %d\n", proxy.Name, revision)
+			_, err = apis.DeployProxy(proxy.Name, revision, true, false, false, proxy.ServiceAccount)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("proxy %s not deployed: %w", proxy.Name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// deployedRevisions returns the revision deployed to the environment for each name
+func deployedRevisions(list func() ([]byte, error)) (map[string]string, error) {
+	respBody, err := list()
+	if err != nil {
+		return nil, err
+	}
+	l := deploymentList{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		return nil, err
+	}
+	deployed := map[string]string{}
+	for _, item := range l.Deployments {
+		deployed[item.APIProxy] = item.Revision
+	}
+	return deployed, nil
+}
+
+// resolveRevision returns the exported revision when it exists in the org, or the latest revision
+func resolveRevision(get func(name string, revision int) ([]byte, error), d Deployment) (int, error) {
+	respBody, err := get(d.Name, -1)
+	if err != nil {
+		return -1, err
+	}
+	entity := struct {
+		Revision []string `json:"revision,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &entity); err != nil {
+		return -1, err
+	}
+	latest := -1
+	for _, r := range entity.Revision {
+		if r == d.Revision {
+			return strconv.Atoi(r)
+		}
+		if n, err := strconv.Atoi(r); err == nil && n > latest {
+			latest = n
+		}
+	}
+	if latest < 0 {
+		return -1, fmt.Errorf("%s has no revisions", d.Name)
+	}
+	clilog.Warning.Printf("Revision %s of %s does not exist in the org, deploying revision %d\n",
+		d.Revision, d.Name, latest)
+	return latest, nil
+}
@@ -0,0 +1,92 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"encoding/json"
+	"internal/client/apis"
+	"internal/client/clienttest"
+	"internal/client/flowhooks"
+	"internal/client/sharedflows"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestExportImportDeployments(t *testing.T) {
+	if clienttest.UseRealOrg() {
+		t.Skip("undeploys proxies in the org")
+	}
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	const proxyName, sfName, sa = "deployments-proxy", "deployments-sf", "sa@fake-org.iam.gserviceaccount.com"
+	if _, err = apis.CreateProxy(proxyName, "", ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err = sharedflows.Create(sfName, "", ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err = apis.DeployProxy(proxyName, 1, false, false, false, sa); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err = sharedflows.Deploy(sfName, 1, false, ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err = flowhooks.Attach("PreProxyFlowHook", "", sfName, nil); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	respBody, err := ExportDeployments()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	d := EnvDeployments{}
+	if err = json.Unmarshal(respBody, &d); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(d.Proxies) != 1 || d.Proxies[0].ServiceAccount != sa || len(d.SharedFlows) != 1 ||
+		len(d.FlowHooks) != 1 || d.FlowHooks[0].SharedFlow != sfName {
+		t.Fatalf("unexpected export %s", string(respBody))
+	}
+
+	if _, err = apis.UndeployProxy(proxyName, 1, false); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err = flowhooks.Detach("PreProxyFlowHook"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	filePath := path.Join(t.TempDir(), "deployments.json")
+	if err = os.WriteFile(filePath, respBody, 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if err = ImportDeployments(filePath); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if respBody, err = ExportDeployments(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	d = EnvDeployments{}
+	if err = json.Unmarshal(respBody, &d); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(d.Proxies) != 1 || d.Proxies[0].ServiceAccount != sa || len(d.FlowHooks) != 1 {
+		t.Fatalf("deployments not restored %s", string(respBody))
+	}
+}
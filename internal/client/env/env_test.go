@@ -23,7 +23,7 @@ import (
 const name = "unittest-env"
 
 func TestCreate(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -40,7 +40,7 @@ func TestCreate(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -59,7 +59,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestList(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -71,7 +71,7 @@ func TestList(t *testing.T) {
 }
 
 func TestDeployments(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -89,7 +89,7 @@ func TestDeployments(t *testing.T) {
 }
 
 func TestSetProperty(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -103,7 +103,7 @@ func TestSetProperty(t *testing.T) {
 }
 
 func TestClearEnvProperties(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -117,7 +117,7 @@ func TestClearEnvProperties(t *testing.T) {
 }
 
 func TestExport(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -128,7 +128,7 @@ func TestExport(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD)
 	if err != nil {
 		t.Fatalf("%v", err)
@@ -24,7 +24,7 @@ const name = "test"
 
 func TestCreate(t *testing.T) {
 	hostnames := []string{"api.example1.com", "api.example2.com"}
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -34,7 +34,7 @@ func TestCreate(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -44,7 +44,7 @@ func TestGet(t *testing.T) {
 }
 
 func TestList(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -54,7 +54,7 @@ func TestList(t *testing.T) {
 }
 
 func TestAttach(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -65,7 +65,7 @@ func TestAttach(t *testing.T) {
 }
 
 func TestListAttach(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -76,7 +76,7 @@ func TestListAttach(t *testing.T) {
 
 func TestPatchHosts(t *testing.T) {
 	hostnames := []string{"api.example4.com", "api.example3.com"}
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -86,7 +86,7 @@ func TestPatchHosts(t *testing.T) {
 }
 
 func TestDetachEnvironment(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -97,7 +97,7 @@ func TestDetachEnvironment(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
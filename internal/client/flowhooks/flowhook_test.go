@@ -26,36 +26,37 @@ var cliPath = os.Getenv("APIGEECLI_PATH")
 
 const (
 	name       = "test-sharedflow"
+	point      = "PreProxyFlowHook"
 	testFolder = "test"
 )
 
 func TestAttach(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := sharedflows.Create(name, path.Join(cliPath, testFolder, "test_flow.zip")); err != nil {
+	if _, err := sharedflows.Create(name, path.Join(cliPath, testFolder, "test_flow.zip"), ""); err != nil {
 		t.Fatalf("%v", err)
 	}
 	cPtr := new(bool)
 	*cPtr = true
-	if _, err := Attach("PreProxyFlowHook", "test description", name, cPtr); err != nil {
+	if _, err := Attach(point, "test description", name, cPtr); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestGet(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := Get(name); err != nil {
+	if _, err := Get(point); err != nil {
 		t.Fatalf("%v", err)
 	}
 }
 
 func TestList(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
@@ -65,11 +66,11 @@ func TestList(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
-	if err := clienttest.TestSetup(clienttest.ENV_REQD,
+	if err := clienttest.TestSetup(t, clienttest.ENV_REQD,
 		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD); err != nil {
 		t.Fatalf("%v", err)
 	}
-	if _, err := Detach(name); err != nil {
+	if _, err := Detach(point); err != nil {
 		t.Fatalf("%v", err)
 	}
 	if _, err := sharedflows.Delete(name, -1); err != nil {
@@ -25,16 +25,12 @@ import (
 var cliPath = os.Getenv("APIGEECLI_PATH")
 
 func TestCreateApi(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 	var contents []byte
 
@@ -48,15 +44,11 @@ func TestCreateApi(t *testing.T) {
 }
 
 func TestGetApi(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	apiID := "test-api"
 	if _, err = GetApi(apiID); err != nil {
 		t.Errorf("failed to get api: %v", err)
@@ -64,31 +56,23 @@ func TestGetApi(t *testing.T) {
 }
 
 func TestListApi(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	if _, err = ListApi("", -1, ""); err != nil {
 		t.Errorf("failed to list api: %v", err)
 	}
 }
 
 func TestCreateApiVersion(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	var contents []byte
 
 	if contents, err = utils.ReadFile(path.Join(cliPath, "test", "api-ver.json")); err != nil {
@@ -104,16 +88,12 @@ func TestCreateApiVersion(t *testing.T) {
 }
 
 func TestGetApiVersion(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 	versionID := "test-version"
 
@@ -123,16 +103,12 @@ func TestGetApiVersion(t *testing.T) {
 }
 
 func TestListApiVersion(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 
 	if _, err = ListApiVersions(apiID, "", -1, ""); err != nil {
@@ -141,15 +117,11 @@ func TestListApiVersion(t *testing.T) {
 }
 
 func TestCreateApiVersionsSpec(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	var contents []byte
 
 	if contents, err = utils.ReadFile(path.Join(cliPath, "test", "petstore-v3.1.json")); err != nil {
@@ -170,16 +142,12 @@ func TestCreateApiVersionsSpec(t *testing.T) {
 }
 
 func TestGetApiVersionSpec(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 	versionID := "test-version"
 	specID := "test-spec"
@@ -190,16 +158,12 @@ func TestGetApiVersionSpec(t *testing.T) {
 }
 
 func TestGetApiVersionsSpecContents(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 	versionID := "test-version"
 	specID := "test-spec"
@@ -210,16 +174,12 @@ func TestGetApiVersionsSpecContents(t *testing.T) {
 }
 
 func TestLintApiVersionSpecs(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 	versionID := "test-version"
 	specID := "test-spec"
@@ -230,16 +190,12 @@ func TestLintApiVersionSpecs(t *testing.T) {
 }
 
 func TestListApiVersionSpecs(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	apiID := "test-api"
 	versionID := "test-version"
 
@@ -249,15 +205,11 @@ func TestListApiVersionSpecs(t *testing.T) {
 }
 
 func TestDeleteApiVersionSpec(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	apiID := "test-api"
 	versionID := "test-version"
 	specID := "test-spec"
@@ -268,15 +220,11 @@ func TestDeleteApiVersionSpec(t *testing.T) {
 }
 
 func TestDeleteApiVersion(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	apiID := "test-api"
 	versionID := "test-version"
 
@@ -286,33 +234,25 @@ func TestDeleteApiVersion(t *testing.T) {
 }
 
 func TestDeleteApi(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	apiID := "test-api"
 
-	if _, err = DeleteApi(apiID); err != nil {
+	if _, err = DeleteApi(apiID, false); err != nil {
 		t.Errorf("failed to delete api: %v", err)
 	}
 }
 
 func TestCreateAttribute(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	var aValues []byte
 	attributeID := "test-attribute"
 	displayName := "test attribute"
@@ -328,15 +268,11 @@ func TestCreateAttribute(t *testing.T) {
 }
 
 func TestGetAttribute(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	attributeID := "test-attribute"
 
 	if _, err := GetAttribute(attributeID); err != nil {
@@ -345,31 +281,23 @@ func TestGetAttribute(t *testing.T) {
 }
 
 func TestListAttribute(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	if _, err := ListAttributes("", -1, ""); err != nil {
 		t.Errorf("failed to list attributes %v", err)
 	}
 }
 
 func TestDeleteAttribute(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	attributeID := "test-attribute"
 
 	if _, err := DeleteAttribute(attributeID); err != nil {
@@ -378,16 +306,12 @@ func TestDeleteAttribute(t *testing.T) {
 }
 
 func TestCreateDependency(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	dependencyID := "test-dependency"
 	description := "test description"
 	consumerDisplayName := "test consumer"
@@ -405,15 +329,11 @@ func TestCreateDependency(t *testing.T) {
 }
 
 func TestGetDependency(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	dependencyID := "test-dependency"
 
 	if _, err := GetDependency(dependencyID); err != nil {
@@ -422,31 +342,23 @@ func TestGetDependency(t *testing.T) {
 }
 
 func TestListDependencies(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	if _, err := ListDependencies("", -1, ""); err != nil {
 		t.Errorf("failed to list dependencies %v", err)
 	}
 }
 
 func TestDeleteDependency(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	dependencyID := "test-dependency"
 
 	if _, err := DeleteDependency(dependencyID); err != nil {
@@ -455,16 +367,12 @@ func TestDeleteDependency(t *testing.T) {
 }
 
 func TestCreateDeployment(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	deploymentID := "test-deployment"
 	description := "test description"
 	displayName := "test display name"
@@ -472,22 +380,18 @@ func TestCreateDeployment(t *testing.T) {
 	resourceURI := "https://httpbin.org/get"
 	endpoints := []string{"https://httpbin.org/get"}
 
-	if _, err = CreateDeployment(deploymentID, displayName, description, externalURI,
+	if _, err = CreateDeployment(deploymentID, displayName, description, deploymentID, externalURI,
 		resourceURI, endpoints, APIGEE, DEVELOPMENT, SLO99_9); err != nil {
 		t.Errorf("failed to create deployment %v", err)
 	}
 }
 
 func TestGetDeployment(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	deploymentID := "test-deployment"
 
 	if _, err := GetDeployment(deploymentID); err != nil {
@@ -496,31 +400,23 @@ func TestGetDeployment(t *testing.T) {
 }
 
 func TestListDeployment(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	if _, err := ListDeployments("", -1, ""); err != nil {
 		t.Errorf("failed to list deployments %v", err)
 	}
 }
 
 func TestDeleteDeployment(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	deploymentID := "test-deployment"
 
 	if _, err := DeleteDeployment(deploymentID); err != nil {
@@ -529,16 +425,12 @@ func TestDeleteDeployment(t *testing.T) {
 }
 
 func TestCreateExternalApis(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	externalApiId := "test-external-api"
 	description := "test description"
 	displayName := "test display name"
@@ -546,21 +438,17 @@ func TestCreateExternalApis(t *testing.T) {
 	paths := []string{"/get"}
 	endpoints := []string{"https://httpbin.org/get"}
 
-	if _, err = CreateExternalAPI(externalApiId, displayName, description, endpoints, paths, externalURI); err != nil {
+	if _, err = CreateExternalAPI(externalApiId, displayName, description, endpoints, paths, externalURI, "", ""); err != nil {
 		t.Errorf("failed to create external api %v", err)
 	}
 }
 
 func TestGetExternalApi(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	externalApiId := "test-external-api"
 
 	if _, err := GetExternalAPI(externalApiId); err != nil {
@@ -569,15 +457,11 @@ func TestGetExternalApi(t *testing.T) {
 }
 
 func TestGetInstance(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
-
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
 	instanceId := "test-instance"
 
 	if _, err := GetInstance(instanceId); err != nil {
@@ -586,16 +470,12 @@ func TestGetInstance(t *testing.T) {
 }
 
 func TestLookupInstance(t *testing.T) {
-	err := clienttest.TestSetup(clienttest.ENV_NOT_REQD,
-		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD)
+	err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_REQD, clienttest.REGION_REQD)
 	if err != nil {
 		t.Fatalf("setup failed: %v", err)
 	}
 
-	if os.Getenv("APIGEE_REGION") == "" {
-		t.Fatalf("APIGEE_REGION not set")
-	}
-
 	if _, err := LookupInstance(); err != nil {
 		t.Errorf("failed to lookup instances %v", err)
 	}
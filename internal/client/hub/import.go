@@ -0,0 +1,352 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/clilog"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SpecFile is an OpenAPI, Swagger or proto file to import and the hub ids derived from it
+type SpecFile struct {
+	Path        string
+	APIID       string
+	VersionID   string
+	SpecID      string
+	Title       string
+	Version     string
+	Description string
+	// Attributes are the hub attributes of the spec, keyed by ImportAttributes names
+	Attributes map[string]string
+	Contents   []byte
+}
+
+// LintIssue is an issue reported by the API hub linter
+type LintIssue struct {
+	Code     string   `json:"code,omitempty"`
+	Path     []string `json:"path,omitempty"`
+	Message  string   `json:"message,omitempty"`
+	Severity string   `json:"severity,omitempty"`
+}
+
+// ImportResult is the outcome of importing a spec file
+type ImportResult struct {
+	File   string
+	Spec   string
+	Issues []LintIssue
+	Err    error
+}
+
+// ImportAttributes are the attributes read from x- extensions or the sidecar file,
+// and the scope of the hub attribute each one is mapped to
+var ImportAttributes = map[string]string{
+	"team":          "API",
+	"business-unit": "API",
+	"lifecycle":     "VERSION",
+}
+
+// sidecarSuffix is the suffix of the file with the attributes of a spec,
+// for ex: orders.hub.yaml for orders.yaml
+const sidecarSuffix = ".hub.yaml"
+
+var (
+	protoPackage = regexp.MustCompile(`(?m)^\s*package\s+([\w.]+)\s*;`)
+	protoVersion = regexp.MustCompile(`^v\d+`)
+)
+
+// ReadSpecFolder walks a folder for OpenAPI, Swagger and proto files. The API id and the
+// version id are derived from the info block (the package for proto files) and the
+// spec id from the file name
+func ReadSpecFolder(folder string) (specs []SpecFile, err error) {
+	err = filepath.WalkDir(folder, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || strings.HasSuffix(p, sidecarSuffix) {
+			return err
+		}
+		ext := strings.ToLower(filepath.Ext(p))
+		if ext != ".yaml" && ext != ".yml" && ext != ".json" && ext != ".proto" {
+			return nil
+		}
+		contents, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		s, ok, err := ParseSpec(p, contents)
+		if err != nil {
+			return fmt.Errorf("error parsing %s: %w", p, err)
+		}
+		if !ok {
+			clilog.Debug.Printf("skipping %s, not an OpenAPI, Swagger or proto file\n", p)
+			return nil
+		}
+		if err = readSidecar(&s); err != nil {
+			return err
+		}
+		specs = append(specs, s)
+		return nil
+	})
+	return specs, err
+}
+
+// ParseSpec derives the hub ids and attributes of a spec. ok is false when the
+// file is not an OpenAPI, Swagger or proto file
+func ParseSpec(fileName string, contents []byte) (s SpecFile, ok bool, err error) {
+	base := filepath.Base(fileName)
+	s = SpecFile{
+		Path:       fileName,
+		SpecID:     ResourceID(strings.TrimSuffix(base, filepath.Ext(base))),
+		Attributes: map[string]string{},
+		Contents:   contents,
+	}
+
+	if strings.EqualFold(filepath.Ext(base), ".proto") {
+		s.Title, s.Version = strings.TrimSuffix(base, filepath.Ext(base)), "v1"
+		if m := protoPackage.FindSubmatch(contents); m != nil {
+			pkg := strings.Split(string(m[1]), ".")
+			s.Title = strings.Join(pkg, ".")
+			if last := pkg[len(pkg)-1]; len(pkg) > 1 && protoVersion.MatchString(last) {
+				s.Title, s.Version = strings.Join(pkg[:len(pkg)-1], "."), last
+			}
+		}
+		s.APIID, s.VersionID = ResourceID(s.Title), ResourceID(s.Version)
+		return s, true, nil
+	}
+
+	doc := map[string]interface{}{}
+	if err = yaml.Unmarshal(contents, &doc); err != nil {
+		return s, false, err
+	}
+	if doc["openapi"] == nil && doc["swagger"] == nil {
+		return s, false, nil
+	}
+	info, _ := doc["info"].(map[string]interface{})
+	s.Title, _ = info["title"].(string)
+	s.Description, _ = info["description"].(string)
+	s.Version = fmt.Sprint(info["version"])
+	if s.Title == "" || info["version"] == nil {
+		return s, false, fmt.Errorf("info.title and info.version are required")
+	}
+	s.APIID, s.VersionID = ResourceID(s.Title), ResourceID(s.Version)
+
+	// extensions in info take precedence over the top level ones
+	for _, ext := range []map[string]interface{}{doc, info} {
+		for name := range ImportAttributes {
+			if v, ok := ext["x-"+name]; ok {
+				s.Attributes[name] = fmt.Sprint(v)
+			}
+		}
+	}
+	return s, true, nil
+}
+
+// ImportSpecs creates the attributes, APIs, versions and specs of the spec files,
+// updating the ones that exist, and lints each spec. A failure is recorded in the
+// result of the file and the import continues with the next file
+func ImportSpecs(specs []SpecFile, lint bool) (results []ImportResult, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	values := map[string][]string{}
+	for _, s := range specs {
+		for name, v := range s.Attributes {
+			values[name] = append(values[name], v)
+		}
+	}
+	for name, v := range values {
+		if err = ensureAttribute(name, v); err != nil {
+			return nil, err
+		}
+	}
+
+	failed := 0
+	for _, s := range specs {
+		r := ImportResult{File: s.Path, Spec: path.Join("apis", s.APIID, "versions", s.VersionID, "specs", s.SpecID)}
+		if r.Err = importSpec(s); r.Err == nil && lint {
+			r.Issues, r.Err = lintSpec(s)
+		}
+		if r.Err != nil {
+			failed++
+		}
+		results = append(results, r)
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d of %d files failed to import", failed, len(specs))
+	}
+	return results, nil
+}
+
+func importSpec(s SpecFile) (err error) {
+	a := map[string]interface{}{
+		"displayName": s.Title,
+		"description": s.Description,
+		"attributes":  attributeValues(s.Attributes, "API"),
+	}
+	var apiBody, versionBody []byte
+	if apiBody, err = json.Marshal(a); err != nil {
+		return err
+	}
+	if _, err = CreateApi(s.APIID, apiBody); apiclient.IsConflict(err) {
+		_, err = patch(path.Join("apis", s.APIID), "display_name,description,attributes", apiBody)
+	}
+	if err != nil {
+		return fmt.Errorf("error importing api %s: %w", s.APIID, err)
+	}
+
+	v := map[string]interface{}{
+		"displayName": s.Version,
+		"attributes":  attributeValues(s.Attributes, "VERSION"),
+	}
+	if versionBody, err = json.Marshal(v); err != nil {
+		return err
+	}
+	if _, err = CreateApiVersion(s.VersionID, s.APIID, versionBody); apiclient.IsConflict(err) {
+		_, err = UpdateApiVersion(s.VersionID, s.APIID, versionBody)
+	}
+	if err != nil {
+		return fmt.Errorf("error importing version %s: %w", s.VersionID, err)
+	}
+
+	mimeType := filepath.Ext(s.Path)
+	if _, err = CreateApiVersionsSpec(s.APIID, s.VersionID, s.SpecID, filepath.Base(s.Path),
+		s.Contents, mimeType, "", ""); apiclient.IsConflict(err) {
+		_, err = UpdateApiVersionSpec(s.APIID, s.VersionID, s.SpecID, filepath.Base(s.Path), s.Contents, mimeType, "")
+	}
+	if err != nil {
+		return fmt.Errorf("error importing spec %s: %w", s.SpecID, err)
+	}
+	clilog.Info.Printf("Imported %s to apis/%s/versions/%s/specs/%s\n", s.Path, s.APIID, s.VersionID, s.SpecID)
+	return nil
+}
+
+// lintSpec lints an OpenAPI spec and returns the issues found. Proto files are not linted
+func lintSpec(s SpecFile) (issues []LintIssue, err error) {
+	if strings.EqualFold(filepath.Ext(s.Path), ".proto") {
+		return nil, nil
+	}
+	if _, err = LintApiVersionSpec(s.APIID, s.VersionID, s.SpecID); err != nil {
+		return nil, fmt.Errorf("error linting spec %s: %w", s.SpecID, err)
+	}
+	respBody, err := GetApiVersionSpec(s.APIID, s.VersionID, s.SpecID)
+	if err != nil {
+		return nil, err
+	}
+	spec := struct {
+		LintResponse struct {
+			Issues []LintIssue `json:"issues,omitempty"`
+		} `json:"lintResponse,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &spec); err != nil {
+		return nil, err
+	}
+	return spec.LintResponse.Issues, nil
+}
+
+// ensureAttribute creates an enum attribute with the values, or adds the missing
+// values to the allowed values of an existing attribute
+func ensureAttribute(name string, values []string) (err error) {
+	allowed := []allowedValue{}
+	respBody, err := GetAttribute(name)
+	if err != nil && !apiclient.IsNotFound(err) {
+		return err
+	}
+	exists := err == nil
+	if exists {
+		a := struct {
+			AllowedValues []allowedValue `json:"allowedValues,omitempty"`
+		}{}
+		if err = json.Unmarshal(respBody, &a); err != nil {
+			return err
+		}
+		allowed = a.AllowedValues
+	}
+
+	known := map[string]bool{}
+	for _, a := range allowed {
+		known[a.Id] = true
+	}
+	added := false
+	sort.Strings(values)
+	for _, v := range values {
+		if id := ResourceID(v); !known[id] {
+			known[id] = true
+			added = true
+			allowed = append(allowed, allowedValue{Id: id, DisplayName: v})
+		}
+	}
+
+	var allowedBody []byte
+	if allowedBody, err = json.Marshal(allowed); err != nil {
+		return err
+	}
+	if !exists {
+		_, err = CreateAttribute(name, name, "Imported from spec files", ImportAttributes[name],
+			"ENUM", allowedBody, 1)
+		if err == nil {
+			clilog.Info.Printf("Created attribute %s\n", name)
+		}
+		return err
+	}
+	if added {
+		_, err = UpdateAttribute(name, "allowed_values", "", "", "", "", allowedBody, 1)
+	}
+	return err
+}
+
+// attributeValues returns the hub attribute values of the given scope
+func attributeValues(attributes map[string]string, scope string) map[string]interface{} {
+	values := map[string]interface{}{}
+	for name, v := range attributes {
+		if ImportAttributes[name] != scope {
+			continue
+		}
+		values[resourceName("attributes", name)] = getAttributeValues(allowedValue{Id: ResourceID(v), DisplayName: v})
+	}
+	return values
+}
+
+// readSidecar reads the attributes of a spec from its sidecar file, which override the
+// x- extensions of the spec. The sidecar is a YAML map, for ex: team: payments
+func readSidecar(s *SpecFile) error {
+	sidecar := strings.TrimSuffix(s.Path, filepath.Ext(s.Path)) + sidecarSuffix
+	contents, err := os.ReadFile(sidecar)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	m := map[string]interface{}{}
+	if err = yaml.Unmarshal(contents, &m); err != nil {
+		return fmt.Errorf("error parsing %s: %w", sidecar, err)
+	}
+	for name, v := range m {
+		if _, ok := ImportAttributes[name]; !ok {
+			return fmt.Errorf("unknown attribute %s in %s", name, sidecar)
+		}
+		s.Attributes[name] = fmt.Sprint(v)
+	}
+	return nil
+}
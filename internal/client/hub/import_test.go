@@ -0,0 +1,76 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"internal/clilog"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadSpecFolder(t *testing.T) {
+	clilog.Init(false, false, true, false)
+	folder := t.TempDir()
+	files := map[string]string{
+		"orders.yaml": `openapi: 3.0.0
+x-team: platform
+info:
+  title: Orders API
+  version: 1.2.0
+  x-team: payments
+  x-lifecycle: production
+paths: {}
+`,
+		"orders.hub.yaml": "business-unit: retail\n",
+		"petstore.json":   `{"swagger": "2.0", "info": {"title": "Petstore", "version": "1"}}`,
+		"greeter.proto":   "syntax = \"proto3\";\npackage example.greeter.v2;\n",
+		"config.yaml":     "key: value\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	specs, err := ReadSpecFolder(folder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 3 {
+		t.Fatalf("found %d specs, want 3", len(specs))
+	}
+	got := map[string]SpecFile{}
+	for _, s := range specs {
+		got[filepath.Base(s.Path)] = s
+	}
+
+	orders := got["orders.yaml"]
+	if orders.APIID != "orders-api" || orders.VersionID != "1-2-0" || orders.SpecID != "orders" {
+		t.Fatalf("unexpected ids %s %s %s", orders.APIID, orders.VersionID, orders.SpecID)
+	}
+	want := map[string]string{"team": "payments", "lifecycle": "production", "business-unit": "retail"}
+	for k, v := range want {
+		if orders.Attributes[k] != v {
+			t.Fatalf("attribute %s = %s, want %s", k, orders.Attributes[k], v)
+		}
+	}
+	if got["petstore.json"].APIID != "petstore" || got["petstore.json"].VersionID != "1" {
+		t.Fatalf("unexpected swagger ids %+v", got["petstore.json"])
+	}
+	if greeter := got["greeter.proto"]; greeter.APIID != "example-greeter" || greeter.VersionID != "v2" {
+		t.Fatalf("unexpected proto ids %s %s", greeter.APIID, greeter.VersionID)
+	}
+}
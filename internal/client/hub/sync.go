@@ -0,0 +1,526 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"archive/zip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/apis"
+	"internal/client/env"
+	"internal/client/envgroups"
+	"internal/clilog"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Inventory is the state of the Apigee proxies to sync to API hub
+type Inventory struct {
+	Org     string
+	Proxies []ProxyInventory
+	// AllProxies are the names of every proxy in the org, to find stale hub APIs
+	AllProxies map[string]bool
+}
+
+// ProxyInventory is a proxy and its deployed revisions
+type ProxyInventory struct {
+	Name      string
+	Revisions []RevisionInventory
+}
+
+// RevisionInventory is a deployed revision, where it is reachable and its OAS specs
+type RevisionInventory struct {
+	Revision  int
+	Endpoints []Endpoint
+	// Specs are the files in resources/oas of the bundle
+	Specs map[string][]byte
+}
+
+// Endpoint is a base path of a revision served on an env group hostname
+type Endpoint struct {
+	Environment string
+	Hostname    string
+	BasePath    string
+}
+
+// SyncReport lists the API hub resources written by Sync and the stale ones
+type SyncReport struct {
+	Created []string
+	Updated []string
+	Stale   []string
+}
+
+const consoleProxyURL = "https://console.cloud.google.com/apigee/proxies/"
+
+var invalidIDChars = regexp.MustCompile(`[^a-z0-9-]+`)
+
+// ReadInventory lists the proxies of the org, their revisions deployed to each environment,
+// the env group hostnames of the environments and the OAS specs of the deployed bundles.
+// With an empty list of names, every proxy is read
+func ReadInventory(names []string) (inv Inventory, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	inv = Inventory{Org: apiclient.GetApigeeOrg(), AllProxies: map[string]bool{}}
+
+	respBody, err := apis.ListProxies(false, "")
+	if err != nil {
+		return inv, err
+	}
+	proxyList := struct {
+		Proxies []struct {
+			Name string `json:"name,omitempty"`
+		} `json:"proxies,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &proxyList); err != nil {
+		return inv, err
+	}
+	for _, p := range proxyList.Proxies {
+		inv.AllProxies[p.Name] = true
+	}
+	if len(names) == 0 {
+		for name := range inv.AllProxies {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+	}
+
+	hostnames, err := envHostnames()
+	if err != nil {
+		return inv, err
+	}
+	deployed, err := deployedRevisions()
+	if err != nil {
+		return inv, err
+	}
+
+	folder, err := os.MkdirTemp("", "apigeecli-hub-sync")
+	if err != nil {
+		return inv, err
+	}
+	defer os.RemoveAll(folder)
+
+	for _, name := range names {
+		if !inv.AllProxies[name] {
+			return inv, fmt.Errorf("proxy %s not found", name)
+		}
+		p := ProxyInventory{Name: name}
+		revisions := []int{}
+		for revision := range deployed[name] {
+			revisions = append(revisions, revision)
+		}
+		sort.Ints(revisions)
+		for _, revision := range revisions {
+			r, err := readRevision(folder, name, revision, deployed[name][revision], hostnames)
+			if err != nil {
+				return inv, err
+			}
+			p.Revisions = append(p.Revisions, r)
+		}
+		inv.Proxies = append(inv.Proxies, p)
+	}
+	return inv, nil
+}
+
+// Sync creates or updates an API hub API for each proxy of the inventory, a version for each
+// deployed revision, a deployment for each env group hostname and base path, and a spec for
+// each OAS file in the bundle. Hub resources of the org that no longer match a proxy, revision
+// or deployment are reported as stale and left in place
+func Sync(inv Inventory) (report SyncReport, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	synced := map[string]bool{}
+	for _, p := range inv.Proxies {
+		apiID := ResourceID(p.Name)
+		consoleURL := consoleProxyURL + p.Name + "/overview?project=" + inv.Org
+
+		a := map[string]interface{}{
+			"displayName":   p.Name,
+			"description":   "Apigee API proxy " + p.Name + " in " + inv.Org,
+			"documentation": map[string]string{"externalUri": consoleURL},
+		}
+		var apiBody []byte
+		if apiBody, err = json.Marshal(a); err != nil {
+			return report, err
+		}
+		if err = upsert(&report, "apis/"+apiID, func() ([]byte, error) {
+			return CreateApi(apiID, apiBody)
+		}, func() ([]byte, error) {
+			return patch(path.Join("apis", apiID), "display_name,description,documentation", apiBody)
+		}); err != nil {
+			return report, err
+		}
+
+		versions := map[string]bool{}
+		for _, r := range p.Revisions {
+			deploymentNames := []string{}
+			for _, e := range r.Endpoints {
+				deploymentID := ResourceID(p.Name + "-" + e.Environment + "-" + e.Hostname + e.BasePath)
+				synced[deploymentID] = true
+				endpoints := []string{"https://" + e.Hostname + e.BasePath}
+				displayName := p.Name + " on " + e.Hostname + e.BasePath
+				description := "Revision " + strconv.Itoa(r.Revision) + " of " + p.Name + " in " + e.Environment
+				if err = upsert(&report, "deployments/"+deploymentID, func() ([]byte, error) {
+					return CreateDeployment(deploymentID, displayName, description, "", consoleURL,
+						consoleURL, endpoints, APIGEE, "", "")
+				}, func() ([]byte, error) {
+					return UpdateDeployment(deploymentID, displayName, description, consoleURL,
+						consoleURL, endpoints, APIGEE, "", "")
+				}); err != nil {
+					return report, err
+				}
+				deploymentNames = append(deploymentNames, resourceName("deployments", deploymentID))
+			}
+
+			versionID := "rev-" + strconv.Itoa(r.Revision)
+			versions[versionID] = true
+			v := map[string]interface{}{
+				"displayName": "Revision " + strconv.Itoa(r.Revision),
+				"deployments": deploymentNames,
+			}
+			var versionBody []byte
+			if versionBody, err = json.Marshal(v); err != nil {
+				return report, err
+			}
+			if err = upsert(&report, path.Join("apis", apiID, "versions", versionID), func() ([]byte, error) {
+				return CreateApiVersion(versionID, apiID, versionBody)
+			}, func() ([]byte, error) {
+				return UpdateApiVersion(versionID, apiID, versionBody)
+			}); err != nil {
+				return report, err
+			}
+
+			files := []string{}
+			for file := range r.Specs {
+				files = append(files, file)
+			}
+			sort.Strings(files)
+			for _, file := range files {
+				specID := ResourceID(strings.TrimSuffix(file, path.Ext(file)))
+				if err = upsert(&report, path.Join("apis", apiID, "versions", versionID, "specs", specID), func() ([]byte, error) {
+					return CreateApiVersionsSpec(apiID, versionID, specID, file, r.Specs[file], path.Ext(file), "", "")
+				}, func() ([]byte, error) {
+					return UpdateApiVersionSpec(apiID, versionID, specID, file, r.Specs[file], path.Ext(file), "")
+				}); err != nil {
+					return report, err
+				}
+			}
+		}
+
+		stale, err := staleVersions(apiID, versions)
+		if err != nil {
+			return report, err
+		}
+		report.Stale = append(report.Stale, stale...)
+	}
+
+	stale, err := staleDeployments(inv, synced)
+	if err != nil {
+		return report, err
+	}
+	report.Stale = append(report.Stale, stale...)
+
+	if stale, err = staleApis(inv); err != nil {
+		return report, err
+	}
+	report.Stale = append(report.Stale, stale...)
+	return report, nil
+}
+
+// ResourceID converts a name to an API hub resource id: lowercase letters, digits and
+// hyphens, at most 63 characters. Long names are shortened with a hash suffix
+func ResourceID(name string) string {
+	id := strings.Trim(invalidIDChars.ReplaceAllString(strings.ToLower(name), "-"), "-")
+	if len(id) <= 63 {
+		return id
+	}
+	sum := sha256.Sum256([]byte(name))
+	return strings.TrimRight(id[:54], "-") + "-" + hex.EncodeToString(sum[:])[:8]
+}
+
+// envHostnames returns the env group hostnames each environment is attached to
+func envHostnames() (hostnames map[string][]string, err error) {
+	respBody, err := envgroups.List()
+	if err != nil {
+		return nil, err
+	}
+	groups := struct {
+		EnvironmentGroups []struct {
+			Name      string   `json:"name,omitempty"`
+			Hostnames []string `json:"hostnames,omitempty"`
+		} `json:"environmentGroups,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &groups); err != nil {
+		return nil, err
+	}
+	hostnames = map[string][]string{}
+	for _, g := range groups.EnvironmentGroups {
+		if respBody, err = envgroups.ListAttach(g.Name); err != nil {
+			return nil, err
+		}
+		attachments := struct {
+			Attachments []struct {
+				Environment string `json:"environment,omitempty"`
+			} `json:"environmentGroupAttachments,omitempty"`
+		}{}
+		if err = json.Unmarshal(respBody, &attachments); err != nil {
+			return nil, err
+		}
+		for _, a := range attachments.Attachments {
+			hostnames[a.Environment] = append(hostnames[a.Environment], g.Hostnames...)
+		}
+	}
+	return hostnames, nil
+}
+
+// deployedRevisions returns the environments each proxy revision is deployed to
+func deployedRevisions() (deployed map[string]map[int][]string, err error) {
+	respBody, err := env.List()
+	if err != nil {
+		return nil, err
+	}
+	environments := []string{}
+	if err = json.Unmarshal(respBody, &environments); err != nil {
+		return nil, err
+	}
+
+	defer apiclient.SetApigeeEnv(apiclient.GetApigeeEnv())
+	deployed = map[string]map[int][]string{}
+	for _, environment := range environments {
+		apiclient.SetApigeeEnv(environment)
+		if respBody, err = env.GetDeployments(false); err != nil {
+			return nil, err
+		}
+		deployments := struct {
+			Deployments []struct {
+				APIProxy string `json:"apiProxy,omitempty"`
+				Revision string `json:"revision,omitempty"`
+			} `json:"deployments,omitempty"`
+		}{}
+		if err = json.Unmarshal(respBody, &deployments); err != nil {
+			return nil, err
+		}
+		for _, d := range deployments.Deployments {
+			revision, err := strconv.Atoi(d.Revision)
+			if err != nil {
+				continue
+			}
+			if deployed[d.APIProxy] == nil {
+				deployed[d.APIProxy] = map[int][]string{}
+			}
+			deployed[d.APIProxy][revision] = append(deployed[d.APIProxy][revision], environment)
+		}
+	}
+	return deployed, nil
+}
+
+// readRevision reads the base paths and the OAS specs of a deployed revision
+func readRevision(folder string, name string, revision int, environments []string,
+	hostnames map[string][]string,
+) (r RevisionInventory, err error) {
+	r = RevisionInventory{Revision: revision}
+
+	respBody, err := apis.GetProxy(name, revision)
+	if err != nil {
+		return r, err
+	}
+	proxyRevision := struct {
+		Basepaths []string `json:"basepaths,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &proxyRevision); err != nil {
+		return r, err
+	}
+	sort.Strings(environments)
+	for _, environment := range environments {
+		for _, hostname := range hostnames[environment] {
+			for _, basePath := range proxyRevision.Basepaths {
+				r.Endpoints = append(r.Endpoints, Endpoint{
+					Environment: environment, Hostname: hostname, BasePath: basePath,
+				})
+			}
+		}
+	}
+
+	if err = apiclient.FetchBundle("apis", folder, name, strconv.Itoa(revision), false); err != nil {
+		return r, err
+	}
+	r.Specs, err = bundleSpecs(path.Join(folder, name+".zip"))
+	return r, err
+}
+
+// bundleSpecs returns the files in the resources/oas folder of a proxy bundle
+func bundleSpecs(bundle string) (specs map[string][]byte, err error) {
+	z, err := zip.OpenReader(bundle)
+	if err != nil {
+		return nil, err
+	}
+	defer z.Close()
+	specs = map[string][]byte{}
+	for _, f := range z.File {
+		if path.Base(path.Dir(f.Name)) != "oas" || !strings.HasSuffix(path.Dir(path.Dir(f.Name)), "resources") {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		contents, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		specs[path.Base(f.Name)] = contents
+	}
+	return specs, nil
+}
+
+// upsert creates a resource and updates it when it already exists
+func upsert(report *SyncReport, resource string, create func() ([]byte, error), update func() ([]byte, error)) error {
+	_, err := create()
+	if err == nil {
+		clilog.Info.Printf("Created %s\n", resource)
+		report.Created = append(report.Created, resource)
+		return nil
+	}
+	if !apiclient.IsConflict(err) {
+		return fmt.Errorf("error creating %s: %w", resource, err)
+	}
+	if _, err = update(); err != nil {
+		return fmt.Errorf("error updating %s: %w", resource, err)
+	}
+	clilog.Info.Printf("Updated %s\n", resource)
+	report.Updated = append(report.Updated, resource)
+	return nil
+}
+
+// staleVersions returns the versions of a hub API that are not a deployed revision
+func staleVersions(apiID string, versions map[string]bool) (stale []string, err error) {
+	l, err := listAll(func(pageToken string) ([]byte, error) {
+		return ListApiVersions(apiID, "", -1, pageToken)
+	}, "versions")
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range l {
+		versionID := path.Base(fmt.Sprint(v["name"]))
+		if strings.HasPrefix(versionID, "rev-") && !versions[versionID] {
+			stale = append(stale, path.Join("apis", apiID, "versions", versionID))
+		}
+	}
+	return stale, nil
+}
+
+// staleDeployments returns the hub deployments of the synced proxies that were not synced
+func staleDeployments(inv Inventory, synced map[string]bool) (stale []string, err error) {
+	proxies := map[string]bool{}
+	for _, p := range inv.Proxies {
+		proxies[p.Name] = true
+	}
+	l, err := listAll(func(pageToken string) ([]byte, error) {
+		return ListDeployments("", -1, pageToken)
+	}, "deployments")
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range l {
+		deploymentID := path.Base(fmt.Sprint(d["name"]))
+		resourceURI, _ := d["resourceUri"].(string)
+		if name, ok := consoleProxy(resourceURI, inv.Org); ok && proxies[name] && !synced[deploymentID] {
+			stale = append(stale, "deployments/"+deploymentID)
+		}
+	}
+	return stale, nil
+}
+
+// staleApis returns the hub APIs synced from proxies that no longer exist
+func staleApis(inv Inventory) (stale []string, err error) {
+	l, err := listAll(func(pageToken string) ([]byte, error) {
+		return ListApi("", -1, pageToken)
+	}, "apis")
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range l {
+		documentation, _ := a["documentation"].(map[string]interface{})
+		externalURI, _ := documentation["externalUri"].(string)
+		if name, ok := consoleProxy(externalURI, inv.Org); ok && !inv.AllProxies[name] {
+			stale = append(stale, "apis/"+path.Base(fmt.Sprint(a["name"])))
+		}
+	}
+	return stale, nil
+}
+
+// consoleProxy returns the proxy of a console url set by Sync for the org
+func consoleProxy(consoleURL string, org string) (name string, ok bool) {
+	rest, found := strings.CutPrefix(consoleURL, consoleProxyURL)
+	if !found {
+		return "", false
+	}
+	name, query, _ := strings.Cut(rest, "/overview?")
+	values, err := url.ParseQuery(query)
+	if err != nil || values.Get("project") != org {
+		return "", false
+	}
+	return name, true
+}
+
+// listAll reads every page of a hub collection
+func listAll(list func(pageToken string) ([]byte, error), key string) (items []map[string]interface{}, err error) {
+	pageToken := ""
+	for {
+		respBody, err := list(pageToken)
+		if err != nil {
+			return nil, err
+		}
+		page := map[string]interface{}{}
+		if err = json.Unmarshal(respBody, &page); err != nil {
+			return nil, err
+		}
+		l, _ := page[key].([]interface{})
+		for _, item := range l {
+			if m, ok := item.(map[string]interface{}); ok {
+				items = append(items, m)
+			}
+		}
+		if pageToken, _ = page["nextPageToken"].(string); pageToken == "" {
+			return items, nil
+		}
+	}
+}
+
+// resourceName returns the full name of a hub resource, for ex: projects/p/locations/l/deployments/d
+func resourceName(collection string, id string) string {
+	u, _ := url.Parse(apiclient.GetApigeeRegistryURL())
+	return path.Join(strings.TrimPrefix(u.Path, "/v1/"), collection, id)
+}
+
+func patch(resource string, updateMask string, contents []byte) (respBody []byte, err error) {
+	u, _ := url.Parse(apiclient.GetApigeeRegistryURL())
+	u.Path = path.Join(u.Path, resource)
+	q := u.Query()
+	q.Set("updateMask", updateMask)
+	u.RawQuery = q.Encode()
+	return apiclient.HttpClient(u.String(), string(contents), "PATCH")
+}
@@ -0,0 +1,83 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hub
+
+import (
+	"archive/zip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResourceID(t *testing.T) {
+	if id := ResourceID("Orders_API v2"); id != "orders-api-v2" {
+		t.Fatalf("ResourceID = %s, want orders-api-v2", id)
+	}
+	long := ResourceID(strings.Repeat("a", 40) + "-test-api.example.com/orders/v1")
+	if len(long) > 63 {
+		t.Fatalf("ResourceID %s is longer than 63 characters", long)
+	}
+	if long == ResourceID(strings.Repeat("a", 40)+"-test-api.example.com/orders/v2") {
+		t.Fatalf("long names must not collide")
+	}
+}
+
+func TestConsoleProxy(t *testing.T) {
+	u := consoleProxyURL + "orders/overview?project=my-org"
+	if name, ok := consoleProxy(u, "my-org"); !ok || name != "orders" {
+		t.Fatalf("consoleProxy = %s, %v", name, ok)
+	}
+	if _, ok := consoleProxy(u, "other-org"); ok {
+		t.Fatalf("console url of another org must not match")
+	}
+	if _, ok := consoleProxy("https://example.com/docs", "my-org"); ok {
+		t.Fatalf("external url must not match")
+	}
+}
+
+func TestBundleSpecs(t *testing.T) {
+	bundle := filepath.Join(t.TempDir(), "orders.zip")
+	f, err := os.Create(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := zip.NewWriter(f)
+	for name, content := range map[string]string{
+		"apiproxy/orders.xml":                "<APIProxy/>",
+		"apiproxy/resources/oas/orders.yaml": "openapi: 3.0.0",
+		"apiproxy/resources/jsc/script.js":   "var a;",
+	} {
+		fw, err := w.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = fw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	f.Close()
+
+	specs, err := bundleSpecs(bundle)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(specs) != 1 || string(specs["orders.yaml"]) != "openapi: 3.0.0" {
+		t.Fatalf("unexpected specs %v", specs)
+	}
+}
@@ -163,7 +163,7 @@ func importKeystores(wg *sync.WaitGroup, jobs <-chan string, errs chan<- error)
 			continue
 		}
 
-		req, err := http.NewRequest(http.MethodPost, u.String(), nil)
+		req, err := http.NewRequestWithContext(apiclient.GetContext(), http.MethodPost, u.String(), nil)
 		if err != nil {
 			errs <- err
 			continue
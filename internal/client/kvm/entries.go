@@ -116,10 +116,12 @@ func upsertEntry(proxyName string, mapName string, keyName string, value string,
 	update := false
 	apiclient.ClientPrintHttpResponse.Set(false)
 	_, err = GetEntry(proxyName, mapName, keyName)
+	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	if err == nil {
 		update = true
+	} else if !apiclient.IsNotFound(err) {
+		return nil, err
 	}
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	if update {
 		clilog.Info.Printf("Updating entry in map [%s] with key [%s]\n", mapName, keyName)
 		return UpdateEntry(proxyName, mapName, keyName, value, stringyfied)
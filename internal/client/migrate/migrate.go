@@ -0,0 +1,532 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/apis"
+	"internal/client/apps"
+	"internal/client/developers"
+	"internal/client/env"
+	"internal/client/envgroups"
+	"internal/client/products"
+	"internal/client/sharedflows"
+	"internal/clilog"
+	"io"
+	"os"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	proxiesFolderName     = "proxies"
+	sharedFlowsFolderName = "sharedflows"
+	productsFileName      = "products.json"
+	developersFileName    = "developers.json"
+	appsFileName          = "apps.json"
+	envGroupsFileName     = "envgroups.json"
+	attachmentsFileName   = "envgroupattachments.json"
+)
+
+// Filter selects the entities to migrate, dependencies are added by Resolve
+type Filter struct {
+	Proxies    []string
+	Products   []string
+	Developers []string
+	EnvGroups  []string
+}
+
+// Options of a migration between two orgs
+type Options struct {
+	Filter
+	SourceOrg   string
+	TargetOrg   string
+	EnvMap      map[string]string // source environment name to target environment name
+	EnvGroupMap map[string]string // source env group name to target env group name
+	Folder      string
+	Conn        int
+	Space       string
+}
+
+// Selection is the set of entities to migrate, including the transitive dependencies
+type Selection struct {
+	Proxies     []string `json:"proxies,omitempty"`
+	SharedFlows []string `json:"sharedFlows,omitempty"`
+	Products    []string `json:"products,omitempty"`
+	Developers  []string `json:"developers,omitempty"`
+	Apps        []string `json:"apps,omitempty"`
+	EnvGroups   []string `json:"envGroups,omitempty"`
+
+	products map[string]map[string]interface{}
+	apps     []map[string]interface{}
+}
+
+// sharedFlowRef matches the sharedflow referenced by a FlowCallout policy
+var sharedFlowRef = regexp.MustCompile(`<SharedFlowBundle>\s*([^<\s]+)\s*</SharedFlowBundle>`)
+
+// Run copies the selected entities and their dependencies from the source org to
+// the target org. The entities are written to the folder in the layout of org export
+func Run(o Options) (s Selection, err error) {
+	defer apiclient.SetApigeeOrg(apiclient.GetApigeeOrg())
+
+	// environments that do not exist in the target are dropped from products
+	if err = apiclient.SetApigeeOrg(o.TargetOrg); err != nil {
+		return s, err
+	}
+	targetEnvs, err := listEnvironments()
+	if err != nil {
+		return s, err
+	}
+
+	if err = apiclient.SetApigeeOrg(o.SourceOrg); err != nil {
+		return s, err
+	}
+	clilog.Info.Printf("Resolving dependencies in org %s\n", o.SourceOrg)
+	if s, err = Resolve(o.Filter, o.Folder); err != nil {
+		return s, err
+	}
+	clilog.Info.Printf("Exporting %s\n", s)
+	if err = Export(s, o.Folder, o.EnvMap, o.EnvGroupMap, targetEnvs); err != nil {
+		return s, err
+	}
+
+	if err = apiclient.SetApigeeOrg(o.TargetOrg); err != nil {
+		return s, err
+	}
+	clilog.Info.Printf("Importing to org %s\n", o.TargetOrg)
+	return s, Import(o.Folder, o.Conn, o.Space)
+}
+
+// Resolve adds the dependencies of the filter in the current org: developers bring
+// their apps, apps bring their products, products bring their proxies and proxies
+// bring the sharedflows they call. Bundles are downloaded to the folder
+func Resolve(f Filter, folder string) (s Selection, err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for _, folderName := range []string{proxiesFolderName, sharedFlowsFolderName} {
+		if err = os.MkdirAll(path.Join(folder, folderName), 0o755); err != nil {
+			return s, err
+		}
+	}
+
+	productNames, proxyNames := newSet(f.Products...), newSet(f.Proxies...)
+	s.products = map[string]map[string]interface{}{}
+
+	for _, email := range f.Developers {
+		respBody, err := developers.GetApps(email, true)
+		if err != nil {
+			return s, fmt.Errorf("error listing apps of developer %s: %w", email, err)
+		}
+		l := struct {
+			App []map[string]interface{} `json:"app,omitempty"`
+		}{}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return s, err
+		}
+		for _, app := range l.App {
+			s.apps = append(s.apps, app)
+			s.Apps = append(s.Apps, email+"/"+fmt.Sprint(app["name"]))
+			productNames.add(appProducts(app)...)
+		}
+	}
+	s.Developers = newSet(f.Developers...).sorted()
+
+	for _, name := range productNames.sorted() {
+		respBody, err := products.Get(name)
+		if err != nil {
+			return s, fmt.Errorf("error reading product %s: %w", name, err)
+		}
+		p := map[string]interface{}{}
+		if err = json.Unmarshal(respBody, &p); err != nil {
+			return s, err
+		}
+		s.products[name] = p
+		proxyNames.add(productProxies(respBody)...)
+	}
+	s.Products = productNames.sorted()
+
+	for _, name := range proxyNames.sorted() {
+		refs, err := fetchLatest("apis", apis.GetProxy, path.Join(folder, proxiesFolderName), name)
+		if err != nil {
+			return s, err
+		}
+		s.Proxies = append(s.Proxies, name)
+		s.SharedFlows = append(s.SharedFlows, refs...)
+	}
+
+	// sharedflows may call other sharedflows
+	sharedFlows := newSet()
+	pending := s.SharedFlows
+	for len(pending) > 0 {
+		name := pending[0]
+		pending = pending[1:]
+		if sharedFlows[name] {
+			continue
+		}
+		sharedFlows.add(name)
+		refs, err := fetchLatest("sharedflows", sharedflows.Get, path.Join(folder, sharedFlowsFolderName), name)
+		if err != nil {
+			return s, err
+		}
+		pending = append(pending, refs...)
+	}
+	s.SharedFlows = sharedFlows.sorted()
+	s.EnvGroups = newSet(f.EnvGroups...).sorted()
+
+	return s, nil
+}
+
+// Export writes the products, developers, apps and env groups of the selection to the
+// folder. Environments and env groups are renamed with the maps, and environments
+// missing from targetEnvs are removed from products
+func Export(s Selection, folder string, envMap map[string]string, envGroupMap map[string]string,
+	targetEnvs map[string]bool,
+) (err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	productList := []map[string]interface{}{}
+	for _, name := range s.Products {
+		p := s.products[name]
+		environments := []string{}
+		envs, _ := p["environments"].([]interface{})
+		for _, e := range envs {
+			e := rename(fmt.Sprint(e), envMap)
+			if targetEnvs != nil && !targetEnvs[e] {
+				clilog.Warning.Printf("Environment %s of product %s does not exist in the target org\n", e, name)
+				continue
+			}
+			environments = append(environments, e)
+		}
+		p["environments"] = environments
+		productList = append(productList, p)
+	}
+	if err = writeJSON(path.Join(folder, productsFileName), productList); err != nil {
+		return err
+	}
+
+	devs := developers.Appdevelopers{}
+	for _, email := range s.Developers {
+		respBody, err := developers.Get(email)
+		if err != nil {
+			return fmt.Errorf("error reading developer %s: %w", email, err)
+		}
+		d := developers.Appdeveloper{}
+		if err = json.Unmarshal(respBody, &d); err != nil {
+			return err
+		}
+		devs.Developer = append(devs.Developer, d)
+	}
+	if err = writeJSON(path.Join(folder, developersFileName), devs); err != nil {
+		return err
+	}
+	if err = writeJSON(path.Join(folder, appsFileName), s.apps); err != nil {
+		return err
+	}
+
+	groups := struct {
+		EnvironmentGroups []map[string]interface{} `json:"environmentGroups"`
+	}{EnvironmentGroups: []map[string]interface{}{}}
+	attachments := map[string][]string{}
+	for _, name := range s.EnvGroups {
+		respBody, err := envgroups.Get(name)
+		if err != nil {
+			return fmt.Errorf("error reading env group %s: %w", name, err)
+		}
+		g := map[string]interface{}{}
+		if err = json.Unmarshal(respBody, &g); err != nil {
+			return err
+		}
+		targetName := rename(name, envGroupMap)
+		groups.EnvironmentGroups = append(groups.EnvironmentGroups,
+			map[string]interface{}{"name": targetName, "hostnames": g["hostnames"]})
+
+		if respBody, err = envgroups.ListAttach(name); err != nil {
+			return err
+		}
+		l := struct {
+			Attachments []struct {
+				Environment string `json:"environment,omitempty"`
+			} `json:"environmentGroupAttachments,omitempty"`
+		}{}
+		if err = json.Unmarshal(respBody, &l); err != nil {
+			return err
+		}
+		attachments[targetName] = []string{}
+		for _, a := range l.Attachments {
+			e := rename(a.Environment, envMap)
+			if targetEnvs != nil && !targetEnvs[e] {
+				clilog.Warning.Printf("Environment %s of env group %s does not exist in the target org\n", e, name)
+				continue
+			}
+			attachments[targetName] = append(attachments[targetName], e)
+		}
+	}
+	if err = writeJSON(path.Join(folder, envGroupsFileName), groups); err != nil {
+		return err
+	}
+	return writeJSON(path.Join(folder, attachmentsFileName), attachments)
+}
+
+// Import creates the entities written by Export in the current org. Sharedflows are
+// imported before the proxies that call them and products before the apps
+func Import(folder string, conn int, space string) (err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	clilog.Info.Println("Importing Sharedflows...")
+	if err = sharedflows.Import(conn, path.Join(folder, sharedFlowsFolderName), space); err != nil {
+		return err
+	}
+	clilog.Info.Println("Importing API Proxies...")
+	if err = apis.ImportProxies(conn, path.Join(folder, proxiesFolderName), space); err != nil {
+		return err
+	}
+	clilog.Info.Println("Importing Products...")
+	if err = products.Import(conn, path.Join(folder, productsFileName), true); err != nil {
+		return err
+	}
+	clilog.Info.Println("Importing Developers...")
+	if err = developers.Import(conn, path.Join(folder, developersFileName)); err != nil {
+		return err
+	}
+	clilog.Info.Println("Importing Apps...")
+	if err = apps.Import(conn, path.Join(folder, appsFileName), path.Join(folder, developersFileName)); err != nil {
+		return err
+	}
+
+	clilog.Info.Println("Importing Environment Groups...")
+	return importEnvGroups(folder)
+}
+
+func importEnvGroups(folder string) error {
+	byteValue, err := os.ReadFile(path.Join(folder, envGroupsFileName))
+	if err != nil {
+		return err
+	}
+	groups := struct {
+		EnvironmentGroups []struct {
+			Name      string   `json:"name"`
+			Hostnames []string `json:"hostnames"`
+		} `json:"environmentGroups"`
+	}{}
+	if err = json.Unmarshal(byteValue, &groups); err != nil {
+		return err
+	}
+	if byteValue, err = os.ReadFile(path.Join(folder, attachmentsFileName)); err != nil {
+		return err
+	}
+	attachments := map[string][]string{}
+	if err = json.Unmarshal(byteValue, &attachments); err != nil {
+		return err
+	}
+
+	errs := []error{}
+	for _, g := range groups.EnvironmentGroups {
+		if _, err = envgroups.Get(g.Name); apiclient.IsNotFound(err) {
+			_, err = envgroups.Create(g.Name, g.Hostnames)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("env group %s not imported: %w", g.Name, err))
+			continue
+		}
+		for _, e := range attachments[g.Name] {
+			if _, err = envgroups.Attach(g.Name, e); err != nil && !apiclient.IsConflict(err) {
+				errs = append(errs, fmt.Errorf("environment %s not attached to %s: %w", e, g.Name, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// fetchLatest downloads the latest revision of a proxy or sharedflow and returns
+// the sharedflows it calls
+func fetchLatest(entityType string, get func(name string, revision int) ([]byte, error),
+	folder string, name string,
+) (refs []string, err error) {
+	respBody, err := get(name, -1)
+	if err != nil {
+		return nil, fmt.Errorf("error reading %s %s: %w", entityType, name, err)
+	}
+	entity := struct {
+		Revision []string `json:"revision,omitempty"`
+	}{}
+	if err = json.Unmarshal(respBody, &entity); err != nil {
+		return nil, err
+	}
+	latest := -1
+	for _, r := range entity.Revision {
+		if n, err := strconv.Atoi(r); err == nil && n > latest {
+			latest = n
+		}
+	}
+	if latest < 0 {
+		return nil, fmt.Errorf("%s %s has no revisions", entityType, name)
+	}
+	if err = apiclient.FetchBundle(entityType, folder, name, strconv.Itoa(latest), false); err != nil {
+		return nil, err
+	}
+	return bundleSharedFlows(path.Join(folder, name+".zip"))
+}
+
+// bundleSharedFlows returns the sharedflows called by the FlowCallout policies of a bundle
+func bundleSharedFlows(bundle string) (refs []string, err error) {
+	r, err := zip.OpenReader(bundle)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	found := newSet()
+	for _, f := range r.File {
+		if !strings.Contains(f.Name, "/policies/") || path.Ext(f.Name) != ".xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		contents, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range sharedFlowRef.FindAllSubmatch(contents, -1) {
+			found.add(string(m[1]))
+		}
+	}
+	return found.sorted(), nil
+}
+
+// appProducts returns the products of all the credentials of an app
+func appProducts(app map[string]interface{}) (names []string) {
+	creds, _ := app["credentials"].([]interface{})
+	for _, c := range creds {
+		cred, _ := c.(map[string]interface{})
+		prods, _ := cred["apiProducts"].([]interface{})
+		for _, p := range prods {
+			if prod, ok := p.(map[string]interface{}); ok {
+				names = append(names, fmt.Sprint(prod["apiproduct"]))
+			}
+		}
+	}
+	return names
+}
+
+// productProxies returns the proxies of a product, including the API sources of its operation groups
+func productProxies(respBody []byte) (names []string) {
+	p := products.APIProduct{}
+	if err := json.Unmarshal(respBody, &p); err != nil {
+		return nil
+	}
+	names = append(names, p.Proxies...)
+	sources := struct {
+		OperationGroup        *operationGroup `json:"operationGroup,omitempty"`
+		GraphQLOperationGroup *operationGroup `json:"graphqlOperationGroup,omitempty"`
+		GrpcOperationGroup    *operationGroup `json:"grpcOperationGroup,omitempty"`
+		LlmOperationGroup     *operationGroup `json:"llmOperationGroup,omitempty"`
+	}{}
+	_ = json.Unmarshal(respBody, &sources)
+	for _, g := range []*operationGroup{
+		sources.OperationGroup, sources.GraphQLOperationGroup,
+		sources.GrpcOperationGroup, sources.LlmOperationGroup,
+	} {
+		if g == nil {
+			continue
+		}
+		for _, c := range g.OperationConfigs {
+			if c.APISource != "" {
+				names = append(names, c.APISource)
+			}
+		}
+	}
+	return names
+}
+
+type operationGroup struct {
+	OperationConfigs []struct {
+		APISource string `json:"apiSource,omitempty"`
+	} `json:"operationConfigs,omitempty"`
+}
+
+func listEnvironments() (map[string]bool, error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	respBody, err := env.List()
+	if err != nil {
+		return nil, err
+	}
+	environments := []string{}
+	if err = json.Unmarshal(respBody, &environments); err != nil {
+		return nil, err
+	}
+	return newSet(environments...), nil
+}
+
+func rename(name string, m map[string]string) string {
+	if newName, ok := m[name]; ok {
+		return newName
+	}
+	return name
+}
+
+func writeJSON(filePath string, v interface{}) error {
+	byteValue, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if byteValue, err = apiclient.PrettifyJSON(byteValue); err != nil {
+		return err
+	}
+	return apiclient.WriteByteArrayToFile(filePath, false, byteValue)
+}
+
+func (s Selection) String() string {
+	return fmt.Sprintf("%d proxies, %d sharedflows, %d products, %d developers, %d apps and %d env groups",
+		len(s.Proxies), len(s.SharedFlows), len(s.Products), len(s.Developers), len(s.Apps), len(s.EnvGroups))
+}
+
+type set map[string]bool
+
+func newSet(names ...string) set {
+	s := set{}
+	s.add(names...)
+	return s
+}
+
+func (s set) add(names ...string) {
+	for _, name := range names {
+		if name != "" {
+			s[name] = true
+		}
+	}
+}
+
+func (s set) sorted() []string {
+	names := make([]string, 0, len(s))
+	for name := range s {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"internal/client/apis"
+	"internal/client/apps"
+	"internal/client/clienttest"
+	"internal/client/developers"
+	"internal/client/products"
+	"internal/client/sharedflows"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+)
+
+// writeBundle writes a bundle with a FlowCallout policy for each sharedflow
+func writeBundle(t *testing.T, fileName string, root string, calls ...string) string {
+	bundle := path.Join(t.TempDir(), fileName)
+	f, err := os.Create(bundle)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	defer f.Close()
+	w := zip.NewWriter(f)
+	for _, sf := range calls {
+		p, err := w.Create(root + "/policies/FC-" + sf + ".xml")
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		_, _ = p.Write([]byte("<FlowCallout name=\"FC-" + sf + "\">\n  <SharedFlowBundle>" + sf +
+			"</SharedFlowBundle>\n</FlowCallout>"))
+	}
+	if err = w.Close(); err != nil {
+		t.Fatalf("%v", err)
+	}
+	return bundle
+}
+
+func TestResolveAndExport(t *testing.T) {
+	if clienttest.UseRealOrg() {
+		t.Skip("creates entities in the org")
+	}
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	const email = "migrate@example.com"
+	if _, err := sharedflows.Create("migrate-sf-2", writeBundle(t, "sf2.zip", "sharedflowbundle"), ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := sharedflows.Create("migrate-sf-1",
+		writeBundle(t, "sf1.zip", "sharedflowbundle", "migrate-sf-2"), ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := apis.CreateProxy("migrate-proxy", writeBundle(t, "proxy.zip", "apiproxy", "migrate-sf-1"), ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := apis.CreateProxy("migrate-other", writeBundle(t, "other.zip", "apiproxy"), ""); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := products.Create(products.APIProduct{
+		Name: "migrate-product", DisplayName: "migrate-product", ApprovalType: "auto",
+		Environments: []string{"fake-env"}, Proxies: []string{"migrate-proxy"},
+	}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := developers.Create(email, "first", "last", "migrate", nil); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if _, err := apps.Create("migrate-app", email, "-1", "", []string{"migrate-product"}, nil, nil); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	folder := t.TempDir()
+	s, err := Resolve(Filter{Developers: []string{email}}, folder)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	want := Selection{
+		Proxies:     []string{"migrate-proxy"},
+		SharedFlows: []string{"migrate-sf-1", "migrate-sf-2"},
+		Products:    []string{"migrate-product"},
+		Developers:  []string{email},
+		Apps:        []string{email + "/migrate-app"},
+		EnvGroups:   []string{},
+	}
+	if got := (Selection{
+		Proxies: s.Proxies, SharedFlows: s.SharedFlows, Products: s.Products,
+		Developers: s.Developers, Apps: s.Apps, EnvGroups: s.EnvGroups,
+	}); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %#v, want %#v", got, want)
+	}
+	for _, bundle := range []string{"proxies/migrate-proxy.zip", "sharedflows/migrate-sf-1.zip", "sharedflows/migrate-sf-2.zip"} {
+		if _, err = os.Stat(path.Join(folder, bundle)); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	if err = Export(s, folder, map[string]string{"fake-env": "dr-env"}, nil,
+		map[string]bool{"dr-env": true}); err != nil {
+		t.Fatalf("%v", err)
+	}
+	byteValue, err := os.ReadFile(path.Join(folder, productsFileName))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	exported := []products.APIProduct{}
+	if err = json.Unmarshal(byteValue, &exported); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(exported) != 1 || !reflect.DeepEqual(exported[0].Environments, []string{"dr-env"}) {
+		t.Fatalf("unexpected products %s", string(byteValue))
+	}
+}
+
+func TestProductProxies(t *testing.T) {
+	product := `{"name":"p","proxies":["a"],"operationGroup":{"operationConfigs":[{"apiSource":"b"}]},
+		"graphqlOperationGroup":{"operationConfigs":[{"apiSource":"c"}]}}`
+	if got := productProxies([]byte(product)); !reflect.DeepEqual(got, []string{"a", "b", "c"}) {
+		t.Fatalf("got %v", got)
+	}
+}
@@ -0,0 +1,658 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/datacollectors"
+	"internal/client/developers"
+	"internal/client/envgroups"
+	"internal/client/keystores"
+	"internal/client/kvm"
+	"internal/client/products"
+	"internal/client/references"
+	"internal/client/targetservers"
+	"internal/cmd/utils"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	clientapps "internal/client/apps"
+)
+
+// these match the file names written by org export
+const (
+	productsFileName     = "products.json"
+	developersFileName   = "developers.json"
+	appsFileName         = "apps.json"
+	targetServerFileName = "targetservers.json"
+	envGroupsFileName    = "envgroups.json"
+	dataCollFileName     = "datacollectors.json"
+	kvmFileName          = "kvms.json"
+	keyStoresFileName    = "keystores.json"
+	referencesFileName   = "references.json"
+)
+
+// kind describes how to read, compare and change one type of entity
+type kind struct {
+	name      string
+	orgScoped bool
+	envScoped bool
+	readFile  func(folder string, scope string) (entities, error)
+	readLive  func(conn int, desired entities) (entities, error)
+	normalize func(e entity) entity
+	create    func(c Change) error
+	update    func(c Change) error
+	delete    func(c Change) error
+}
+
+// kinds are listed in dependency order. Creates and updates are applied in
+// this order and deletes in the reverse order
+var kinds = []kind{
+	{
+		name:      "keystore",
+		envScoped: true,
+		readFile:  namesFile(keyStoresFileName),
+		readLive:  liveNames(keystores.List),
+		normalize: noop,
+		create:    createIn("keystores"),
+		update:    func(c Change) error { return nil },
+		delete:    deleteIn("keystores"),
+	},
+	{
+		name:      "reference",
+		envScoped: true,
+		readFile:  arrayFile(referencesFileName, "name"),
+		readLive:  liveExport(references.Export),
+		normalize: noop,
+		create:    createIn("references"),
+		update:    replaceIn("references"),
+		delete:    deleteIn("references"),
+	},
+	{
+		name:      "targetserver",
+		envScoped: true,
+		readFile:  arrayFile(targetServerFileName, "name"),
+		readLive:  liveExport(targetservers.Export),
+		normalize: noop,
+		create:    createIn("targetservers"),
+		update:    replaceIn("targetservers"),
+		delete:    deleteIn("targetservers"),
+	},
+	{
+		name:      "kvm",
+		orgScoped: true,
+		envScoped: true,
+		readFile:  readKVMFiles,
+		readLive:  liveKVMs,
+		normalize: noop,
+		create:    createKVM,
+		update:    updateKVMEntries,
+		delete:    deleteIn("keyvaluemaps"),
+	},
+	{
+		name:      "envgroup",
+		orgScoped: true,
+		readFile:  wrappedFile(envGroupsFileName, "environmentGroups", "name"),
+		readLive:  liveWrapped(envgroups.List, "environmentGroups", "name"),
+		normalize: noop,
+		create:    createIn("envgroups"),
+		update:    patchIn("hostnames", "envgroups"),
+		delete:    deleteIn("envgroups"),
+	},
+	{
+		name:      "datacollector",
+		orgScoped: true,
+		readFile:  wrappedFile(dataCollFileName, "dataCollectors", "name"),
+		readLive:  liveWrapped(datacollectors.List, "dataCollectors", "name"),
+		normalize: noop,
+		create:    createIn("datacollectors"),
+		update:    patchIn("description", "datacollectors"),
+		delete:    deleteIn("datacollectors"),
+	},
+	{
+		name:      "apiproduct",
+		orgScoped: true,
+		readFile:  arrayFile(productsFileName, "name"),
+		readLive: liveExport(func(conn int) ([][]byte, error) {
+			return products.Export(conn, "")
+		}),
+		normalize: noop,
+		create:    createIn("apiproducts"),
+		update:    replaceIn("apiproducts"),
+		delete:    deleteIn("apiproducts"),
+	},
+	{
+		name:      "developer",
+		orgScoped: true,
+		readFile:  wrappedFile(developersFileName, "developer", "email"),
+		readLive: liveWrapped(func() ([]byte, error) {
+			return developers.Export()
+		}, "developer", "email"),
+		normalize: noop,
+		create:    createIn("developers"),
+		update:    replaceIn("developers"),
+		delete:    deleteIn("developers"),
+	},
+	{
+		name:      "app",
+		orgScoped: true,
+		readFile:  readAppsFile,
+		readLive:  liveApps,
+		normalize: normalizeApp,
+		create:    createApp,
+		update:    updateApp,
+		delete:    deleteApp,
+	},
+}
+
+func getKind(name string) kind {
+	for _, k := range kinds {
+		if k.name == name {
+			return k
+		}
+	}
+	return kind{}
+}
+
+func noop(e entity) entity {
+	return e
+}
+
+// collectionURL returns the url of a collection in the org, or in the environment when scope is set
+func collectionURL(scope string, elem ...string) string {
+	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
+	if scope != "" {
+		u.Path = path.Join(u.Path, apiclient.GetApigeeOrg(), "environments", scope)
+	} else {
+		u.Path = path.Join(u.Path, apiclient.GetApigeeOrg())
+	}
+	u.Path = path.Join(append([]string{u.Path}, elem...)...)
+	return u.String()
+}
+
+// payload drops the server managed fields before an entity is sent
+func payload(e entity) string {
+	p := entity{}
+	for k, v := range e {
+		if !ignoredFields[k] {
+			p[k] = v
+		}
+	}
+	b, _ := json.Marshal(p)
+	return string(b)
+}
+
+func createIn(collection string) func(c Change) error {
+	return func(c Change) error {
+		_, err := apiclient.HttpClient(collectionURL(c.Env, collection), payload(c.desired))
+		return err
+	}
+}
+
+func replaceIn(collection string) func(c Change) error {
+	return func(c Change) error {
+		_, err := apiclient.HttpClient(collectionURL(c.Env, collection, c.Name), payload(c.desired), "PUT")
+		return err
+	}
+}
+
+func patchIn(updateMask string, collection string) func(c Change) error {
+	return func(c Change) error {
+		u, _ := url.Parse(collectionURL(c.Env, collection, c.Name))
+		q := u.Query()
+		q.Set("updateMask", updateMask)
+		u.RawQuery = q.Encode()
+		_, err := apiclient.HttpClient(u.String(), payload(c.desired), "PATCH")
+		return err
+	}
+}
+
+func deleteIn(collection string) func(c Change) error {
+	return func(c Change) error {
+		_, err := apiclient.HttpClient(collectionURL(c.Env, collection, c.Name), "", "DELETE")
+		return err
+	}
+}
+
+// readJSONFile returns false when the file does not exist
+func readJSONFile(filePath string, v interface{}) (bool, error) {
+	if !utils.FileExists(filePath) {
+		return false, nil
+	}
+	byteValue, err := os.ReadFile(filePath)
+	if err != nil {
+		return true, err
+	}
+	return true, json.Unmarshal(byteValue, v)
+}
+
+func keyBy(list []entity, field string) entities {
+	e := entities{}
+	for _, item := range list {
+		if name, ok := item[field].(string); ok && name != "" {
+			e[name] = item
+		}
+	}
+	return e
+}
+
+// scopedFileName prefixes env scoped files with the environment name
+func scopedFileName(folder string, scope string, fileName string) string {
+	if scope == "" {
+		return path.Join(folder, fileName)
+	}
+	return path.Join(folder, scope+utils.DefaultFileSplitter+fileName)
+}
+
+func arrayFile(fileName string, field string) func(folder string, scope string) (entities, error) {
+	return func(folder string, scope string) (entities, error) {
+		list := []entity{}
+		if ok, err := readJSONFile(scopedFileName(folder, scope, fileName), &list); !ok || err != nil {
+			return nil, err
+		}
+		return keyBy(list, field), nil
+	}
+}
+
+func wrappedFile(fileName string, key string, field string) func(folder string, scope string) (entities, error) {
+	return func(folder string, scope string) (entities, error) {
+		wrapped := map[string][]entity{}
+		if ok, err := readJSONFile(scopedFileName(folder, scope, fileName), &wrapped); !ok || err != nil {
+			return nil, err
+		}
+		return keyBy(wrapped[key], field), nil
+	}
+}
+
+func namesFile(fileName string) func(folder string, scope string) (entities, error) {
+	return func(folder string, scope string) (entities, error) {
+		names := []string{}
+		if ok, err := readJSONFile(scopedFileName(folder, scope, fileName), &names); !ok || err != nil {
+			return nil, err
+		}
+		return namedEntities(names), nil
+	}
+}
+
+func namedEntities(names []string) entities {
+	e := entities{}
+	for _, name := range names {
+		e[name] = entity{"name": name}
+	}
+	return e
+}
+
+func liveNames(list func() ([]byte, error)) func(conn int, desired entities) (entities, error) {
+	return func(conn int, desired entities) (entities, error) {
+		respBody, err := list()
+		if err != nil {
+			return nil, err
+		}
+		names := []string{}
+		if err = json.Unmarshal(respBody, &names); err != nil {
+			return nil, err
+		}
+		return namedEntities(names), nil
+	}
+}
+
+func liveExport(export func(conn int) ([][]byte, error)) func(conn int, desired entities) (entities, error) {
+	return func(conn int, desired entities) (entities, error) {
+		payload, err := export(conn)
+		if err != nil {
+			return nil, err
+		}
+		list := []entity{}
+		for _, respBody := range payload {
+			e := entity{}
+			if err = json.Unmarshal(respBody, &e); err != nil {
+				return nil, err
+			}
+			list = append(list, e)
+		}
+		return keyBy(list, "name"), nil
+	}
+}
+
+func liveWrapped(list func() ([]byte, error), key string, field string) func(conn int, desired entities) (entities, error) {
+	return func(conn int, desired entities) (entities, error) {
+		respBody, err := list()
+		if err != nil {
+			return nil, err
+		}
+		wrapped := map[string][]entity{}
+		if len(respBody) > 0 {
+			if err = json.Unmarshal(respBody, &wrapped); err != nil {
+				return nil, err
+			}
+		}
+		return keyBy(wrapped[key], field), nil
+	}
+}
+
+// readKVMFiles reads the map names and, when exported, the map entries
+func readKVMFiles(folder string, scope string) (entities, error) {
+	var prefix string
+	fileName := scopedFileName(folder, scope, kvmFileName)
+	if scope == "" {
+		fileName = path.Join(folder, apiclient.GetApigeeOrg()+utils.DefaultFileSplitter+kvmFileName)
+		prefix = "org"
+	} else {
+		prefix = strings.Join([]string{"env", scope}, utils.DefaultFileSplitter)
+	}
+
+	names := []string{}
+	if ok, err := readJSONFile(fileName, &names); !ok || err != nil {
+		return nil, err
+	}
+
+	maps := namedEntities(names)
+	for _, name := range names {
+		pages, err := filepath.Glob(path.Join(folder,
+			strings.Join([]string{prefix, name, "kvmfile", "*.json"}, utils.DefaultFileSplitter)))
+		if err != nil {
+			return nil, err
+		}
+		if len(pages) == 0 {
+			continue
+		}
+		entries := map[string]interface{}{}
+		for _, page := range pages {
+			if err = readEntries(page, entries); err != nil {
+				return nil, err
+			}
+		}
+		maps[name]["entries"] = entries
+	}
+	return maps, nil
+}
+
+func readEntries(filePath string, entries map[string]interface{}) error {
+	byteValue, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	return unmarshalEntries(byteValue, entries)
+}
+
+func unmarshalEntries(respBody []byte, entries map[string]interface{}) error {
+	page := struct {
+		KeyValueEntries []struct {
+			Name  string      `json:"name"`
+			Value interface{} `json:"value"`
+		} `json:"keyValueEntries"`
+	}{}
+	if err := json.Unmarshal(respBody, &page); err != nil {
+		return err
+	}
+	for _, entry := range page.KeyValueEntries {
+		entries[entry.Name] = entry.Value
+	}
+	return nil
+}
+
+// liveKVMs lists the maps, entries are read only for maps with entries in the folder
+func liveKVMs(conn int, desired entities) (entities, error) {
+	maps, err := liveNames(func() ([]byte, error) { return kvm.List("") })(conn, desired)
+	if err != nil {
+		return nil, err
+	}
+	for name, m := range maps {
+		if desired[name] == nil || desired[name]["entries"] == nil {
+			continue
+		}
+		pages, err := kvm.ExportEntries("", name)
+		if err != nil {
+			return nil, err
+		}
+		entries := map[string]interface{}{}
+		for _, page := range pages {
+			if err = unmarshalEntries(page, entries); err != nil {
+				return nil, err
+			}
+		}
+		m["entries"] = entries
+	}
+	return maps, nil
+}
+
+// createKVM creates an encrypted map, like org import, and its entries
+func createKVM(c Change) error {
+	if _, err := apiclient.HttpClient(collectionURL(c.Env, "keyvaluemaps"),
+		payload(entity{"name": c.Name, "encrypted": true})); err != nil {
+		return err
+	}
+	return updateKVMEntries(c)
+}
+
+func updateKVMEntries(c Change) error {
+	desired, _ := c.desired["entries"].(map[string]interface{})
+	live := map[string]interface{}{}
+	if c.live != nil {
+		live, _ = c.live["entries"].(map[string]interface{})
+	}
+	entriesURL := collectionURL(c.Env, "keyvaluemaps", c.Name, "entries")
+	for _, key := range sortedKeys(desired) {
+		value, ok := live[key]
+		if ok && value == desired[key] {
+			continue
+		}
+		var err error
+		entry := payload(entity{"name": key, "value": desired[key]})
+		if ok {
+			_, err = apiclient.HttpClient(entriesURL+"/"+url.PathEscape(key), entry, "PUT")
+		} else {
+			_, err = apiclient.HttpClient(entriesURL, entry)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	for _, key := range sortedKeys(live) {
+		if _, ok := desired[key]; ok || desired == nil {
+			continue
+		}
+		if _, err := apiclient.HttpClient(entriesURL+"/"+url.PathEscape(key), "", "DELETE"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// apps are keyed by developer email and app name, for ex: dev@example.com/app
+func appName(email string, name string) string {
+	return email + "/" + name
+}
+
+func splitAppName(name string) (email string, app string) {
+	i := strings.LastIndex(name, "/")
+	return name[:i], name[i+1:]
+}
+
+// keyApps keys apps with the email of the developer that owns them
+func keyApps(list []entity, devs entities) (entities, error) {
+	emails := map[string]string{}
+	for email, dev := range devs {
+		if id, ok := dev["developerId"].(string); ok {
+			emails[id] = email
+		}
+	}
+	e := entities{}
+	for _, app := range list {
+		id, _ := app["developerId"].(string)
+		email, ok := emails[id]
+		if !ok {
+			return nil, fmt.Errorf("developer %s of app %v was not found", id, app["name"])
+		}
+		name, _ := app["name"].(string)
+		e[appName(email, name)] = app
+	}
+	return e, nil
+}
+
+func readAppsFile(folder string, scope string) (entities, error) {
+	list := []entity{}
+	if ok, err := readJSONFile(path.Join(folder, appsFileName), &list); !ok || err != nil {
+		return nil, err
+	}
+	devs, err := wrappedFile(developersFileName, "developer", "email")(folder, scope)
+	if err != nil {
+		return nil, err
+	}
+	return keyApps(list, devs)
+}
+
+func liveApps(conn int, desired entities) (entities, error) {
+	payload, err := clientapps.Export(conn)
+	if err != nil {
+		return nil, err
+	}
+	list := []entity{}
+	for _, respBody := range payload {
+		e := entity{}
+		if err = json.Unmarshal(respBody, &e); err != nil {
+			return nil, err
+		}
+		list = append(list, e)
+	}
+	devs, err := liveWrapped(func() ([]byte, error) {
+		return developers.Export()
+	}, "developer", "email")(conn, desired)
+	if err != nil {
+		return nil, err
+	}
+	return keyApps(list, devs)
+}
+
+// appProducts returns the sorted products of all the app credentials
+func appProducts(e entity) []interface{} {
+	names := map[string]bool{}
+	creds, _ := e["credentials"].([]interface{})
+	for _, cred := range creds {
+		c, _ := cred.(map[string]interface{})
+		products, _ := c["apiProducts"].([]interface{})
+		for _, product := range products {
+			if p, ok := product.(map[string]interface{}); ok {
+				names[fmt.Sprint(p["apiproduct"])] = true
+			}
+		}
+	}
+	list := []string{}
+	for name := range names {
+		list = append(list, name)
+	}
+	sort.Strings(list)
+	products := []interface{}{}
+	for _, name := range list {
+		products = append(products, name)
+	}
+	return products
+}
+
+// normalizeApp compares the products of the app keys instead of the keys. An app
+// written by hand can list apiProducts instead of credentials
+func normalizeApp(e entity) entity {
+	n := entity{}
+	for k, v := range e {
+		n[k] = v
+	}
+	if _, ok := e["credentials"]; ok || e["apiProducts"] == nil {
+		n["apiProducts"] = appProducts(e)
+		return n
+	}
+	creds := []interface{}{}
+	products, _ := e["apiProducts"].([]interface{})
+	for _, product := range products {
+		creds = append(creds, map[string]interface{}{
+			"apiProducts": []interface{}{map[string]interface{}{"apiproduct": product}},
+		})
+	}
+	n["apiProducts"] = appProducts(entity{"credentials": creds})
+	return n
+}
+
+func createApp(c Change) error {
+	email, _ := splitAppName(c.Name)
+	_, err := apiclient.HttpClient(collectionURL("", "developers", email, "apps"), payload(normalizeApp(c.desired)))
+	return err
+}
+
+// updateApp replaces the app and then adds or removes products from each key
+func updateApp(c Change) error {
+	email, name := splitAppName(c.Name)
+	appURL := collectionURL("", "developers", email, "apps", name)
+	app := normalizeApp(c.desired)
+	desired := app["apiProducts"].([]interface{})
+	delete(app, "apiProducts")
+	if _, err := apiclient.HttpClient(appURL, payload(app), "PUT"); err != nil {
+		return err
+	}
+
+	creds, _ := c.live["credentials"].([]interface{})
+	for _, cred := range creds {
+		credential, _ := cred.(map[string]interface{})
+		key := fmt.Sprint(credential["consumerKey"])
+		live := appProducts(entity{"credentials": []interface{}{cred}})
+		if add := missing(desired, live); len(add) > 0 {
+			body, _ := json.Marshal(map[string]interface{}{"apiProducts": add})
+			if _, err := apiclient.HttpClient(appURL+"/keys/"+url.PathEscape(key), string(body)); err != nil {
+				return err
+			}
+		}
+		for _, product := range missing(live, desired) {
+			if _, err := apiclient.HttpClient(appURL+"/keys/"+url.PathEscape(key)+"/apiproducts/"+
+				url.PathEscape(fmt.Sprint(product)), "", "DELETE"); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func deleteApp(c Change) error {
+	email, name := splitAppName(c.Name)
+	_, err := apiclient.HttpClient(collectionURL("", "developers", email, "apps", name), "", "DELETE")
+	return err
+}
+
+// missing returns the items of a that are not in b
+func missing(a []interface{}, b []interface{}) []interface{} {
+	found := map[interface{}]bool{}
+	for _, item := range b {
+		found[item] = true
+	}
+	list := []interface{}{}
+	for _, item := range a {
+		if !found[item] {
+			list = append(list, item)
+		}
+	}
+	return list
+}
@@ -0,0 +1,233 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/env"
+	"internal/clilog"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Action is the change needed to bring a live entity to the desired state
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Change is a single action of a plan
+type Change struct {
+	Kind   string   `json:"kind"`
+	Env    string   `json:"environment,omitempty"`
+	Name   string   `json:"name"`
+	Action Action   `json:"action"`
+	Fields []string `json:"fields,omitempty"`
+
+	desired entity
+	live    entity
+}
+
+// Plan is the list of changes, in the order they are applied
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+type entity map[string]interface{}
+
+// entities are keyed by the name used in the management API path
+type entities map[string]entity
+
+// ignoredFields are set by the control plane and never compared or sent
+var ignoredFields = map[string]bool{
+	"createdAt":        true,
+	"lastModifiedAt":   true,
+	"createdBy":        true,
+	"lastModifiedBy":   true,
+	"organizationName": true,
+	"developerId":      true,
+	"appId":            true,
+	"apps":             true,
+	"companies":        true,
+	"appFamily":        true,
+	"status":           true,
+	"state":            true,
+	"credentials":      true,
+}
+
+// Build compares the folder, in the layout written by org export, with the live org.
+// Only the resources that have a file in the folder are compared. Entities missing
+// from the folder are deleted only when prune is set
+func Build(folder string, conn int, prune bool) (p Plan, err error) {
+	var envRespBody []byte
+	environments := []string{}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	if envRespBody, err = env.List(); err != nil {
+		return p, err
+	}
+	if err = json.Unmarshal(envRespBody, &environments); err != nil {
+		return p, err
+	}
+
+	currentEnv := apiclient.GetApigeeEnv()
+	defer apiclient.SetApigeeEnv(currentEnv)
+
+	creates, deletes := []Change{}, []Change{}
+	for _, k := range kinds {
+		scopes := []string{}
+		if k.orgScoped {
+			scopes = append(scopes, "")
+		}
+		if k.envScoped {
+			scopes = append(scopes, environments...)
+		}
+		for _, scope := range scopes {
+			var desired, live entities
+			if desired, err = k.readFile(folder, scope); err != nil {
+				return p, fmt.Errorf("error reading %s files: %w", k.name, err)
+			}
+			if desired == nil {
+				continue
+			}
+			clilog.Debug.Printf("Comparing %d %s entities with the org\n", len(desired), k.name)
+			apiclient.SetApigeeEnv(scope)
+			if live, err = k.readLive(conn, desired); err != nil {
+				return p, fmt.Errorf("error listing %s entities: %w", k.name, err)
+			}
+			c, d := compare(k, scope, desired, live)
+			creates = append(creates, c...)
+			if prune {
+				deletes = append(d, deletes...)
+			}
+		}
+	}
+	p.Changes = append(creates, deletes...)
+	return p, nil
+}
+
+// compare returns the creates and updates, and the deletes for one kind
+func compare(k kind, scope string, desired entities, live entities) (changes []Change, deletes []Change) {
+	for _, name := range sortedNames(desired) {
+		c := Change{Kind: k.name, Env: scope, Name: name, desired: desired[name], live: live[name]}
+		if c.live == nil {
+			c.Action = Create
+			changes = append(changes, c)
+			continue
+		}
+		if c.Fields = diff(k.normalize(c.desired), k.normalize(c.live)); len(c.Fields) > 0 {
+			c.Action = Update
+			changes = append(changes, c)
+		}
+	}
+	for _, name := range sortedNames(live) {
+		if desired[name] == nil {
+			deletes = append(deletes, Change{Kind: k.name, Env: scope, Name: name, Action: Delete, live: live[name]})
+		}
+	}
+	return changes, deletes
+}
+
+// diff returns the top level fields that differ, server managed fields are ignored
+func diff(desired entity, live entity) (fields []string) {
+	keys := map[string]bool{}
+	for k := range desired {
+		keys[k] = true
+	}
+	for k := range live {
+		keys[k] = true
+	}
+	for k := range keys {
+		if !ignoredFields[k] && !reflect.DeepEqual(desired[k], live[k]) {
+			fields = append(fields, k)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// Apply performs the changes of the plan in order and stops at the first error
+func Apply(p Plan) (err error) {
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	for _, c := range p.Changes {
+		if apiclient.Canceled() {
+			return fmt.Errorf("apply interrupted: %w", apiclient.GetContext().Err())
+		}
+		k := getKind(c.Kind)
+		clilog.Info.Println(c.String())
+		switch c.Action {
+		case Create:
+			err = k.create(c)
+		case Update:
+			err = k.update(c)
+		case Delete:
+			err = k.delete(c)
+		}
+		if err != nil {
+			return fmt.Errorf("%s %s not applied: %w", c.Kind, c.Name, err)
+		}
+	}
+	return nil
+}
+
+// Empty returns true when the org matches the folder
+func (p Plan) Empty() bool {
+	return len(p.Changes) == 0
+}
+
+func (c Change) String() string {
+	symbol := map[Action]string{Create: "+", Update: "~", Delete: "-"}[c.Action]
+	line := fmt.Sprintf("%s %s %s %s", symbol, c.Action, c.Kind, c.Name)
+	if c.Env != "" {
+		line += " in environment " + c.Env
+	}
+	if len(c.Fields) > 0 {
+		line += " (" + strings.Join(c.Fields, ", ") + ")"
+	}
+	return line
+}
+
+func (p Plan) String() string {
+	if p.Empty() {
+		return "No changes, the org matches the folder\n"
+	}
+	count := map[Action]int{}
+	var b strings.Builder
+	for _, c := range p.Changes {
+		b.WriteString(c.String() + "\n")
+		count[c.Action]++
+	}
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update, %d to delete\n", count[Create], count[Update], count[Delete])
+	return b.String()
+}
+
+func sortedNames(e entities) []string {
+	names := make([]string, 0, len(e))
+	for name := range e {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
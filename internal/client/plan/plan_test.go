@@ -0,0 +1,90 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"internal/client/clienttest"
+	"internal/client/products"
+	"os"
+	"path"
+	"testing"
+)
+
+var folderFiles = map[string]string{
+	"products.json": `[{"name":"plan-update","displayName":"new"},{"name":"plan-new","displayName":"new"}]`,
+	"developers.json": `{"developer":[{"email":"plan@example.com","firstName":"plan","lastName":"test",` +
+		`"userName":"plan","developerId":"exported-id"}]}`,
+	"apps.json": `[{"name":"plan-app","developerId":"exported-id",` +
+		`"credentials":[{"consumerKey":"k","apiProducts":[{"apiproduct":"plan-new","status":"approved"}]}]}]`,
+	"fake-env__targetservers.json":             `[{"name":"plan-ts","host":"example.com","port":443,"isEnabled":true}]`,
+	"fake-env__kvms.json":                      `["plan-kvm"]`,
+	"env__fake-env__plan-kvm__kvmfile__0.json": `{"keyValueEntries":[{"name":"key","value":"value"}]}`,
+}
+
+func TestPlanAndApply(t *testing.T) {
+	if clienttest.UseRealOrg() {
+		t.Skip("plan and apply change the org, run only against the fake control plane")
+	}
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	folder := t.TempDir()
+	for name, content := range folderFiles {
+		if err := os.WriteFile(path.Join(folder, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	for _, name := range []string{"plan-update", "plan-stale"} {
+		if _, err := products.Create(products.APIProduct{Name: name, DisplayName: "old"}); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	p, err := Build(folder, 2, true)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	expected := []string{
+		"+ create targetserver plan-ts in environment fake-env",
+		"+ create kvm plan-kvm in environment fake-env",
+		"+ create apiproduct plan-new",
+		"~ update apiproduct plan-update (displayName)",
+		"+ create developer plan@example.com",
+		"+ create app plan@example.com/plan-app",
+		"- delete apiproduct plan-stale",
+	}
+	if len(p.Changes) != len(expected) {
+		t.Fatalf("expected %d changes, got\n%s", len(expected), p)
+	}
+	for i, c := range p.Changes {
+		if c.String() != expected[i] {
+			t.Fatalf("expected change %d to be %q, got %q", i, expected[i], c.String())
+		}
+	}
+
+	if err = Apply(p); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if p, err = Build(folder, 2, true); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !p.Empty() {
+		t.Fatalf("expected no changes after apply, got\n%s", p)
+	}
+}
@@ -17,6 +17,7 @@ package products
 import (
 	"encoding/json"
 	"errors"
+	"fmt"
 	"internal/apiclient"
 	"internal/clilog"
 	"io"
@@ -24,7 +25,6 @@ import (
 	"os"
 	"path"
 	"strconv"
-	"strings"
 	"sync"
 )
 
@@ -78,6 +78,15 @@ type GrpcOperationGroup struct {
 	OperationConfigs    []grpcOperationConfig `json:"operationConfigs,omitempty"`
 }
 
+// AddOperation adds the methods of a gRPC service of an API proxy to the operation group
+func (g *GrpcOperationGroup) AddOperation(apiSource string, service string, methods []string) {
+	g.OperationConfigs = append(g.OperationConfigs, grpcOperationConfig{
+		APISource: apiSource,
+		Service:   service,
+		Methods:   methods,
+	})
+}
+
 type LlmOperationGroup struct {
 	OperationConfigs    []llmOperationConfig  `json:"operationConfigs,omitempty"`
 }
@@ -188,10 +197,12 @@ func upsert(p APIProduct, a Action) (respBody []byte, err error) {
 	case UPSERT:
 		apiclient.ClientPrintHttpResponse.Set(false)
 		_, err = Get(p.Name)
-		if err != nil {
+		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+		if apiclient.IsNotFound(err) {
 			createNew = true // product does not exist
+		} else if err != nil {
+			return nil, err
 		}
-		apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	}
 
 	payload, err := json.Marshal(p)
@@ -364,7 +375,7 @@ func Export(conn int, space string) (payload [][]byte, err error) {
 
 	start := 0
 
-	for i, end := 0, 0; i < numOfLoops; i++ {
+	for i, end := 0, 0; i < numOfLoops && !apiclient.Canceled(); i++ {
 		pwg.Add(1)
 		end = (i * conn) + conn
 		clilog.Debug.Printf("Exporting batch %d of products\n", (i + 1))
@@ -373,7 +384,7 @@ func Export(conn int, space string) (payload [][]byte, err error) {
 		pwg.Wait()
 	}
 
-	if remaining > 0 {
+	if remaining > 0 && !apiclient.Canceled() {
 		pwg.Add(1)
 		clilog.Debug.Printf("Exporting remaining %d products\n", remaining)
 		go batchExport(products.APIProduct[start:numProd], entityType, &pwg, &mu)
@@ -383,6 +394,9 @@ func Export(conn int, space string) (payload [][]byte, err error) {
 	payload = make([][]byte, len(apiclient.GetEntityPayloadList()))
 	copy(payload, apiclient.GetEntityPayloadList())
 	apiclient.ClearEntityPayloadList()
+	if err = apiclient.GetContext().Err(); err != nil {
+		return payload, fmt.Errorf("product export interrupted: %w", err)
+	}
 	return payload, nil
 }
 
@@ -404,7 +418,7 @@ func Import(conn int, filePath string, upsert bool) (err error) {
 	fanOutWg := sync.WaitGroup{}
 	fanInWg := sync.WaitGroup{}
 
-	errs := []string{}
+	errs := []error{}
 	fanInWg.Add(1)
 	go func() {
 		defer fanInWg.Done()
@@ -413,7 +427,7 @@ func Import(conn int, filePath string, upsert bool) (err error) {
 			if !ok {
 				return
 			}
-			errs = append(errs, newErr.Error())
+			errs = append(errs, newErr)
 		}
 	}()
 
@@ -423,6 +437,9 @@ func Import(conn int, filePath string, upsert bool) (err error) {
 	}
 
 	for _, entity := range entities {
+		if apiclient.Canceled() {
+			break
+		}
 		jobChan <- entity
 	}
 	close(jobChan)
@@ -430,8 +447,12 @@ func Import(conn int, filePath string, upsert bool) (err error) {
 	close(errChan)
 	fanInWg.Wait()
 
+	if err = apiclient.GetContext().Err(); err != nil {
+		return fmt.Errorf("product import interrupted: %w", err)
+	}
+
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errors.Join(errs...)
 	}
 	return nil
 }
@@ -216,7 +216,7 @@ func Import(conn int, filePath string) (err error) {
 	if err != nil {
 		return err
 	}
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	req, err := http.NewRequestWithContext(apiclient.GetContext(), http.MethodGet, u.String(), nil)
 	if err != nil {
 		return err
 	}
@@ -311,7 +311,7 @@ func importReferences(knownRefs map[string]bool, wg *sync.WaitGroup, jobs <-chan
 			method = http.MethodPut
 		}
 
-		req, err := http.NewRequest(method, u.String(), bytes.NewReader(b))
+		req, err := http.NewRequestWithContext(apiclient.GetContext(), method, u.String(), bytes.NewReader(b))
 		if err != nil {
 			errs <- err
 			continue
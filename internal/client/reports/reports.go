@@ -15,12 +15,24 @@
 package reports
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"internal/apiclient"
+	"internal/clilog"
 	"net/url"
+	"os"
 	"path"
 	"strconv"
 )
 
+type reportList struct {
+	Qualifier []map[string]interface{} `json:"qualifier,omitempty"`
+}
+
+// serverFields are set by the control plane and dropped before a report is created
+var serverFields = []string{"name", "organization", "createdAt", "lastModifiedAt", "lastViewedAt"}
+
 // Create a report
 func Create(contents []byte) (respBody []byte, err error) {
 	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
@@ -55,3 +67,55 @@ func List(expand bool) (respBody []byte, err error) {
 	respBody, err = apiclient.HttpClient(u.String())
 	return respBody, err
 }
+
+// Import creates the reports in a file written by List(true). The control plane
+// assigns new names, so reports are matched by display name and existing ones are skipped
+func Import(filePath string) (err error) {
+	byteValue, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	l := reportList{}
+	if err = json.Unmarshal(byteValue, &l); err != nil {
+		return err
+	}
+
+	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
+	if byteValue, err = List(true); err != nil {
+		return err
+	}
+	known := reportList{}
+	if err = json.Unmarshal(byteValue, &known); err != nil {
+		return err
+	}
+	knownNames := map[string]bool{}
+	for _, report := range known.Qualifier {
+		if displayName, ok := report["displayName"].(string); ok {
+			knownNames[displayName] = true
+		}
+	}
+
+	errs := []error{}
+	for _, report := range l.Qualifier {
+		displayName, _ := report["displayName"].(string)
+		if knownNames[displayName] {
+			clilog.Warning.Printf("Report %s already exists\n", displayName)
+			continue
+		}
+		for _, field := range serverFields {
+			delete(report, field)
+		}
+		contents, err := json.Marshal(report)
+		if err != nil {
+			return err
+		}
+		if _, err = Create(contents); err != nil {
+			errs = append(errs, fmt.Errorf("report %s not imported: %w", displayName, err))
+			continue
+		}
+		clilog.Debug.Printf("Completed report: %s", displayName)
+	}
+	return errors.Join(errs...)
+}
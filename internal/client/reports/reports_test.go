@@ -0,0 +1,58 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reports
+
+import (
+	"encoding/json"
+	"internal/client/clienttest"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestImport(t *testing.T) {
+	if clienttest.UseRealOrg() {
+		t.Skip("creates reports in the org")
+	}
+	if err := clienttest.TestSetup(t, clienttest.ENV_NOT_REQD,
+		clienttest.SITEID_NOT_REQD, clienttest.CLIPATH_NOT_REQD); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	reportsFile := path.Join(t.TempDir(), "customreports.json")
+	contents := `{"qualifier":[{"name":"0b4e1f38","displayName":"errors","organization":"src-org",
+		"metrics":[{"name":"sum(message_count)"}],"dimensions":["apiproxy"]}]}`
+	if err := os.WriteFile(reportsFile, []byte(contents), 0o600); err != nil {
+		t.Fatalf("%v", err)
+	}
+	// the second import finds the report by display name and skips it
+	for i := 0; i < 2; i++ {
+		if err := Import(reportsFile); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	respBody, err := List(true)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	l := reportList{}
+	if err = json.Unmarshal(respBody, &l); err != nil {
+		t.Fatalf("%v", err)
+	}
+	if len(l.Qualifier) != 1 || l.Qualifier[0]["name"] == "0b4e1f38" || l.Qualifier[0]["organization"] != nil {
+		t.Fatalf("unexpected reports %s", string(respBody))
+	}
+}
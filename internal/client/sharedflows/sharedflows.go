@@ -308,8 +308,16 @@ func Fetch(name string, revision int) (err error) {
 	return apiclient.FetchBundle("sharedflows", "", name, strconv.Itoa(revision), true)
 }
 
-// Export
-func Export(conn int, folder string, allRevisions bool, space string) (err error) {
+// Export exports sharedflow bundles to a folder. When incremental is set, only new
+// or changed revisions are downloaded and bundles of deleted sharedflows are removed
+func Export(conn int, folder string, allRevisions bool, space string, incremental bool) (err error) {
+	var manifest *apiclient.Manifest
+	if incremental {
+		if manifest, err = apiclient.ReadManifest(folder); err != nil {
+			return err
+		}
+	}
+
 	u, _ := url.Parse(apiclient.GetApigeeBaseURL())
 	q := u.Query()
 	if space != "" {
@@ -321,8 +329,9 @@ func Export(conn int, folder string, allRevisions bool, space string) (err error
 
 	// don't print to sysout
 	apiclient.ClientPrintHttpResponse.Set(false)
+	defer apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
+
 	respBody, err := apiclient.HttpClient(u.String())
-	apiclient.ClientPrintHttpResponse.Set(apiclient.GetCmdPrintHttpResponseSetting())
 	if err != nil {
 		return err
 	}
@@ -356,15 +365,18 @@ func Export(conn int, folder string, allRevisions bool, space string) (err error
 
 	for i := 0; i < conn; i++ {
 		fanOutWg.Add(1)
-		go exportSharedFlows(&fanOutWg, jobChan, folder, allRevisions, errChan)
+		go exportSharedFlows(&fanOutWg, jobChan, folder, allRevisions, manifest, errChan)
 	}
 
 	for _, proxy := range shrdflows.Flows {
 		if allRevisions {
 			for _, rev := range proxy.Revision {
+				if apiclient.Canceled() {
+					break
+				}
 				jobChan <- revision{name: proxy.Name, rev: rev}
 			}
-		} else {
+		} else if !apiclient.Canceled() {
 			lastRevision := maxRevision(proxy.Revision)
 			jobChan <- revision{name: proxy.Name, rev: lastRevision}
 		}
@@ -374,20 +386,38 @@ func Export(conn int, folder string, allRevisions bool, space string) (err error
 	close(errChan)
 	fanInWg.Wait()
 
+	if err = apiclient.GetContext().Err(); err != nil {
+		if manifest != nil {
+			_ = manifest.Write()
+		}
+		return fmt.Errorf("sharedflow export interrupted: %w", err)
+	}
+
+	if manifest != nil {
+		if err = manifest.Prune(); err != nil {
+			errs = append(errs, err.Error())
+		}
+		if err = manifest.Write(); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+
 	if len(errs) > 0 {
 		return errors.New(strings.Join(errs, "\n"))
 	}
 	return nil
 }
 
-func exportSharedFlows(wg *sync.WaitGroup, jobs <-chan revision, folder string, allRevisions bool, errs chan<- error) {
+func exportSharedFlows(wg *sync.WaitGroup, jobs <-chan revision, folder string, allRevisions bool,
+	manifest *apiclient.Manifest, errs chan<- error,
+) {
 	defer wg.Done()
 	for {
 		job, ok := <-jobs
 		if !ok {
 			return
 		}
-		err := apiclient.FetchBundle("sharedflows", folder, job.name, job.rev, allRevisions)
+		err := apiclient.FetchChangedBundle("sharedflows", folder, job.name, job.rev, allRevisions, manifest)
 		if err != nil {
 			errs <- err
 		}
@@ -424,7 +454,7 @@ func Import(conn int, folder string, space string) error {
 	fanOutWg := sync.WaitGroup{}
 	fanInWg := sync.WaitGroup{}
 
-	errs := []string{}
+	errs := []error{}
 	fanInWg.Add(1)
 	go func() {
 		defer fanInWg.Done()
@@ -433,7 +463,7 @@ func Import(conn int, folder string, space string) error {
 			if !ok {
 				return
 			}
-			errs = append(errs, newErr.Error())
+			errs = append(errs, newErr)
 		}
 	}()
 
@@ -443,6 +473,9 @@ func Import(conn int, folder string, space string) error {
 	}
 
 	for _, bundle := range bundles {
+		if apiclient.Canceled() {
+			break
+		}
 		jobChan <- bundle
 	}
 	close(jobChan)
@@ -450,8 +483,12 @@ func Import(conn int, folder string, space string) error {
 	close(errChan)
 	fanInWg.Wait()
 
+	if err = apiclient.GetContext().Err(); err != nil {
+		return fmt.Errorf("sharedflow import interrupted: %w", err)
+	}
+
 	if len(errs) > 0 {
-		return errors.New(strings.Join(errs, "\n"))
+		return errors.Join(errs...)
 	}
 	return nil
 }
@@ -496,7 +533,7 @@ func importSharedFlows(wg *sync.WaitGroup, jobs <-chan string, space string, err
 			errs <- err
 			continue
 		}
-		req, err := http.NewRequest(http.MethodPost, u.String(), reqBody)
+		req, err := http.NewRequestWithContext(apiclient.GetContext(), http.MethodPost, u.String(), reqBody)
 		if err != nil {
 			errs <- err
 			continue
@@ -514,11 +551,13 @@ func importSharedFlows(wg *sync.WaitGroup, jobs <-chan string, space string, err
 			continue
 		}
 		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
 		if err != nil {
-			b = []byte(err.Error())
+			errs <- fmt.Errorf("bundle %s not imported: %w", n, err)
+			continue
 		}
-		if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
-			errs <- fmt.Errorf("bundle %s not imported: (HTTP %v) %s", n, resp.StatusCode, b)
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			errs <- fmt.Errorf("bundle %s not imported: %w", n, apiclient.NewAPIError(resp, b))
 			continue
 		}
 
@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Cmd to query analytics
+var Cmd = &cobra.Command{
+	Use:   "analytics",
+	Short: "Query Apigee Analytics",
+	Long:  "Query Apigee Analytics metrics for an environment",
+}
+
+var org, env, region, id, folder string
+
+var (
+	wait                  bool
+	interval, waitTimeout time.Duration
+)
+
+var examples = []string{
+	`apigeecli analytics query -e $env --dimensions apiproxy,target_url --select "percentile(total_response_time,99)" --last 1h --sortby "percentile(total_response_time,99)" --sort DESC`,
+	`apigeecli analytics queries create -e $env --dimensions apiproxy --select "sum(message_count)" --time-range last24hours --output-format csv --wait --folder ./results`,
+	`apigeecli analytics exports create -e $env --name daily --start 2026-01-01 --end 2026-01-02 --datastore my-gcs-datastore --wait`,
+}
+
+func init() {
+	Cmd.PersistentFlags().StringVarP(&org, "org", "o",
+		"", "Apigee organization name")
+	Cmd.PersistentFlags().StringVarP(&env, "env", "e",
+		"", "Apigee environment name")
+	Cmd.PersistentFlags().StringVarP(&region, "region", "r",
+		"", "Apigee control plane region name; default is https://apigee.googleapis.com")
+
+	_ = Cmd.MarkPersistentFlagRequired("env")
+
+	Cmd.AddCommand(QueryCmd)
+	Cmd.AddCommand(QueriesCmd)
+	Cmd.AddCommand(ExportsCmd)
+}
+
+func GetExample(i int) string {
+	return examples[i]
+}
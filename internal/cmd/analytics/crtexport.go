@@ -0,0 +1,97 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/analytics"
+	"internal/clilog"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// CrtExportCmd to export analytics data to a datastore
+var CrtExportCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Export analytics data to a datastore",
+	Long: "Export the analytics data of a range of days to a datastore. The datastore is " +
+		"set by its display name, as created with apigeecli datastores create",
+	Example: `Export a day of data and wait for completion:
+` + GetExample(2),
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		for _, day := range []string{startDate, endDate} {
+			if _, err = time.Parse(time.DateOnly, day); err != nil {
+				return fmt.Errorf("start and end must be dates as YYYY-MM-DD: %w", err)
+			}
+		}
+		if outputFormat != "csv" && outputFormat != "json" {
+			return fmt.Errorf("output-format must be csv or json")
+		}
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		if wait {
+			apiclient.DisableCmdPrintHttpResponse()
+		}
+		respBody, err := analytics.CreateExport(env, analytics.Export{
+			Name:         name,
+			Description:  description,
+			DateRange:    analytics.DateRange{Start: startDate, End: endDate},
+			OutputFormat: outputFormat,
+			CsvDelimiter: csvDelimiter,
+		}, datastore)
+		if err != nil || !wait {
+			return err
+		}
+		exportID, err := analytics.JobID(respBody)
+		if err != nil {
+			return err
+		}
+		clilog.Info.Printf("Created export %s\n", exportID)
+		_, err = analytics.WaitForExport(env, exportID, interval, waitTimeout)
+		return err
+	},
+}
+
+var description, startDate, endDate, datastore string
+
+func init() {
+	CrtExportCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Name of the export")
+	CrtExportCmd.Flags().StringVarP(&description, "desc", "d",
+		"", "Description of the export")
+	CrtExportCmd.Flags().StringVarP(&startDate, "start", "",
+		"", "First day to export, as YYYY-MM-DD")
+	CrtExportCmd.Flags().StringVarP(&endDate, "end", "",
+		"", "Day after the last day to export, as YYYY-MM-DD")
+	CrtExportCmd.Flags().StringVarP(&datastore, "datastore", "",
+		"", "Display name of the datastore to export to")
+	CrtExportCmd.Flags().StringVarP(&outputFormat, "output-format", "",
+		"json", "Format of the exported data, csv or json")
+	CrtExportCmd.Flags().StringVarP(&csvDelimiter, "csv-delimiter", "",
+		"", "Delimiter of csv data; default is a comma")
+	addWaitFlags(CrtExportCmd)
+
+	_ = CrtExportCmd.MarkFlagRequired("name")
+	_ = CrtExportCmd.MarkFlagRequired("start")
+	_ = CrtExportCmd.MarkFlagRequired("end")
+	_ = CrtExportCmd.MarkFlagRequired("datastore")
+}
@@ -0,0 +1,139 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/analytics"
+	"internal/clilog"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// CrtQueryCmd to create an asynchronous query
+var CrtQueryCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create an asynchronous analytics query",
+	Long: "Create an asynchronous analytics query. With --wait, the command waits for the " +
+		"query to complete and downloads the decompressed result to a folder",
+	Example: `Run a query and download the result:
+` + GetExample(1),
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if asyncTimeRange != "" && last > 0 {
+			return fmt.Errorf("time-range and last cannot be used together")
+		}
+		if asyncTimeRange == "" && last <= 0 {
+			return fmt.Errorf("time-range or last must be set")
+		}
+		if outputFormat != "csv" && outputFormat != "json" {
+			return fmt.Errorf("output-format must be csv or json")
+		}
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		q := analytics.AsyncQuery{
+			Name:            name,
+			Dimensions:      dimensions,
+			TimeRange:       analytics.AsyncTimeRange(asyncTimeRange, last, time.Now()),
+			Filter:          filter,
+			GroupByTimeUnit: timeUnit,
+			Limit:           limit,
+			OutputFormat:    outputFormat,
+			CsvDelimiter:    csvDelimiter,
+		}
+		for _, metric := range metrics {
+			m, err := analytics.ParseMetric(metric)
+			if err != nil {
+				return err
+			}
+			q.Metrics = append(q.Metrics, m)
+		}
+
+		if wait {
+			apiclient.DisableCmdPrintHttpResponse()
+		}
+		respBody, err := analytics.CreateQuery(env, q)
+		if err != nil || !wait {
+			return err
+		}
+		queryID, err := analytics.JobID(respBody)
+		if err != nil {
+			return err
+		}
+		clilog.Info.Printf("Created query %s\n", queryID)
+		return waitAndDownload(queryID)
+	},
+}
+
+var name, asyncTimeRange, outputFormat, csvDelimiter string
+
+func init() {
+	CrtQueryCmd.Flags().StringVarP(&name, "name", "n",
+		"", "Name of the query")
+	CrtQueryCmd.Flags().StringSliceVarP(&dimensions, "dimensions", "d",
+		[]string{}, "Dimensions to group by, for ex: apiproxy,target_url")
+	CrtQueryCmd.Flags().StringArrayVarP(&metrics, "select", "s",
+		[]string{}, "Metrics to select as function(name), for ex: sum(message_count)")
+	CrtQueryCmd.Flags().StringVarP(&filter, "filter", "",
+		"", "Filter expression, for ex: (response_status_code ge 500)")
+	CrtQueryCmd.Flags().StringVarP(&asyncTimeRange, "time-range", "",
+		"", "Time range as start~end in RFC3339, or last60minutes, last24hours or last7days")
+	CrtQueryCmd.Flags().DurationVarP(&last, "last", "",
+		0, "Query the last duration instead of a time range, for ex: 36h")
+	CrtQueryCmd.Flags().StringVarP(&timeUnit, "time-unit", "",
+		"", "Group the values by minute, hour, day, week or month")
+	CrtQueryCmd.Flags().IntVarP(&limit, "limit", "",
+		0, "Maximum number of rows")
+	CrtQueryCmd.Flags().StringVarP(&outputFormat, "output-format", "",
+		"json", "Format of the result, csv or json")
+	CrtQueryCmd.Flags().StringVarP(&csvDelimiter, "csv-delimiter", "",
+		"", "Delimiter of csv results; default is a comma")
+	addWaitFlags(CrtQueryCmd)
+	CrtQueryCmd.Flags().StringVarP(&folder, "folder", "f",
+		".", "Folder to write the result to when waiting")
+
+	_ = CrtQueryCmd.MarkFlagRequired("select")
+}
+
+// addWaitFlags adds the flags to wait for a query or an export
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&wait, "wait", "",
+		false, "Wait for completion")
+	cmd.Flags().DurationVarP(&interval, "interval", "",
+		10*time.Second, "Time between status checks")
+	cmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait, for ex: 30m; default is no limit")
+}
+
+func waitAndDownload(queryID string) error {
+	if _, err := analytics.WaitForQuery(env, queryID, interval, waitTimeout); err != nil {
+		return err
+	}
+	return downloadResult(queryID)
+}
+
+func downloadResult(queryID string) error {
+	files, err := analytics.DownloadQueryResult(env, queryID, folder)
+	for _, file := range files {
+		clilog.Info.Printf("Wrote %s\n", file)
+	}
+	return err
+}
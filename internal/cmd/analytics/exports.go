@@ -0,0 +1,32 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// ExportsCmd to manage analytics data exports
+var ExportsCmd = &cobra.Command{
+	Use:   "exports",
+	Short: "Manage analytics data exports to a datastore",
+	Long:  "Manage exports of analytics data to a datastore created with apigeecli datastores create",
+}
+
+func init() {
+	ExportsCmd.AddCommand(CrtExportCmd)
+	ExportsCmd.AddCommand(GetExportCmd)
+	ExportsCmd.AddCommand(ListExportCmd)
+}
@@ -0,0 +1,46 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"internal/apiclient"
+	"internal/client/analytics"
+
+	"github.com/spf13/cobra"
+)
+
+// GetExportCmd to get the status of an export
+var GetExportCmd = &cobra.Command{
+	Use:   "get",
+	Short: "Get the status of an analytics export",
+	Long:  "Get the status of an analytics data export",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		_, err = analytics.GetExport(env, id)
+		return err
+	},
+}
+
+func init() {
+	GetExportCmd.Flags().StringVarP(&id, "id", "i",
+		"", "Export ID")
+
+	_ = GetExportCmd.MarkFlagRequired("id")
+}
@@ -0,0 +1,50 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"internal/apiclient"
+	"internal/client/analytics"
+
+	"github.com/spf13/cobra"
+)
+
+// ListQueryCmd to list queries
+var ListQueryCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List asynchronous queries",
+	Long:  "List asynchronous analytics queries in an environment",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		_, err = analytics.ListQueries(env, submittedBy, from, to)
+		return err
+	},
+}
+
+var submittedBy, from, to string
+
+func init() {
+	ListQueryCmd.Flags().StringVarP(&submittedBy, "submitted-by", "",
+		"", "Filter by the email of the user that submitted the queries")
+	ListQueryCmd.Flags().StringVarP(&from, "from", "",
+		"", "Filter by queries created after this time, in RFC3339")
+	ListQueryCmd.Flags().StringVarP(&to, "to", "",
+		"", "Filter by queries created before this time, in RFC3339")
+}
@@ -0,0 +1,33 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// QueriesCmd to manage asynchronous queries
+var QueriesCmd = &cobra.Command{
+	Use:   "queries",
+	Short: "Manage asynchronous analytics queries",
+	Long:  "Manage asynchronous analytics queries, for large results that time out with the stats API",
+}
+
+func init() {
+	QueriesCmd.AddCommand(CrtQueryCmd)
+	QueriesCmd.AddCommand(GetQueryCmd)
+	QueriesCmd.AddCommand(ListQueryCmd)
+	QueriesCmd.AddCommand(ResultQueryCmd)
+}
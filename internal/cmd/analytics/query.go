@@ -0,0 +1,155 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"encoding/csv"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/analytics"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// QueryCmd to run a stats query
+var QueryCmd = &cobra.Command{
+	Use:   "query",
+	Short: "Query analytics metrics by dimension",
+	Long: "Query analytics metrics for any dimensions, with an optional filter, time unit, " +
+		"sort and pagination. The results are printed as a table, csv or json",
+	Example: `Get the p99 latency per target for the last hour:
+` + GetExample(0),
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if timeRange != "" && last > 0 {
+			return fmt.Errorf("time-range and last cannot be used together")
+		}
+		if timeRange == "" && last <= 0 {
+			return fmt.Errorf("time-range or last must be set")
+		}
+		if output != "table" && output != "csv" && output != "json" {
+			return fmt.Errorf("output must be one of table, csv or json")
+		}
+		if sort != "" && sort != "ASC" && sort != "DESC" {
+			return fmt.Errorf("sort must be ASC or DESC")
+		}
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		q := analytics.Query{
+			Environment: env,
+			Dimensions:  dimensions,
+			Select:      metrics,
+			Filter:      filter,
+			TimeRange:   timeRange,
+			TimeUnit:    timeUnit,
+			SortBy:      sortBy,
+			Sort:        sort,
+			TopK:        topK,
+			Limit:       limit,
+			Offset:      offset,
+		}
+		if last > 0 {
+			q.TimeRange = analytics.TimeRange(last, time.Now())
+		}
+
+		if output != "json" {
+			apiclient.DisableCmdPrintHttpResponse()
+		}
+		respBody, err := analytics.Stats(q)
+		if err != nil || output == "json" {
+			return err
+		}
+
+		t, err := analytics.ToTable(respBody, dimensions)
+		if err != nil {
+			return err
+		}
+		if output == "csv" {
+			return writeCSV(cmd.OutOrStdout(), t)
+		}
+		writeTable(cmd.OutOrStdout(), t)
+		return nil
+	},
+}
+
+var (
+	dimensions, metrics                       []string
+	filter, timeRange, timeUnit, sortBy, sort string
+	output                                    string
+	topK, limit, offset                       int
+	last                                      time.Duration
+)
+
+func init() {
+	QueryCmd.Flags().StringSliceVarP(&dimensions, "dimensions", "d",
+		[]string{}, "Dimensions to group by, for ex: apiproxy,target_url")
+	QueryCmd.Flags().StringArrayVarP(&metrics, "select", "s",
+		[]string{}, "Metrics to select, for ex: sum(message_count) or percentile(total_response_time,99)")
+	QueryCmd.Flags().StringVarP(&filter, "filter", "",
+		"", "Filter expression, for ex: (response_status_code ge 500)")
+	QueryCmd.Flags().StringVarP(&timeRange, "time-range", "",
+		"", "Time range in UTC as MM/DD/YYYY HH:MM~MM/DD/YYYY HH:MM")
+	QueryCmd.Flags().DurationVarP(&last, "last", "",
+		0, "Query the last duration instead of a time range, for ex: 1h")
+	QueryCmd.Flags().StringVarP(&timeUnit, "time-unit", "",
+		"", "Split the values by minute, hour, day, week or month")
+	QueryCmd.Flags().StringVarP(&sortBy, "sortby", "",
+		"", "Metric to sort by")
+	QueryCmd.Flags().StringVarP(&sort, "sort", "",
+		"", "Sort order, ASC or DESC")
+	QueryCmd.Flags().IntVarP(&topK, "topk", "",
+		0, "Return only the top k dimension values")
+	QueryCmd.Flags().IntVarP(&limit, "limit", "",
+		0, "Maximum number of results")
+	QueryCmd.Flags().IntVarP(&offset, "offset", "",
+		0, "Number of results to skip")
+	QueryCmd.Flags().StringVarP(&output, "output", "",
+		"table", "Output format, table, csv or json")
+
+	_ = QueryCmd.MarkFlagRequired("dimensions")
+	_ = QueryCmd.MarkFlagRequired("select")
+}
+
+func writeTable(w io.Writer, t analytics.Table) {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	header := make([]string, len(t.Header))
+	for i, h := range t.Header {
+		header[i] = strings.ToUpper(h)
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range t.Rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+}
+
+func writeCSV(w io.Writer, t analytics.Table) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(t.Header); err != nil {
+		return err
+	}
+	if err := cw.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
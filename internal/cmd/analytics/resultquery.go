@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package analytics
+
+import (
+	"internal/apiclient"
+
+	"github.com/spf13/cobra"
+)
+
+// ResultQueryCmd to download the result of a query
+var ResultQueryCmd = &cobra.Command{
+	Use:   "result",
+	Short: "Download the result of an asynchronous query",
+	Long: "Download the result of a completed asynchronous query and decompress it to a folder. " +
+		"With --wait, the command first waits for the query to complete",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		apiclient.DisableCmdPrintHttpResponse()
+		if !wait {
+			return downloadResult(id)
+		}
+		return waitAndDownload(id)
+	},
+}
+
+func init() {
+	ResultQueryCmd.Flags().StringVarP(&id, "id", "i",
+		"", "Query ID")
+	ResultQueryCmd.Flags().StringVarP(&folder, "folder", "f",
+		".", "Folder to write the result to")
+	addWaitFlags(ResultQueryCmd)
+
+	_ = ResultQueryCmd.MarkFlagRequired("id")
+}
@@ -55,7 +55,7 @@ var ImpCmd = &cobra.Command{
 		return apiclient.SetApigeeOrg(org)
 	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return apidocs.Import(siteid, useSrcSiteID, folder)
+		return apidocs.Import(siteid, useSrcSiteID, folder, nil)
 	},
 }
 
@@ -43,4 +43,13 @@ func init() {
 	Cmd.AddCommand(attributes.AttributeCmd)
 	Cmd.AddCommand(projectattachments.ProjectAttachmentCmd)
 	Cmd.AddCommand(projectregistrations.ProjectRegistrationCmd)
+	Cmd.AddCommand(SyncCmd)
+}
+
+var examples = []string{
+	`apigeecli apihub sync -o $org -r us-central1 --default-token`,
+}
+
+func GetExample(i int) string {
+	return examples[i]
 }
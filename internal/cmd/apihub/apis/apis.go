@@ -31,6 +31,7 @@ var org, region string
 
 var examples = []string{
 	`apigeecli apihub apis create -i $apiId -f ./test/api.json -r $region -o $project --default-token`,
+	`apigeecli apihub apis import -f ./specs -r $region -o $project --default-token`,
 }
 
 func init() {
@@ -45,6 +46,7 @@ func init() {
 	ApisCmd.AddCommand(DelCmd)
 	ApisCmd.AddCommand(UpdateCmd)
 	ApisCmd.AddCommand(ExportCmd)
+	ApisCmd.AddCommand(ImpCmd)
 	ApisCmd.AddCommand(versions.ApiVersionsCmd)
 
 	_ = ApisCmd.MarkFlagRequired("org")
@@ -0,0 +1,74 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/hub"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// ImpCmd to import a folder of specs
+var ImpCmd = &cobra.Command{
+	Use:   "import",
+	Short: "Import a folder of OpenAPI, Swagger and proto files",
+	Long: "Import a folder of OpenAPI, Swagger and proto files. The API and version are derived from " +
+		"the info block of each spec (the package of proto files) and the spec id from the file name. " +
+		"The x-team, x-business-unit and x-lifecycle extensions, or a sidecar file like orders.hub.yaml " +
+		"for orders.yaml, are mapped to API hub attributes",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		apiclient.DisableCmdPrintHttpResponse()
+
+		specs, err := hub.ReadSpecFolder(folder)
+		if err != nil {
+			return err
+		}
+		if len(specs) == 0 {
+			return fmt.Errorf("no OpenAPI, Swagger or proto files found in %s", folder)
+		}
+		results, err := hub.ImportSpecs(specs, lint)
+		for _, r := range results {
+			if r.Err != nil {
+				fmt.Printf("%s: %v\n", r.File, r.Err)
+				continue
+			}
+			fmt.Printf("%s: imported to %s, %d lint issues\n", r.File, r.Spec, len(r.Issues))
+			for _, i := range r.Issues {
+				fmt.Printf("  %s %s %s: %s\n", i.Severity, i.Code, strings.Join(i.Path, "."), i.Message)
+			}
+		}
+		return err
+	},
+	Example: `Import a folder of specs: ` + GetExample(1),
+}
+
+var lint bool
+
+func init() {
+	ImpCmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Folder containing the spec files")
+	ImpCmd.Flags().BoolVarP(&lint, "lint", "",
+		true, "Lint each spec after it is imported")
+
+	_ = ImpCmd.MarkFlagRequired("folder")
+}
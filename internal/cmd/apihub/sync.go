@@ -0,0 +1,78 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apihub
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/hub"
+
+	"github.com/spf13/cobra"
+)
+
+// SyncCmd to sync Apigee proxies to API hub
+var SyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync Apigee API proxies and deployments to API Hub",
+	Long: "Sync Apigee API proxies and deployments to API Hub. Each proxy is registered as an API, " +
+		"each deployed revision as a version, each env group hostname and base path as a deployment, " +
+		"and the OAS files in resources/oas of the bundle as specs. Re-running sync updates the existing " +
+		"entries; entries that no longer match a proxy, revision or deployment are reported as stale",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		apiclient.DisableCmdPrintHttpResponse()
+
+		apiclient.SetRegion(apigeeRegion)
+		inv, err := hub.ReadInventory(proxies)
+		if err != nil {
+			return err
+		}
+		apiclient.SetRegion(region)
+		report, err := hub.Sync(inv)
+		for _, r := range report.Created {
+			fmt.Printf("created %s\n", r)
+		}
+		for _, r := range report.Updated {
+			fmt.Printf("updated %s\n", r)
+		}
+		for _, r := range report.Stale {
+			fmt.Printf("stale %s\n", r)
+		}
+		return err
+	},
+	Example: `Sync all the proxies of the org to API Hub: ` + GetExample(0),
+}
+
+var (
+	org, region, apigeeRegion string
+	proxies                   []string
+)
+
+func init() {
+	SyncCmd.Flags().StringVarP(&org, "org", "o",
+		"", "Apigee organization name")
+	SyncCmd.Flags().StringVarP(&region, "region", "r",
+		"", "API Hub region name")
+	SyncCmd.Flags().StringVarP(&apigeeRegion, "apigee-region", "",
+		"", "Apigee control plane region for data residency orgs")
+	SyncCmd.Flags().StringSliceVarP(&proxies, "proxies", "p",
+		[]string{}, "Names of the proxies to sync; all the proxies are synced when not set")
+
+	_ = SyncCmd.MarkFlagRequired("org")
+	_ = SyncCmd.MarkFlagRequired("region")
+}
@@ -47,6 +47,19 @@ var examples = []string{
 --owner=apigee \
 --repo=api-platform-samples \
 --proxy-path=sample-proxies/apikey  --default-token`,
+	"apigeecli apis lint -f ./apiproxy --export-folder=./export --output=sarif > lint.sarif",
+	"apigeecli apis test -b ./apiproxy -f ./tests/orders.yaml",
+	"apigeecli apis diff --from=orders@test --to=orders@prod --xml --default-token",
+	`apigeecli apis rollout -n orders -e prod --canary-basepath=/canary \
+--window=30m --max-error-rate=2 --max-latency=800ms --log-file=orders-rollout.log --default-token`,
+	"apigeecli apis generate-spec -n orders -f orders.yaml --default-token",
+	`apigeecli apis create oas -n petstore-mock --space=space1 \
+-f ./samples/petstore.yaml \
+--mock --env=$env --wait=true --default-token`,
+	`apigeecli apis create grpc -n greeter -f ./helloworld.proto \
+--target-server-name=greeter-grpc --product=greeter-product --env=$env --default-token`,
+	`apigeecli apis create template -n orders --template-dir=./templates/standard \
+-f ./orders.yaml --params=./orders-params.yaml --policy-template-dir=./policy-templates --default-token`,
 }
 
 func init() {
@@ -71,6 +84,11 @@ func init() {
 	Cmd.AddCommand(UpdateCmd)
 	Cmd.AddCommand(CloneCmd)
 	Cmd.AddCommand(MoveCmd)
+	Cmd.AddCommand(LintCmd)
+	Cmd.AddCommand(TestCmd)
+	Cmd.AddCommand(DiffCmd)
+	Cmd.AddCommand(RolloutCmd)
+	Cmd.AddCommand(GenSpecCmd)
 }
 
 func GetExample(i int) string {
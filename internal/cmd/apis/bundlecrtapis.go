@@ -109,7 +109,7 @@ var BundleCreateCmd = &cobra.Command{
 				return err
 			}
 			if wait {
-				return apis.Wait(name, revision)
+				return apis.Wait(name, revision, waitTimeout)
 			}
 		}
 		return err
@@ -134,6 +134,8 @@ func init() {
 		false, "Forces deployment of the new revision")
 	BundleCreateCmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the deployment to finish, with success or error")
+	BundleCreateCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the deployment, for ex: 5m; default is no limit")
 	BundleCreateCmd.Flags().BoolVarP(&sequencedRollout, "sequencedrollout", "",
 		false, "If set to true, the routing rules will be rolled out in a safe order; default is false")
 	BundleCreateCmd.Flags().BoolVarP(&safeDeploy, "safedeploy", "",
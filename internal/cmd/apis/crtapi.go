@@ -15,6 +15,8 @@
 package apis
 
 import (
+	"internal/bundlegen/policies"
+
 	"github.com/spf13/cobra"
 )
 
@@ -28,15 +30,32 @@ var CreateCmd = &cobra.Command{
 var (
 	targetURLRef                     string
 	importProxy, skipPolicy, addCORS bool
+	policyTemplateDir                string
 )
 
 func init() {
+	// only the generators of these commands use the policy templates
+	for _, cmd := range []*cobra.Command{OasCreatev2Cmd, GqlCreateCmd, IntegrationCmd, SwaggerCreateCmd, TemplateCreateCmd} {
+		cmd.Flags().StringVarP(&policyTemplateDir, "policy-template-dir", "",
+			"", "Folder of policy templates overriding the built-in ones, ex: cors.xml, spike-arrest.xml")
+	}
+
 	// disable v1 of OasCreate
 	// CreateCmd.AddCommand(OasCreateCmd)
 	CreateCmd.AddCommand(OasCreatev2Cmd)
 	CreateCmd.AddCommand(GhCreateCmd)
 	CreateCmd.AddCommand(BundleCreateCmd)
 	CreateCmd.AddCommand(GqlCreateCmd)
+	CreateCmd.AddCommand(GrpcCreateCmd)
 	CreateCmd.AddCommand(IntegrationCmd)
 	CreateCmd.AddCommand(SwaggerCreateCmd)
+	CreateCmd.AddCommand(TemplateCreateCmd)
+}
+
+// overridePolicyTemplates replaces the built-in policy templates when a folder is passed
+func overridePolicyTemplates() error {
+	if policyTemplateDir == "" {
+		return nil
+	}
+	return policies.OverridePolicyTemplates(policyTemplateDir)
 }
@@ -27,6 +27,9 @@ var CreateTrcCmd = &cobra.Command{
 	Short: "Create a new debug session for an API proxy",
 	Long:  "Create a new debug session for Apigee API proxy revision deployed in an environment",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = checkEnv(); err != nil {
+			return err
+		}
 		apiclient.SetApigeeEnv(env)
 		apiclient.SetRegion(region)
 		return apiclient.SetApigeeOrg(org)
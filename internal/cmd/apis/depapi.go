@@ -17,6 +17,7 @@ package apis
 import (
 	"internal/apiclient"
 	"internal/client/apis"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -50,7 +51,7 @@ var DepCmd = &cobra.Command{
 		}
 
 		if wait {
-			err = apis.Wait(name, revision)
+			err = apis.Wait(name, revision, waitTimeout)
 		}
 		return err
 	},
@@ -59,6 +60,7 @@ var DepCmd = &cobra.Command{
 var (
 	overrides, wait, sequencedRollout, safeDeploy bool
 	serviceAccountName                            string
+	waitTimeout                                   time.Duration
 )
 
 func init() {
@@ -72,6 +74,8 @@ func init() {
 		false, "Forces deployment of the new revision")
 	DepCmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the deployment to finish, with success or error")
+	DepCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the deployment, for ex: 5m; default is no limit")
 	DepCmd.Flags().BoolVarP(&sequencedRollout, "sequencedrollout", "",
 		false, "If set to true, the routing rules will be rolled out in a safe order; default is false")
 	DepCmd.Flags().BoolVarP(&safeDeploy, "safedeploy", "",
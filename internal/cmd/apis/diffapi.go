@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"internal/apiclient"
+	"internal/bundlegen/diff"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// DiffCmd to compare two API proxy bundles
+var DiffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Compare two API proxy bundles",
+	Long: "Compare two API proxy bundles and print a unified diff per file. A bundle is a revision " +
+		"(name@revision), the revision deployed to an environment (name@environment) or a local zip file or folder",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if diff.IsLocal(diffFrom) && diff.IsLocal(diffTo) {
+			return nil
+		}
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		tmpDir, err := os.MkdirTemp("", "proxy")
+		if err != nil {
+			return err
+		}
+		defer os.RemoveAll(tmpDir)
+
+		fetch := func(name string, revision string, environment string) (string, error) {
+			if environment != "" {
+				apiclient.SetApigeeEnv(environment)
+			}
+			return apiclient.DownloadBundle("apis", tmpDir, name, revision)
+		}
+		diffs, err := diff.Sources(diffFrom, diffTo, fetch, diff.Options{XML: xmlAware, Context: diffContext})
+		if err != nil {
+			return err
+		}
+		diff.Write(os.Stdout, diffs)
+		return nil
+	},
+	Example: `Compare the revisions deployed to test and prod, ignoring XML formatting: ` + GetExample(8),
+}
+
+var (
+	diffFrom, diffTo string
+	xmlAware         bool
+	diffContext      int
+)
+
+func init() {
+	DiffCmd.Flags().StringVarP(&diffFrom, "from", "",
+		"", "API Proxy bundle to compare from; name@revision, name@environment or a zip file or folder")
+	DiffCmd.Flags().StringVarP(&diffTo, "to", "",
+		"", "API Proxy bundle to compare to; name@revision, name@environment or a zip file or folder")
+	DiffCmd.Flags().BoolVarP(&xmlAware, "xml", "",
+		false, "Compare XML files ignoring attribute order and whitespace")
+	DiffCmd.Flags().IntVarP(&diffContext, "context", "U",
+		3, "Number of unchanged lines shown around a change")
+
+	_ = DiffCmd.MarkFlagRequired("from")
+	_ = DiffCmd.MarkFlagRequired("to")
+}
@@ -36,11 +36,11 @@ var ExpCmd = &cobra.Command{
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
-		return apis.ExportProxies(conn, folder, allRevisions, space)
+		return apis.ExportProxies(conn, folder, allRevisions, space, incremental)
 	},
 }
 
-var allRevisions bool
+var allRevisions, incremental bool
 
 func init() {
 	ExpCmd.Flags().IntVarP(&conn, "conn", "c",
@@ -51,4 +51,7 @@ func init() {
 		false, "Export all proxy revisions")
 	ExpCmd.Flags().StringVarP(&space, "space", "",
 		"", "Apigee Space associated to")
+	ExpCmd.Flags().BoolVarP(&incremental, "incremental", "",
+		false, "Download only new or changed revisions and remove bundles of deleted proxys; "+
+			"uses the manifest.json file in the folder")
 }
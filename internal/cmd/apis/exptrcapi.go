@@ -0,0 +1,79 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/debugsessions"
+
+	"github.com/spf13/cobra"
+)
+
+// ExpTrcCmd to export the transactions of a debug session
+var ExpTrcCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the transactions of a debug session to an archive",
+	Long: "Export every transaction of a debug session to a zip archive with the raw trace JSON, " +
+		"the requests and responses as HAR 1.2 and a per policy timing summary",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = checkEnv(); err != nil {
+			return err
+		}
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		if revision == -1 {
+			revisions, err := apiclient.DeployedRevisions("apis", env, name)
+			if err != nil {
+				return err
+			}
+			switch len(revisions) {
+			case 0:
+				return fmt.Errorf("%s is not deployed to %s", name, env)
+			case 1:
+				revision = revisions[0]
+			default:
+				return fmt.Errorf("%s has %d revisions deployed to %s, a revision must be set",
+					name, len(revisions), env)
+			}
+		}
+		if archiveFile == "" {
+			archiveFile = name + "_" + sessionID + ".zip"
+		}
+		_, err = debugsessions.Export(debugsessions.NewSession(name, revision, sessionID), archiveFile)
+		return err
+	},
+}
+
+var archiveFile string
+
+func init() {
+	ExpTrcCmd.Flags().StringVarP(&name, "name", "n",
+		"", "API proxy name")
+	ExpTrcCmd.Flags().IntVarP(&revision, "rev", "v",
+		-1, "API Proxy revision. If not set, the revision deployed to the environment is used")
+	ExpTrcCmd.Flags().StringVarP(&sessionID, "ses", "s",
+		"", "Debug session Id")
+	ExpTrcCmd.Flags().StringVarP(&archiveFile, "file", "f",
+		"", "Archive to write; default is <name>_<session>.zip")
+
+	_ = ExpTrcCmd.MarkFlagRequired("name")
+	_ = ExpTrcCmd.MarkFlagRequired("ses")
+}
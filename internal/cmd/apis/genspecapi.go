@@ -0,0 +1,103 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/apiclient"
+	proxytypes "internal/bundlegen/common"
+	"internal/bundlegen/specgen"
+	"internal/client/apis"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+// GenSpecCmd to generate an OpenAPI spec from an API proxy bundle
+var GenSpecCmd = &cobra.Command{
+	Use:   "generate-spec",
+	Short: "Generate an OpenAPI 3.1 spec from an API proxy",
+	Long: "Generate an OpenAPI 3.1 skeleton from a revision of an API proxy or a local bundle. Paths and " +
+		"operations are read from the conditional flows, security schemes from the VerifyAPIKey, OAuthV2 " +
+		"and VerifyJWT policies and operations from the OAS resources of OASValidation policies. Operations " +
+		"inferred from the flows have an " + specgen.InferredExtension + " extension with their confidence",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if (name == "") == (specBundle == "") {
+			return fmt.Errorf("either name or bundle must be specified, not both")
+		}
+		if specBundle != "" {
+			return nil
+		}
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		bundlePath := specBundle
+		if name != "" {
+			if revision == -1 {
+				if revision, err = apis.GetHighestProxyRevision(name); err != nil {
+					return err
+				}
+			}
+			tmpDir, err := os.MkdirTemp("", "proxy")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(tmpDir)
+			if bundlePath, err = apiclient.DownloadBundle("apis", tmpDir, name, strconv.Itoa(revision)); err != nil {
+				return err
+			}
+		}
+		b, err := proxytypes.ReadBundle(bundlePath)
+		if err != nil {
+			return err
+		}
+		doc, err := specgen.Generate(b, specgen.Options{})
+		if err != nil {
+			return err
+		}
+		format := "yaml"
+		if filepath.Ext(specFile) == ".json" {
+			format = "json"
+		}
+		content, err := specgen.Marshal(doc, format)
+		if err != nil {
+			return err
+		}
+		if specFile == "" {
+			_, err = os.Stdout.Write(content)
+			return err
+		}
+		return apiclient.WriteByteArrayToFile(specFile, false, content)
+	},
+	Example: `Generate a spec from the highest revision of a proxy: ` + GetExample(10),
+}
+
+var specBundle, specFile string
+
+func init() {
+	GenSpecCmd.Flags().StringVarP(&name, "name", "n",
+		"", "API proxy name")
+	GenSpecCmd.Flags().IntVarP(&revision, "rev", "v",
+		-1, "API Proxy revision. If not set, the highest revision is used")
+	GenSpecCmd.Flags().StringVarP(&specBundle, "bundle", "b",
+		"", "Path to the Proxy bundle/zip file or folder; ex: ./test/apiproxy")
+	GenSpecCmd.Flags().StringVarP(&specFile, "file", "f",
+		"", "File the spec is written to, as JSON with a .json extension or YAML; default is stdout")
+}
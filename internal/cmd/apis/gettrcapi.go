@@ -27,6 +27,9 @@ var GetTrcCmd = &cobra.Command{
 	Short: "Get a debug session for an API proxy revision",
 	Long:  "Get a debug session for an API proxy revision deployed in an environment",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = checkEnv(); err != nil {
+			return err
+		}
 		apiclient.SetApigeeEnv(env)
 		apiclient.SetRegion(region)
 		return apiclient.SetApigeeOrg(org)
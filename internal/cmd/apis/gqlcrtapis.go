@@ -52,6 +52,10 @@ var GqlCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		if err = overridePolicyTemplates(); err != nil {
+			return err
+		}
+
 		var content []byte
 		var gqlDocName string
 		if gqlFile != "" {
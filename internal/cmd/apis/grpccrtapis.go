@@ -0,0 +1,143 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"encoding/json"
+	"internal/apiclient"
+	"internal/client/apis"
+	"internal/client/products"
+	"internal/clilog"
+	"os"
+	"path/filepath"
+
+	bundle "internal/bundlegen"
+	proxybundle "internal/bundlegen/proxybundle"
+
+	"github.com/spf13/cobra"
+)
+
+var GrpcCreateCmd = &cobra.Command{
+	Use:   "grpc",
+	Short: "Creates a gRPC API proxy from a proto file",
+	Long: "Creates a gRPC API proxy from a proto file, with a flow per method and a target endpoint per service. " +
+		"The target endpoints use a target server of the GRPC protocol",
+	Example: `Create a gRPC API Proxy and an API product for its methods:
+` + GetExample(12),
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if env != "" {
+			apiclient.SetApigeeEnv(env)
+		}
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		content, err := os.ReadFile(protoFile)
+		if err != nil {
+			return err
+		}
+		protoDef, err := bundle.ParseProto(content)
+		if err != nil {
+			return err
+		}
+
+		// Generate the apiproxy struct
+		if err = bundle.GenerateAPIProxyDefFromProto(name,
+			desc,
+			filepath.Base(protoFile),
+			protoDef,
+			basePath,
+			targetServerName); err != nil {
+			return err
+		}
+
+		// Create the API proxy bundle
+		if err = proxybundle.GenerateAPIProxyBundleFromProto(name); err != nil {
+			return err
+		}
+
+		grpcOperationGroup := &products.GrpcOperationGroup{}
+		for _, service := range protoDef.Services {
+			methods := []string{}
+			for _, method := range service.Methods {
+				methods = append(methods, method.Name)
+			}
+			grpcOperationGroup.AddOperation(name, protoDef.FullName(service), methods)
+		}
+
+		if !importProxy {
+			if productName != "" {
+				// write the operation group to use with products create --grpcopgrp
+				payload, err := json.MarshalIndent(grpcOperationGroup, "", "  ")
+				if err != nil {
+					return err
+				}
+				clilog.Info.Printf("Writing the gRPC operation group to %s\n", productName+"-grpcopgrp.json")
+				return apiclient.WriteByteArrayToFile(productName+"-grpcopgrp.json", false, payload)
+			}
+			return nil
+		}
+
+		if _, err = apis.CreateProxy(name, name+zipExt, space); err != nil {
+			return err
+		}
+
+		if productName != "" {
+			p := products.APIProduct{
+				Name:               productName,
+				DisplayName:        productName,
+				ApprovalType:       "auto",
+				GrpcOperationGroup: grpcOperationGroup,
+				Space:              space,
+			}
+			if env != "" {
+				p.Environments = []string{env}
+			}
+			_, err = products.Create(p)
+		}
+
+		return err
+	},
+}
+
+var protoFile, productName string
+
+func init() {
+	GrpcCreateCmd.Flags().StringVarP(&name, "name", "n",
+		"", "API Proxy name")
+	GrpcCreateCmd.Flags().StringVarP(&protoFile, "proto", "f",
+		"", "Proto file with the gRPC services")
+	GrpcCreateCmd.Flags().StringVarP(&basePath, "basepath", "p",
+		"", "Base Path of the API Proxy; defaults to the service name when the proto file has one service, / otherwise")
+	GrpcCreateCmd.Flags().StringVarP(&targetServerName, "target-server-name", "",
+		"", "Name of a target server of the GRPC protocol for the target endpoints")
+	GrpcCreateCmd.Flags().StringVarP(&productName, "product", "",
+		"", "Create an API product with a grpcOperationGroup covering the methods of the proto file; "+
+			"the operation group is written to a file when the API Proxy is not imported")
+	GrpcCreateCmd.Flags().StringVarP(&env, "env", "e",
+		"", "Apigee environment name to add to the API product")
+	GrpcCreateCmd.Flags().StringVarP(&space, "space", "",
+		"", "Apigee Space to associate to")
+	GrpcCreateCmd.Flags().BoolVarP(&importProxy, "import", "",
+		true, "Import API Proxy after generation from the proto file")
+	GrpcCreateCmd.Flags().StringVarP(&desc, "desc", "d",
+		"", "Sets the API Proxy description")
+
+	_ = GrpcCreateCmd.MarkFlagRequired("name")
+	_ = GrpcCreateCmd.MarkFlagRequired("proto")
+	_ = GrpcCreateCmd.MarkFlagRequired("target-server-name")
+}
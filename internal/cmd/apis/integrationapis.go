@@ -37,6 +37,10 @@ var IntegrationCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		if err = overridePolicyTemplates(); err != nil {
+			return err
+		}
+
 		tmpDir, err := os.MkdirTemp("", "proxy")
 		if err != nil {
 			return err
@@ -0,0 +1,61 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/bundlegen/lint"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// LintCmd to lint an API proxy bundle
+var LintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint an API proxy bundle",
+	Long: "Lint an API proxy bundle (zip or folder) without connecting to Apigee. Reports undefined " +
+		"and unused policies, steps referencing missing policies, flows with duplicate conditions, " +
+		"hard-coded credentials, endpoints without fault rules and, with an export folder, " +
+		"target servers and KVMs that do not exist",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if (proxyZip == "") == (proxyFolder == "") {
+			return fmt.Errorf("either proxy bundle (zip) or folder must be specified, not both")
+		}
+		return lint.CheckFormat(lintOutput)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		bundlePath := proxyZip
+		if proxyFolder != "" {
+			bundlePath = proxyFolder
+		}
+		return lint.Run(os.Stdout, bundlePath, lint.Options{ExportFolder: exportFolder}, lintOutput)
+	},
+	Example: `Lint a proxy folder and write SARIF: ` + GetExample(6),
+}
+
+var exportFolder, lintOutput string
+
+func init() {
+	LintCmd.Flags().StringVarP(&proxyZip, "proxy-zip", "p",
+		"", "Path to the Proxy bundle/zip file")
+	LintCmd.Flags().StringVarP(&proxyFolder, "proxy-folder", "f",
+		"", "Path to the Proxy Bundle; ex: ./test/apiproxy")
+	LintCmd.Flags().StringVarP(&exportFolder, "export-folder", "",
+		"", "Folder created by organizations export, to check target servers and KVMs")
+	LintCmd.Flags().StringVarP(&lintOutput, "output", "",
+		"text", "Output format: text, json or sarif")
+}
@@ -27,6 +27,9 @@ var ListTrcCmd = &cobra.Command{
 	Short: "List all debug sessions for an API proxy revision",
 	Long:  "List all debug sessions for an API proxy revision deployed in an environment",
 	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = checkEnv(); err != nil {
+			return err
+		}
 		apiclient.SetApigeeEnv(env)
 		apiclient.SetRegion(region)
 		return apiclient.SetApigeeOrg(org)
@@ -37,7 +37,10 @@ var OasCreatev2Cmd = &cobra.Command{
 ` + GetExample(1) + `
 
 Create an API Proxy from OAS and deploy the proxy to an environment:
-` + GetExample(2),
+` + GetExample(2) + `
+
+Create a mock API Proxy returning the examples of the OAS; set the ` + bundle.MockStatusHeader + ` header to pick a status code:
+` + GetExample(11),
 	Args: func(cmd *cobra.Command, args []string) (err error) {
 		if oasFile == "" && oasURI == "" {
 			return fmt.Errorf("either oas-base-folderpath or oas-base-uri must be passed")
@@ -57,6 +60,9 @@ Create an API Proxy from OAS and deploy the proxy to an environment:
 		if targetURL != "" && targetServerName != "" {
 			return fmt.Errorf("targetURL and targetServerName cannot be set at the same time")
 		}
+		if mock && (targetURL != "" || targetURLRef != "" || targetServerName != "" || integration != "") {
+			return fmt.Errorf("mock cannot be set with target-url, target-url-ref, target-server-name or integration")
+		}
 
 		if env != "" {
 			apiclient.SetApigeeEnv(env)
@@ -67,6 +73,10 @@ Create an API Proxy from OAS and deploy the proxy to an environment:
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		if err = overridePolicyTemplates(); err != nil {
+			return err
+		}
+
 		var content []byte
 
 		if oasFile != "" {
@@ -103,6 +113,7 @@ Create an API Proxy from OAS and deploy the proxy to an environment:
 				TargetURL:                       targetURL,
 				TargetServerName:                targetServerName,
 			},
+			Mock: mock,
 		}
 
 		// Generate the apiproxy struct
@@ -143,7 +154,7 @@ Create an API Proxy from OAS and deploy the proxy to an environment:
 					return err
 				}
 				if wait {
-					return apis.Wait(name, revision)
+					return apis.Wait(name, revision, waitTimeout)
 				}
 			}
 		}
@@ -155,7 +166,7 @@ Create an API Proxy from OAS and deploy the proxy to an environment:
 var (
 	specName, oasFile, oasURI, targetURL, targetServerName, desc                        string
 	oasGoogleAcessTokenScopeLiteral, oasGoogleIDTokenAudLiteral, oasGoogleIDTokenAudRef string
-	validateSpec, formatValidation                                                      bool
+	validateSpec, formatValidation, mock                                                bool
 )
 
 func init() {
@@ -181,6 +192,8 @@ func init() {
 		"", "Set a target URL for the target endpoint")
 	OasCreatev2Cmd.Flags().StringVarP(&targetServerName, "target-server-name", "",
 		"", "Set a target server name for the target endpoint")
+	OasCreatev2Cmd.Flags().BoolVarP(&mock, "mock", "",
+		false, "Generate mock responses from the examples and schemas of the spec instead of a target")
 	OasCreatev2Cmd.Flags().StringVarP(&integration, "integration", "i",
 		"", "Integration name")
 	OasCreatev2Cmd.Flags().StringVarP(&apitrigger, "trigger", "",
@@ -202,6 +215,8 @@ func init() {
 		false, "Forces deployment of the new revision")
 	OasCreatev2Cmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the deployment to finish, with success or error")
+	OasCreatev2Cmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the deployment, for ex: 5m; default is no limit")
 	OasCreatev2Cmd.Flags().BoolVarP(&sequencedRollout, "sequencedrollout", "",
 		false, "If set to true, the routing rules will be rolled out in a safe order; default is false")
 	OasCreatev2Cmd.Flags().BoolVarP(&safeDeploy, "safedeploy", "",
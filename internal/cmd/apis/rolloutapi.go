@@ -0,0 +1,112 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/apis"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// RolloutCmd to deploy a revision as a canary and promote it
+var RolloutCmd = &cobra.Command{
+	Use:   "rollout",
+	Short: "Deploys a revision as a canary, then promotes or rolls it back",
+	Long: "Deploys a revision of an API proxy to a canary environment, or a copy of the revision under a " +
+		"canary base path, and watches its error rate and average latency with the stats API for a window. " +
+		"The revision is then deployed to the environment, or the canary is removed and the previous revision " +
+		"continues to serve. Every decision is appended to a log file",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if (canaryEnv == "") == (canaryBasePath == "") {
+			return fmt.Errorf("either canary-env or canary-basepath must be set, not both")
+		}
+		if interval <= 0 || window < interval {
+			return fmt.Errorf("interval must be positive and window must be longer than interval")
+		}
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		if revision == -1 {
+			if revision, err = apis.GetHighestProxyRevision(name); err != nil {
+				return err
+			}
+		}
+		return apis.Rollout(apis.RolloutOptions{
+			Name:               name,
+			Revision:           revision,
+			Environment:        env,
+			CanaryEnvironment:  canaryEnv,
+			CanaryBasePath:     canaryBasePath,
+			Window:             window,
+			Interval:           interval,
+			MaxErrorRate:       maxErrorRate,
+			MaxLatency:         maxLatency,
+			MinRequests:        minRequests,
+			WaitTimeout:        waitTimeout,
+			ServiceAccountName: serviceAccountName,
+			Space:              space,
+			LogFile:            rolloutLog,
+		})
+	},
+	Example: `Watch a canary under /canary for 30 minutes before the promotion: ` + GetExample(9),
+}
+
+var (
+	canaryEnv, canaryBasePath, rolloutLog string
+	window, interval, maxLatency          time.Duration
+	maxErrorRate                          float64
+	minRequests                           int
+)
+
+func init() {
+	RolloutCmd.Flags().StringVarP(&name, "name", "n",
+		"", "API proxy name")
+	RolloutCmd.Flags().StringVarP(&env, "env", "e",
+		"", "Apigee environment name")
+	RolloutCmd.Flags().IntVarP(&revision, "rev", "v",
+		-1, "API Proxy revision. If not set, the highest revision is used")
+	RolloutCmd.Flags().StringVarP(&canaryEnv, "canary-env", "",
+		"", "Environment, attached to its own environment group, where the canary is deployed")
+	RolloutCmd.Flags().StringVarP(&canaryBasePath, "canary-basepath", "",
+		"", "Base path prepended to the base paths of the canary, deployed as the proxy <name>-canary")
+	RolloutCmd.Flags().DurationVarP(&window, "window", "",
+		15*time.Minute, "How long the canary is watched; analytics can take several minutes to be available")
+	RolloutCmd.Flags().DurationVarP(&interval, "interval", "",
+		time.Minute, "Time between two checks of the canary analytics")
+	RolloutCmd.Flags().Float64VarP(&maxErrorRate, "max-error-rate", "",
+		5, "Highest percentage of errors of the canary")
+	RolloutCmd.Flags().DurationVarP(&maxLatency, "max-latency", "",
+		0, "Highest average total response time of the canary, for ex: 500ms; default is no limit")
+	RolloutCmd.Flags().IntVarP(&minRequests, "min-requests", "",
+		10, "Lowest number of requests to the canary to promote it")
+	RolloutCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		10*time.Minute, "Maximum time to wait for a deployment of the canary or the promotion")
+	RolloutCmd.Flags().StringVarP(&serviceAccountName, "sa", "s",
+		"", "The format must be {ACCOUNT_ID}@{PROJECT}.iam.gserviceaccount.com.")
+	RolloutCmd.Flags().StringVarP(&space, "space", "",
+		"", "Apigee Space of the canary proxy")
+	RolloutCmd.Flags().StringVarP(&rolloutLog, "log-file", "",
+		"rollout.log", "File the rollout decisions are appended to, one JSON object per line")
+
+	_ = RolloutCmd.MarkFlagRequired("env")
+	_ = RolloutCmd.MarkFlagRequired("name")
+}
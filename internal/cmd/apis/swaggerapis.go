@@ -39,6 +39,10 @@ var SwaggerCreateCmd = &cobra.Command{
 	RunE: func(cmd *cobra.Command, args []string) (err error) {
 		cmd.SilenceUsage = true
 
+		if err = overridePolicyTemplates(); err != nil {
+			return err
+		}
+
 		// var content []byte
 		var oasDocName string
 
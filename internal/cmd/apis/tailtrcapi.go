@@ -0,0 +1,80 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/debugsessions"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// TailTrcCmd to stream the transactions of a debug session
+var TailTrcCmd = &cobra.Command{
+	Use:   "tail",
+	Short: "Stream debug transactions of an API proxy",
+	Long: "Create a debug session for an API proxy deployed in an environment and print each " +
+		"transaction as it completes, with the policies executed, the flow variables set, " +
+		"the request, the response and the errors",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if err = checkEnv(); err != nil {
+			return err
+		}
+		if tailDuration <= 0 || tailDuration > 10*time.Minute {
+			return fmt.Errorf("duration must be between 1s and 10m")
+		}
+		apiclient.SetApigeeEnv(env)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		sessions, err := debugsessions.CreateSessions([]string{name}, revision, tailDuration)
+		if err != nil {
+			return err
+		}
+		return debugsessions.Tail(sessions, debugsessions.Filter{StatusCodes: statusCodes, Path: pathPrefix},
+			tailInterval, tailDuration, func(t debugsessions.Transaction, raw []byte) error {
+				debugsessions.Render(cmd.OutOrStdout(), t)
+				return nil
+			})
+	},
+}
+
+var (
+	statusCodes                []string
+	pathPrefix                 string
+	tailInterval, tailDuration time.Duration
+)
+
+func init() {
+	TailTrcCmd.Flags().StringVarP(&name, "name", "n",
+		"", "API proxy name")
+	TailTrcCmd.Flags().IntVarP(&revision, "rev", "v",
+		-1, "API Proxy revision. If not set, the revisions deployed to the environment are used")
+	TailTrcCmd.Flags().StringArrayVarP(&statusCodes, "status", "",
+		[]string{}, "Show only transactions with a status code, for ex: 500 or 5xx")
+	TailTrcCmd.Flags().StringVarP(&pathPrefix, "path", "",
+		"", "Show only transactions with a request path starting with this prefix")
+	TailTrcCmd.Flags().DurationVarP(&tailInterval, "interval", "",
+		2*time.Second, "Time between checks for new transactions")
+	TailTrcCmd.Flags().DurationVarP(&tailDuration, "duration", "",
+		10*time.Minute, "How long to capture transactions; a debug session lasts at most 10m")
+
+	_ = TailTrcCmd.MarkFlagRequired("name")
+}
@@ -0,0 +1,62 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/bundlegen/testrunner"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// TestCmd to run request fixtures against an API proxy bundle
+var TestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Test an API proxy bundle with request fixtures",
+	Long: "Run the request fixtures of a YAML test file against an API proxy bundle (zip or folder) " +
+		"without connecting to Apigee. Evaluates flow conditions, route rules, conditional steps and " +
+		"the AssignMessage, ExtractVariables and RaiseFault policies; other policies, like JavaScript, " +
+		"set the variables of their stubs. Asserts on the flows and policies executed and on the response",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		suite, err := testrunner.ReadSuite(testFile)
+		if err != nil {
+			return err
+		}
+		results, err := testrunner.Run(testBundle, suite)
+		if err != nil {
+			return err
+		}
+		testrunner.WriteText(os.Stdout, results)
+		if failed := testrunner.Failed(results); failed > 0 {
+			return fmt.Errorf("%d tests failed", failed)
+		}
+		return nil
+	},
+	Example: `Run the tests of a proxy folder: ` + GetExample(7),
+}
+
+var testBundle, testFile string
+
+func init() {
+	TestCmd.Flags().StringVarP(&testBundle, "bundle", "b",
+		"", "Path to the Proxy bundle/zip file or folder; ex: ./test/apiproxy")
+	TestCmd.Flags().StringVarP(&testFile, "file", "f",
+		"", "Path to the YAML file with the tests")
+
+	_ = TestCmd.MarkFlagRequired("bundle")
+	_ = TestCmd.MarkFlagRequired("file")
+}
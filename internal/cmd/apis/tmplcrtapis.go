@@ -0,0 +1,109 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"internal/apiclient"
+	"internal/client/apis"
+	"os"
+
+	"internal/bundlegen/bundletemplate"
+	proxybundle "internal/bundlegen/proxybundle"
+
+	"github.com/spf13/cobra"
+)
+
+var TemplateCreateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Creates an API proxy from a bundle template",
+	Long: "Creates an API proxy from a bundle template, a folder with an apiproxy folder whose paths and XML files " +
+		"are Go templates. The templates can use .Name, .BasePath, .Description, .Spec with the operations of an " +
+		"OpenAPI 3 spec and .Params with the values of a params file",
+	Example: `Create an API Proxy from a bundle template, with a flow per operation of the spec:
+` + GetExample(13),
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		if err = overridePolicyTemplates(); err != nil {
+			return err
+		}
+
+		values := bundletemplate.Values{
+			Name:        name,
+			BasePath:    basePath,
+			Description: desc,
+		}
+
+		if templateSpecFile != "" {
+			content, err := os.ReadFile(templateSpecFile)
+			if err != nil {
+				return err
+			}
+			if values.Spec, err = bundletemplate.ReadSpec(content); err != nil {
+				return err
+			}
+			if values.BasePath == "" {
+				values.BasePath = values.Spec.BasePath
+			}
+			if values.Description == "" {
+				values.Description = values.Spec.Description
+			}
+		}
+
+		if paramsFile != "" {
+			if values.Params, err = bundletemplate.ReadParams(paramsFile); err != nil {
+				return err
+			}
+		}
+
+		if err = proxybundle.GenerateAPIProxyBundleFromTemplate(name, templateDir, values); err != nil {
+			return err
+		}
+
+		if importProxy {
+			_, err = apis.CreateProxy(name, name+zipExt, space)
+		}
+
+		return err
+	},
+}
+
+var templateDir, templateSpecFile, paramsFile string
+
+func init() {
+	TemplateCreateCmd.Flags().StringVarP(&name, "name", "n",
+		"", "API Proxy name")
+	TemplateCreateCmd.Flags().StringVarP(&templateDir, "template-dir", "",
+		"", "Bundle template folder, containing an apiproxy folder")
+	TemplateCreateCmd.Flags().StringVarP(&templateSpecFile, "oas-file", "f",
+		"", "Open API 3.0/3.1 Specification file whose operations are available to the templates")
+	TemplateCreateCmd.Flags().StringVarP(&paramsFile, "params", "",
+		"", "YAML or JSON file with the parameters available to the templates")
+	TemplateCreateCmd.Flags().StringVarP(&basePath, "basepath", "p",
+		"", "Base Path of the API Proxy; defaults to the path of the first server of the spec")
+	TemplateCreateCmd.Flags().StringVarP(&desc, "desc", "d",
+		"", "API Proxy description; defaults to the description of the spec")
+	TemplateCreateCmd.Flags().BoolVarP(&importProxy, "import", "",
+		true, "Import API Proxy after generation from the template")
+	TemplateCreateCmd.Flags().StringVarP(&space, "space", "",
+		"", "Apigee Space to associate to")
+
+	_ = TemplateCreateCmd.MarkFlagRequired("name")
+	_ = TemplateCreateCmd.MarkFlagRequired("template-dir")
+}
@@ -15,6 +15,8 @@
 package apis
 
 import (
+	"fmt"
+
 	"github.com/spf13/cobra"
 )
 
@@ -25,13 +27,23 @@ var TraceCmd = &cobra.Command{
 	Long:  "Manage debusessions of Apigee API proxy revisions deployed in an environment",
 }
 
+// checkEnv is called by the debug session commands that connect to Apigee; the env
+// flag is not required on TraceCmd since view reads a local archive
+func checkEnv() error {
+	if env == "" {
+		return fmt.Errorf(`required flag(s) "env" not set`)
+	}
+	return nil
+}
+
 func init() {
 	TraceCmd.PersistentFlags().StringVarP(&env, "env", "e",
 		"", "Apigee environment name")
 
-	_ = TraceCmd.MarkPersistentFlagRequired("env")
-
 	TraceCmd.AddCommand(CreateTrcCmd)
 	TraceCmd.AddCommand(ListTrcCmd)
 	TraceCmd.AddCommand(GetTrcCmd)
+	TraceCmd.AddCommand(TailTrcCmd)
+	TraceCmd.AddCommand(ExpTrcCmd)
+	TraceCmd.AddCommand(ViewTrcCmd)
 }
@@ -0,0 +1,53 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apis
+
+import (
+	"fmt"
+	"internal/client/debugsessions"
+
+	"github.com/spf13/cobra"
+)
+
+// ViewTrcCmd to render an exported debug session
+var ViewTrcCmd = &cobra.Command{
+	Use:   "view",
+	Short: "View the transactions of an exported debug session",
+	Long: "View the transactions of a debug session archive written by debugsessions export, " +
+		"without connecting to Apigee",
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		m, transactions, err := debugsessions.ReadArchive(archiveFile)
+		if err != nil {
+			return err
+		}
+		w := cmd.OutOrStdout()
+		fmt.Fprintf(w, "Debug session %s of %s revision %d in %s, %d transactions\n\n",
+			m.Session, m.Proxy, m.Revision, m.Environment, len(transactions))
+		for _, t := range transactions {
+			debugsessions.Render(w, t)
+		}
+		debugsessions.RenderTimings(w, debugsessions.Timings(transactions))
+		return nil
+	},
+}
+
+func init() {
+	ViewTrcCmd.Flags().StringVarP(&archiveFile, "file", "f",
+		"", "Archive written by debugsessions export")
+
+	_ = ViewTrcCmd.MarkFlagRequired("file")
+}
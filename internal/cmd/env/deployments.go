@@ -33,4 +33,5 @@ func init() {
 
 	DeployCmd.AddCommand(GetDeployCmd)
 	DeployCmd.AddCommand(GetConfigCmd)
+	DeployCmd.AddCommand(WaitDeployCmd)
 }
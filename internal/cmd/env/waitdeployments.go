@@ -0,0 +1,116 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package env
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// WaitDeployCmd to wait for several deployments
+var WaitDeployCmd = &cobra.Command{
+	Use:   "wait",
+	Short: "Wait for proxy and sharedflow deployments to complete",
+	Long: "Wait for several proxy and sharedflow deployments to complete and print a summary. " +
+		"Deployments are set as [environment/]name[:revision]; the environment defaults to --env " +
+		"and the revision defaults to the revisions deployed to the environment",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if len(waitProxies) == 0 && len(waitSharedFlows) == 0 {
+			return fmt.Errorf("at least one proxy or sharedflow must be set")
+		}
+		apiclient.SetApigeeEnv(environment)
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		targets := []apiclient.DeploymentTarget{}
+		for _, deployment := range waitProxies {
+			t, err := parseDeploymentTarget("apis", deployment)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, t...)
+		}
+		for _, deployment := range waitSharedFlows {
+			t, err := parseDeploymentTarget("sharedflows", deployment)
+			if err != nil {
+				return err
+			}
+			targets = append(targets, t...)
+		}
+
+		statuses, err := apiclient.WaitForDeployments(targets, waitInterval, waitTimeout)
+		fmt.Fprint(cmd.OutOrStdout(), apiclient.DeploymentSummary(statuses))
+		return err
+	},
+}
+
+var (
+	waitProxies, waitSharedFlows []string
+	waitInterval, waitTimeout    time.Duration
+)
+
+func init() {
+	WaitDeployCmd.Flags().StringArrayVarP(&waitProxies, "proxies", "",
+		[]string{}, "API Proxy deployments as [environment/]name[:revision]")
+	WaitDeployCmd.Flags().StringArrayVarP(&waitSharedFlows, "sharedflows", "",
+		[]string{}, "Sharedflow deployments as [environment/]name[:revision]")
+	WaitDeployCmd.Flags().DurationVarP(&waitInterval, "interval", "",
+		10*time.Second, "Time between deployment status checks")
+	WaitDeployCmd.Flags().DurationVarP(&waitTimeout, "timeout", "",
+		0, "Maximum time to wait for the deployments, for ex: 5m; default is no limit")
+}
+
+// parseDeploymentTarget parses [environment/]name[:revision]. Without a revision,
+// every revision deployed to the environment is returned
+func parseDeploymentTarget(entityType string, deployment string) (targets []apiclient.DeploymentTarget, err error) {
+	t := apiclient.DeploymentTarget{EntityType: entityType, Environment: environment}
+	name := deployment
+	if i := strings.Index(name, "/"); i >= 0 {
+		t.Environment, name = name[:i], name[i+1:]
+	}
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		if t.Revision, err = strconv.Atoi(name[i+1:]); err != nil {
+			return nil, fmt.Errorf("invalid revision in %s", deployment)
+		}
+		name = name[:i]
+	}
+	if t.Name = name; t.Name == "" || t.Environment == "" {
+		return nil, fmt.Errorf("invalid deployment %s", deployment)
+	}
+	if t.Revision > 0 {
+		return []apiclient.DeploymentTarget{t}, nil
+	}
+
+	revisions, err := apiclient.DeployedRevisions(entityType, t.Environment, t.Name)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, fmt.Errorf("%s is not deployed to %s", t.Name, t.Environment)
+	}
+	for _, revision := range revisions {
+		t.Revision = revision
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
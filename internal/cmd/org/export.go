@@ -16,6 +16,7 @@ package org
 
 import (
 	"encoding/json"
+	"fmt"
 	"internal/apiclient"
 	"internal/client/apicategories"
 	"internal/client/apidocs"
@@ -66,6 +67,10 @@ var ExportCmd = &cobra.Command{
 
 		runtimeType, _ := orgs.GetOrgField("runtimeType")
 
+		if cleanPath && incremental {
+			return fmt.Errorf("clean and incremental cannot be used together")
+		}
+
 		if cleanPath {
 			if err = cleanExportData(); err != nil {
 				return err
@@ -77,12 +82,12 @@ var ExportCmd = &cobra.Command{
 		}
 
 		clilog.Info.Println("Exporting API Proxies...")
-		if err = apis.ExportProxies(conn, proxiesFolderName, allRevisions, space); proceedOnError(err) != nil {
+		if err = apis.ExportProxies(conn, proxiesFolderName, allRevisions, space, incremental); proceedOnError(err) != nil {
 			return err
 		}
 
 		clilog.Info.Println("Exporting Sharedflows...")
-		if err = sharedflows.Export(conn, sharedFlowsFolderName, allRevisions, space); proceedOnError(err) != nil {
+		if err = sharedflows.Export(conn, sharedFlowsFolderName, allRevisions, space, incremental); proceedOnError(err) != nil {
 			return err
 		}
 
@@ -282,6 +287,17 @@ var ExportCmd = &cobra.Command{
 				return err
 			}
 
+			clilog.Info.Println("\tExporting deployments and flowhooks...")
+			if respBody, err = env.ExportDeployments(); proceedOnError(err) != nil {
+				return err
+			}
+			if err = apiclient.WriteByteArrayToFile(
+				environment.Name+utils.DefaultFileSplitter+deploymentsFileName,
+				false,
+				respBody); proceedOnError(err) != nil {
+				return err
+			}
+
 			if environment.Type != "BASE" {
 				clilog.Info.Printf("\tExporting KV Map names for environment...\n")
 				if listKVMBytes, err = kvm.List(""); proceedOnError(err) != nil {
@@ -329,7 +345,7 @@ var ExportCmd = &cobra.Command{
 	},
 }
 
-var allRevisions, continueOnErr, cleanPath, exportEntries bool
+var allRevisions, continueOnErr, cleanPath, exportEntries, incremental bool
 
 func init() {
 	ExportCmd.Flags().StringVarP(&org, "org", "o",
@@ -349,19 +365,22 @@ func init() {
 			"Applies to proxies, sf and sec profiles")
 	ExportCmd.Flags().BoolVarP(&continueOnErr, "continue-on-error", "",
 		false, "Ignore errors and continue exporting data")
+	ExportCmd.Flags().BoolVarP(&incremental, "incremental", "",
+		false, "Reuse the proxy and sharedflow bundles of a prior export; download only new "+
+			"or changed revisions and remove bundles of deleted entities")
 }
 
 func createFolders() (err error) {
-	if err = os.Mkdir(proxiesFolderName, 0o755); err != nil {
-		return err
-	}
-	if err = os.Mkdir(sharedFlowsFolderName, 0o755); err != nil {
-		return err
-	}
-	if err = os.Mkdir(portalsFolderName, 0o755); err != nil {
-		return err
+	for _, folderName := range []string{
+		proxiesFolderName, sharedFlowsFolderName,
+		portalsFolderName, securityProfilesFolderName,
+	} {
+		// an incremental export reuses the folders of a prior export
+		if err = os.Mkdir(folderName, 0o755); err != nil && !(incremental && os.IsExist(err)) {
+			return err
+		}
 	}
-	return os.Mkdir(securityProfilesFolderName, 0o755)
+	return nil
 }
 
 func exportKVMEntries(scope string, env string, listKVMBytes []byte) (err error) {
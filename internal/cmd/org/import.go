@@ -18,7 +18,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"internal/apiclient"
+	"internal/client/apicategories"
+	"internal/client/apidocs"
 	"internal/client/apis"
+	"internal/client/appgroups"
 	"internal/client/apps"
 	"internal/client/datacollectors"
 	"internal/client/developers"
@@ -29,14 +32,17 @@ import (
 	"internal/client/orgs"
 	"internal/client/products"
 	"internal/client/references"
+	"internal/client/reports"
 	"internal/client/securityprofiles"
 	"internal/client/sharedflows"
+	"internal/client/sites"
 	"internal/client/targetservers"
 	"internal/clilog"
 	"internal/cmd/utils"
 	"io"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -113,6 +119,27 @@ var ImportCmd = &cobra.Command{
 			}
 		}
 
+		if utils.FileExists(path.Join(folder, appGroupsFileName)) {
+			clilog.Info.Println("Importing AppGroups...")
+			if err = appgroups.Import(conn, path.Join(folder, appGroupsFileName)); err != nil {
+				return err
+			}
+
+			if utils.FileExists(path.Join(folder, appGroupsAppsFileName)) {
+				clilog.Info.Println("Importing AppGroups Apps...")
+				if err = appgroups.ImportAllApps(conn, path.Join(folder, appGroupsAppsFileName)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if utils.FileExists(path.Join(folder, portalsFolderName)) {
+			clilog.Info.Println("Importing API Portal apicategories and apidocs Configuration...")
+			if err = importPortals(path.Join(folder, portalsFolderName)); err != nil {
+				return err
+			}
+		}
+
 		if utils.FileExists(path.Join(folder, envGroupsFileName)) {
 			clilog.Info.Println("Importing Environment Group Configuration...")
 			if err = envgroups.Import(path.Join(folder, envGroupsFileName)); err != nil {
@@ -127,6 +154,13 @@ var ImportCmd = &cobra.Command{
 			}
 		}
 
+		if utils.FileExists(path.Join(folder, customReportsName)) {
+			clilog.Info.Println("Importing analytics custom reports...")
+			if err = reports.Import(path.Join(folder, customReportsName)); err != nil {
+				return err
+			}
+		}
+
 		if orgs.GetAddOn("apiSecurityConfig") {
 			clilog.Info.Println("Importing Security Profile Configuration...")
 			if err = securityprofiles.Import(conn, path.Join(folder, securityProfilesFolderName)); err != nil {
@@ -215,6 +249,16 @@ var ImportCmd = &cobra.Command{
 					}
 				}
 			}
+
+			if importDeployments {
+				if utils.FileExists(path.Join(folder, environment+utils.DefaultFileSplitter+deploymentsFileName)) {
+					clilog.Info.Println("\tImporting deployments and flowhooks...")
+					if err = env.ImportDeployments(path.Join(folder,
+						environment+utils.DefaultFileSplitter+deploymentsFileName)); err != nil {
+						return err
+					}
+				}
+			}
 		}
 
 		return err
@@ -222,8 +266,8 @@ var ImportCmd = &cobra.Command{
 }
 
 var (
-	importTrace, importDebugmask bool
-	folder                       string
+	importTrace, importDebugmask, importDeployments bool
+	folder                                          string
 )
 
 func init() {
@@ -237,6 +281,8 @@ func init() {
 		false, "Import distributed trace configuration; default false")
 	ImportCmd.Flags().BoolVarP(&importDebugmask, "import-debugmask", "",
 		false, "Import debugmask configuration; default false")
+	ImportCmd.Flags().BoolVarP(&importDeployments, "deploy", "",
+		false, "Deploy the exported proxy and sharedflow revisions and attach flowhooks; default false")
 	ImportCmd.Flags().StringVarP(&space, "space", "",
 		"", "Apigee Space to associate imported resources")
 
@@ -258,3 +304,54 @@ func readEntityFileAsString(filePath string) (string, error) {
 
 	return string(byteValue), nil
 }
+
+// importPortals imports the apicategories and apidocs of each exported site into the
+// matching site of the org. Category IDs assigned by the site are remapped in the apidocs
+func importPortals(portalsFolder string) error {
+	siteIDs, err := sites.GetSiteIDs()
+	if err != nil {
+		return err
+	}
+	files, err := os.ReadDir(portalsFolder)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		if file.IsDir() || !strings.HasPrefix(file.Name(), "site"+utils.DefaultFileSplitter) {
+			continue
+		}
+		srcSiteID := strings.TrimSuffix(strings.TrimPrefix(file.Name(), "site"+utils.DefaultFileSplitter), ".json")
+		siteID := matchSiteID(siteIDs, srcSiteID)
+		if siteID == "" {
+			clilog.Warning.Printf("No site in the org matches site %s, skipping its apidocs\n", srcSiteID)
+			continue
+		}
+		var categoryIDs map[string]string
+		if categoryFile := path.Join(portalsFolder, "apicategory_"+srcSiteID+".json"); utils.FileExists(categoryFile) {
+			clilog.Info.Printf("\tImporting apicategories of site %s to %s\n", srcSiteID, siteID)
+			if categoryIDs, err = apicategories.ImportAndMapIDs(siteID, categoryFile); err != nil {
+				return err
+			}
+		}
+		clilog.Info.Printf("\tImporting apidocs of site %s to %s\n", srcSiteID, siteID)
+		if err = apidocs.Import(siteID, srcSiteID, portalsFolder, categoryIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchSiteID returns the org site for an exported site. Site IDs are the org name
+// and the site name joined by a hyphen, so sites are matched by the site name
+func matchSiteID(siteIDs []string, srcSiteID string) (siteID string) {
+	for _, id := range siteIDs {
+		if id == srcSiteID {
+			return id
+		}
+		siteName := strings.TrimPrefix(id, apiclient.GetApigeeOrg()+"-")
+		if strings.HasSuffix(srcSiteID, "-"+siteName) && len(id) > len(siteID) {
+			siteID = id
+		}
+	}
+	return siteID
+}
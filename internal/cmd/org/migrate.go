@@ -0,0 +1,110 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package org
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/migrate"
+	"internal/clilog"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// MigrateCmd to copy entities between orgs
+var MigrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Copy proxies, products and developers to another org",
+	Long: "Copy the selected proxies, products and developers, with their dependencies, to another org. " +
+		"Products bring their proxies, developers bring their apps and the apps' products, " +
+		"and proxies bring the sharedflows they call",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetRegion(region)
+		if len(migrateFilter.Proxies) == 0 && len(migrateFilter.Products) == 0 &&
+			len(migrateFilter.Developers) == 0 && len(migrateFilter.EnvGroups) == 0 {
+			return fmt.Errorf("at least one of proxies, products, developers or envgroups must be set")
+		}
+		if sourceOrg == targetOrg {
+			return fmt.Errorf("source-org and target-org must be different")
+		}
+		return apiclient.SetApigeeOrg(sourceOrg)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		migrateFolder := folder
+		if migrateFolder == "" {
+			if migrateFolder, err = os.MkdirTemp("", "apigeecli-migrate"); err != nil {
+				return err
+			}
+			defer os.RemoveAll(migrateFolder)
+		} else if entries, err := os.ReadDir(migrateFolder); err == nil && len(entries) > 0 {
+			return fmt.Errorf("folder %s is not empty", migrateFolder)
+		}
+
+		apiclient.DisableCmdPrintHttpResponse()
+
+		s, err := migrate.Run(migrate.Options{
+			Filter:      migrateFilter,
+			SourceOrg:   sourceOrg,
+			TargetOrg:   targetOrg,
+			EnvMap:      envMap,
+			EnvGroupMap: envGroupMap,
+			Folder:      migrateFolder,
+			Conn:        conn,
+			Space:       space,
+		})
+		if err != nil {
+			return err
+		}
+		clilog.Info.Printf("Migrated %s from %s to %s\n", s, sourceOrg, targetOrg)
+		return nil
+	},
+}
+
+var (
+	sourceOrg, targetOrg string
+	migrateFilter        migrate.Filter
+	envMap, envGroupMap  map[string]string
+)
+
+func init() {
+	MigrateCmd.Flags().StringVarP(&sourceOrg, "source-org", "",
+		"", "Apigee organization to copy from")
+	MigrateCmd.Flags().StringVarP(&targetOrg, "target-org", "",
+		"", "Apigee organization to copy to")
+	MigrateCmd.Flags().StringArrayVarP(&migrateFilter.Proxies, "proxies", "",
+		[]string{}, "API Proxies to migrate; the latest revision is copied")
+	MigrateCmd.Flags().StringArrayVarP(&migrateFilter.Products, "products", "",
+		[]string{}, "API Products to migrate, with their proxies")
+	MigrateCmd.Flags().StringArrayVarP(&migrateFilter.Developers, "developers", "",
+		[]string{}, "Developer emails to migrate, with their apps and the apps' products")
+	MigrateCmd.Flags().StringArrayVarP(&migrateFilter.EnvGroups, "envgroups", "",
+		[]string{}, "Environment groups to migrate, with their hostnames and attachments")
+	MigrateCmd.Flags().StringToStringVar(&envMap, "env-map",
+		map[string]string{}, "Rename environments, for ex: prod=dr-prod")
+	MigrateCmd.Flags().StringToStringVar(&envGroupMap, "envgroup-map",
+		map[string]string{}, "Rename environment groups, for ex: prod-group=dr-group")
+	MigrateCmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Empty folder to keep the migrated entities; default is a temporary folder")
+	MigrateCmd.Flags().IntVarP(&conn, "conn", "c",
+		4, "Number of connections")
+	MigrateCmd.Flags().StringVarP(&space, "space", "",
+		"", "Apigee Space to associate imported resources")
+
+	_ = MigrateCmd.MarkFlagRequired("source-org")
+	_ = MigrateCmd.MarkFlagRequired("target-org")
+}
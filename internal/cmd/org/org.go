@@ -47,4 +47,5 @@ func init() {
 	Cmd.AddCommand(ReportCmd)
 	Cmd.AddCommand(DelCmd)
 	Cmd.AddCommand(DeployCmd)
+	Cmd.AddCommand(MigrateCmd)
 }
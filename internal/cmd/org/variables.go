@@ -31,6 +31,7 @@ const (
 	referencesFileName    = "references.json"
 	envFileName           = "envs.json"
 	customReportsName     = "customreports.json"
+	deploymentsFileName   = "deployments.json"
 
 	proxiesFolderName          = "proxies"
 	sharedFlowsFolderName      = "sharedflows"
@@ -0,0 +1,52 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"fmt"
+	"internal/apiclient"
+	"internal/client/plan"
+
+	"github.com/spf13/cobra"
+)
+
+// ApplyCmd to make the org match a folder
+var ApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Make the org match a folder",
+	Long: "Compare a folder, in the layout written by org export, with the org and create, " +
+		"update and delete entities in dependency order. Run plan first to review the changes",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		p, err := build()
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), p)
+		if p.Empty() {
+			return nil
+		}
+		return plan.Apply(p)
+	},
+}
+
+func init() {
+	addFlags(ApplyCmd)
+}
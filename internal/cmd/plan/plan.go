@@ -0,0 +1,91 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"errors"
+	"fmt"
+	"internal/apiclient"
+	"internal/client/plan"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Cmd to compare a folder with the org
+var Cmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Show the changes needed to make the org match a folder",
+	Long: "Compare a folder, in the layout written by org export, with the org and print " +
+		"the create, update and delete actions that apply would perform. Covers products, developers, apps, " +
+		"target servers, references, KVMs, keystores, env groups and data collectors",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		apiclient.SetRegion(region)
+		return apiclient.SetApigeeOrg(org)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+
+		p, err := build()
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(cmd.OutOrStdout(), p)
+		if detailedExitCode && !p.Empty() {
+			// the plan is already printed, only the exit status must change
+			cmd.SilenceErrors = true
+			return ErrChanges
+		}
+		return nil
+	},
+}
+
+// ErrChanges is returned with --detailed-exitcode when the plan has changes,
+// main exits with status 2 for it
+var ErrChanges = errors.New("the org does not match the folder")
+
+var (
+	org, region, folder     string
+	conn                    int
+	prune, detailedExitCode bool
+)
+
+func init() {
+	Cmd.Flags().BoolVarP(&detailedExitCode, "detailed-exitcode", "",
+		false, "Exit with status 2 when there are changes")
+	addFlags(Cmd)
+}
+
+func addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&org, "org", "o",
+		"", "Apigee organization name")
+	cmd.Flags().StringVarP(&region, "region", "r",
+		"", "Apigee control plane region name; default is https://apigee.googleapis.com")
+	cmd.Flags().StringVarP(&folder, "folder", "f",
+		"", "Folder with the desired org configuration, in the layout written by org export")
+	cmd.Flags().IntVarP(&conn, "conn", "c",
+		4, "Number of connections")
+	cmd.Flags().BoolVarP(&prune, "prune", "",
+		false, "Delete entities that are not in the folder")
+
+	_ = cmd.MarkFlagRequired("folder")
+}
+
+func build() (p plan.Plan, err error) {
+	if stat, err := os.Stat(folder); err != nil || !stat.IsDir() {
+		return p, fmt.Errorf("supplied path is not a folder")
+	}
+	return plan.Build(folder, conn, prune)
+}
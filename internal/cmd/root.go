@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"internal/apiclient"
 	"internal/clilog"
+	"internal/cmd/analytics"
 	"internal/cmd/apicategories"
 	"internal/cmd/apidocs"
 	"internal/cmd/apihub"
@@ -40,6 +41,7 @@ import (
 	"internal/cmd/observe"
 	"internal/cmd/ops"
 	"internal/cmd/org"
+	"internal/cmd/plan"
 	"internal/cmd/preferences"
 	"internal/cmd/products"
 	"internal/cmd/projects"
@@ -55,7 +57,10 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"os/signal"
 	"strconv"
+	"syscall"
+	"time"
 
 	cache "internal/cmd/cache"
 
@@ -101,6 +106,18 @@ var RootCmd = &cobra.Command{
 		}
 
 		apiclient.SetAPI(api)
+		apiclient.SetMaxAttempts(maxAttempts)
+		apiclient.SetMaxRetryWait(maxRetryWait)
+		apiclient.SetRequestTimeout(requestTimeout)
+
+		ctx := cmd.Context()
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		if deadline > 0 {
+			ctx, cancelDeadline = context.WithTimeout(ctx, deadline)
+		}
+		apiclient.SetContext(ctx)
 
 		if !metadataToken && !defaultToken {
 			apiclient.SetServiceAccount(serviceAccount)
@@ -124,15 +141,32 @@ var RootCmd = &cobra.Command{
 }
 
 func Execute() {
-	if err := RootCmd.Execute(); err != nil {
+	ctx, stop := NotifyContext()
+	defer stop()
+	if err := RootCmd.ExecuteContext(ctx); err != nil {
 		clilog.Error.Println(err)
 	}
 }
 
+// NotifyContext returns a context that is canceled on SIGINT or SIGTERM, or when
+// the --deadline elapses. Call stop to release it
+func NotifyContext() (ctx context.Context, stop context.CancelFunc) {
+	ctx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	return ctx, func() {
+		if cancelDeadline != nil {
+			cancelDeadline()
+		}
+		stopSignals()
+	}
+}
+
 var (
 	accessToken, serviceAccount                                                  string
 	disableCheck, printOutput, noOutput, metadataToken, defaultToken, noWarnings bool
 	api                                                                          apiclient.API
+	maxAttempts                                                                  int
+	maxRetryWait, requestTimeout, deadline                                       time.Duration
+	cancelDeadline                                                               context.CancelFunc
 )
 
 const ENABLED = "true"
@@ -167,6 +201,18 @@ func init() {
 	RootCmd.PersistentFlags().Var(&api, "api", "Sets the control plane API. Must be one of prod, autopush "+
 		"or staging; default is prod")
 
+	RootCmd.PersistentFlags().IntVarP(&maxAttempts, "max-attempts", "",
+		apiclient.DefaultMaxAttempts, "Max number of attempts for a request that fails with 429 or 5xx; 1 disables retries")
+
+	RootCmd.PersistentFlags().DurationVarP(&maxRetryWait, "max-retry-wait", "",
+		apiclient.DefaultMaxRetryWait, "Max time to wait between two attempts of a request")
+
+	RootCmd.PersistentFlags().DurationVarP(&requestTimeout, "request-timeout", "",
+		0, "Time limit for each request, for ex: 30s; default is no limit")
+
+	RootCmd.PersistentFlags().DurationVarP(&deadline, "deadline", "",
+		0, "Time limit for the whole command, for ex: 10m; default is no limit")
+
 	RootCmd.AddCommand(apis.Cmd)
 	RootCmd.AddCommand(org.Cmd)
 	RootCmd.AddCommand(sync.Cmd)
@@ -203,6 +249,9 @@ func init() {
 	RootCmd.AddCommand(observe.Cmd)
 	RootCmd.AddCommand(tree.Cmd)
 	RootCmd.AddCommand(reports.Cmd)
+	RootCmd.AddCommand(analytics.Cmd)
+	RootCmd.AddCommand(plan.Cmd)
+	RootCmd.AddCommand(plan.ApplyCmd)
 }
 
 func initConfig() {
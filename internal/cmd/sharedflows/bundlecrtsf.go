@@ -109,7 +109,7 @@ var BundleCreateCmd = &cobra.Command{
 				return err
 			}
 			if wait {
-				return Wait(name, revision)
+				return Wait(name, revision, waitTimeout)
 			}
 		}
 		return err
@@ -133,6 +133,8 @@ func init() {
 		false, "Forces deployment of the new revision")
 	BundleCreateCmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the deployment to finish, with success or error")
+	BundleCreateCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the deployment, for ex: 5m; default is no limit")
 	BundleCreateCmd.Flags().StringVarP(&serviceAccountName, "sa", "s",
 		"", "The format must be {ACCOUNT_ID}@{PROJECT}.iam.gserviceaccount.com.")
 	BundleCreateCmd.Flags().StringVarP(&desc, "desc", "d",
@@ -18,7 +18,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"internal/apiclient"
-	"internal/client/sharedflows"
 	"internal/clilog"
 	"strconv"
 	"time"
@@ -38,39 +37,16 @@ func GetRevision(respBody []byte) (revision int, err error) {
 	return apiProxyRev, nil
 }
 
-func Wait(name string, revision int) error {
-	var err error
-
-	apiclient.DisableCmdPrintHttpResponse()
-
+// Wait polls the deployment of the sharedflow revision in the environment until it
+// completes. A timeout of 0 waits until the deployment completes
+func Wait(name string, revision int, timeout time.Duration) error {
 	clilog.Info.Printf("Checking deployment status in %d seconds\n", interval)
 
-	stop := apiclient.Every(interval*time.Second, func(time.Time) bool {
-		var respBody []byte
-		respMap := make(map[string]interface{})
-		if respBody, err = sharedflows.ListRevisionDeployments(name, revision); err != nil {
-			clilog.Error.Printf("Error fetching sharedflow revision status: %v", err)
-			return false
-		}
-
-		if err = json.Unmarshal(respBody, &respMap); err != nil {
-			return true
-		}
-
-		switch respMap["state"] {
-		case "PROGRESSING":
-			clilog.Info.Printf("Sharedflow deployment status is: %s. Waiting %d seconds.\n", respMap["state"], interval)
-			return true
-		case "READY":
-			clilog.Info.Println("Sharedflow deployment completed with status: ", respMap["state"])
-		default:
-			clilog.Info.Println("Sharedflow deployment failed with status: ", respMap["state"])
-			err = fmt.Errorf("Sharedflow deployment failed with status: %s", respMap["state"])
-		}
-		return false
-	})
-
-	<-stop
-
+	_, err := apiclient.WaitForDeployments([]apiclient.DeploymentTarget{{
+		EntityType:  "sharedflows",
+		Name:        name,
+		Revision:    revision,
+		Environment: apiclient.GetApigeeEnv(),
+	}}, interval*time.Second, timeout)
 	return err
 }
@@ -17,6 +17,7 @@ package sharedflows
 import (
 	"internal/apiclient"
 	"internal/client/sharedflows"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -47,7 +48,7 @@ var DepCmd = &cobra.Command{
 		}
 
 		if wait {
-			err = Wait(name, revision)
+			err = Wait(name, revision, waitTimeout)
 		}
 
 		return err
@@ -57,6 +58,7 @@ var DepCmd = &cobra.Command{
 var (
 	overrides, wait    bool
 	serviceAccountName string
+	waitTimeout        time.Duration
 )
 
 const interval = 10
@@ -72,6 +74,8 @@ func init() {
 		false, "Forces deployment of the new revision")
 	DepCmd.Flags().BoolVarP(&wait, "wait", "",
 		false, "Waits for the deployment to finish, with success or error")
+	DepCmd.Flags().DurationVarP(&waitTimeout, "wait-timeout", "",
+		0, "Maximum time to wait for the deployment, for ex: 5m; default is no limit")
 
 	DepCmd.Flags().StringVarP(&serviceAccountName, "sa", "s",
 		"", "The format must be {ACCOUNT_ID}@{PROJECT}.iam.gserviceaccount.com.")
@@ -37,11 +37,11 @@ var ExpCmd = &cobra.Command{
 		if err = apiclient.FolderExists(folder); err != nil {
 			return err
 		}
-		return sharedflows.Export(conn, folder, allRevisions, space)
+		return sharedflows.Export(conn, folder, allRevisions, space, incremental)
 	},
 }
 
-var allRevisions bool
+var allRevisions, incremental bool
 
 func init() {
 	ExpCmd.Flags().IntVarP(&conn, "conn", "c",
@@ -52,4 +52,7 @@ func init() {
 		false, "Export all proxy revisions")
 	ExpCmd.Flags().StringVarP(&space, "space", "",
 		"", "Apigee Space associated to")
+	ExpCmd.Flags().BoolVarP(&incremental, "incremental", "",
+		false, "Download only new or changed revisions and remove bundles of deleted sharedflows; "+
+			"uses the manifest.json file in the folder")
 }
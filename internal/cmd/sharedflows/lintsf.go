@@ -0,0 +1,60 @@
+// Copyright 2026 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharedflows
+
+import (
+	"fmt"
+	"internal/bundlegen/lint"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// LintCmd to lint a sharedflow bundle
+var LintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Lint a sharedflow bundle",
+	Long: "Lint a sharedflow bundle (zip or folder) without connecting to Apigee. Reports undefined " +
+		"and unused policies, steps referencing missing policies, hard-coded credentials and, " +
+		"with an export folder, KVMs that do not exist",
+	Args: func(cmd *cobra.Command, args []string) (err error) {
+		if (sfZip == "") == (sfFolder == "") {
+			return fmt.Errorf("either sharedflow bundle (zip) or folder must be specified, not both")
+		}
+		return lint.CheckFormat(lintOutput)
+	},
+	RunE: func(cmd *cobra.Command, args []string) (err error) {
+		cmd.SilenceUsage = true
+		bundlePath := sfZip
+		if sfFolder != "" {
+			bundlePath = sfFolder
+		}
+		return lint.Run(os.Stdout, bundlePath, lint.Options{ExportFolder: exportFolder}, lintOutput)
+	},
+	Example: `Lint a sharedflow folder: ` + GetExample(1),
+}
+
+var exportFolder, lintOutput string
+
+func init() {
+	LintCmd.Flags().StringVarP(&sfZip, "sf-zip", "p",
+		"", "Path to the Sharedflow bundle/zip file")
+	LintCmd.Flags().StringVarP(&sfFolder, "sf-folder", "f",
+		"", "Path to the Sharedflow Bundle; ex: ./test/sharedflowbundle")
+	LintCmd.Flags().StringVarP(&exportFolder, "export-folder", "",
+		"", "Folder created by organizations export, to check KVMs")
+	LintCmd.Flags().StringVarP(&lintOutput, "output", "",
+		"text", "Output format: text, json or sarif")
+}
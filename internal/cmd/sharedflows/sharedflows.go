@@ -30,7 +30,11 @@ var (
 	conn, revision                int
 )
 
-var examples = []string{"apigeecli sharedflows import -f samples/sharedflows"}
+var examples = []string{
+	"apigeecli sharedflows import -f samples/sharedflows",
+	"apigeecli sharedflows lint -f ./sharedflowbundle --output=json",
+	"apigeecli sharedflows diff --from=security@3 --to=./sharedflowbundle --xml --default-token",
+}
 
 func init() {
 	Cmd.PersistentFlags().StringVarP(&org, "org", "o",
@@ -50,6 +54,9 @@ func init() {
 	Cmd.AddCommand(CleanCmd)
 	Cmd.AddCommand(ListDepCmd)
 	Cmd.AddCommand(MoveCmd)
+	Cmd.AddCommand(TraceCmd)
+	Cmd.AddCommand(LintCmd)
+	Cmd.AddCommand(DiffCmd)
 }
 
 func GetExample(i int) string {